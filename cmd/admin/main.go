@@ -0,0 +1,71 @@
+package main
+
+import (
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/repository"
+	"BACKEND/internal/services"
+	"BACKEND/internal/validators"
+	"BACKEND/pkg/config"
+	"BACKEND/pkg/database"
+	"BACKEND/pkg/utils"
+	"context"
+	"flag"
+	"fmt"
+	"github.com/go-playground/validator/v10"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"os"
+	"strings"
+	"time"
+)
+
+// Создает администратора или обновляет пароль и роли существующего.
+// Запускается из директории cmd, чтобы подхватить .env:
+// go run ./admin -email admin@example.com -roles content_moderator,support
+// Пароль берется из флага -password или переменной окружения ADMIN_PASSWORD
+func main() {
+	email := flag.String("email", "", "Admin email")
+	password := flag.String("password", os.Getenv("ADMIN_PASSWORD"), "Admin password")
+	roles := flag.String("roles", strings.Join(utils.AdminRoles, ","), "Comma separated admin roles")
+	flag.Parse()
+
+	logger := zerolog.New(os.Stdout).With().Timestamp().Logger()
+
+	config.InitConfig()
+
+	auth := dto.Auth{Email: *email, Password: *password}
+
+	validate := validator.New()
+	validate.RegisterValidation("password", validators.ValidatePassword)
+	if err := validate.Struct(auth); err != nil {
+		fmt.Println(validators.ValidationError(err, &dto.Auth{}))
+		os.Exit(1)
+	}
+
+	var adminRoles []string
+	for _, role := range strings.Split(*roles, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			adminRoles = append(adminRoles, role)
+		}
+	}
+
+	db := database.GetDB()
+	defer db.Close()
+
+	dbResponseTime := time.Duration(viper.GetInt(config.DBResponseTime)) * time.Second
+	// Вход через команду не выполняется, поэтому ограничитель попыток и Redis не нужны
+	adminService := services.InitAdminService(repository.InitAdminRepo(db), nil, dbResponseTime, logger)
+
+	id, err := adminService.Save(context.Background(), domain.AdminCreate{
+		Email:    auth.Email,
+		Password: auth.Password,
+		Roles:    adminRoles,
+	})
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	fmt.Printf("Admin %s saved with id %d and roles %v\n", auth.Email, id, adminRoles)
+}
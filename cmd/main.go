@@ -8,12 +8,23 @@ import (
 	"BACKEND/pkg/database"
 	"BACKEND/pkg/log"
 	"BACKEND/pkg/utils"
+	"context"
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 )
 
+const defaultShutdownTime = 15 * time.Second
+
 func main() {
 	router := gin.Default()
 
@@ -29,16 +40,75 @@ func main() {
 	db := database.GetDB()
 	logger.Info().Msg("Database Initialized")
 
-	middleWarrior := middleware.InitMiddleware(logger)
+	jwtUtil := utils.InitJWTUtil()
+	go reloadJWTKeys(jwtUtil, logger)
+	logger.Info().Msg("JWT keys Initialized")
+
+	session := utils.InitRedisSession()
+	logger.Info().Msg("Session storage Initialized")
+
+	loginLimiter := utils.InitLoginLimiter()
+	logger.Info().Msg("Login limiter Initialized")
+
+	broker := utils.InitBroker()
+	logger.Info().Msg("Chat broker Initialized")
+
+	presence := utils.InitPresence()
+	logger.Info().Msg("Chat presence Initialized")
 
-	routers.InitRouting(router, db, middleWarrior, utils.InitJWTUtil(), utils.InitRedisSession(), logger)
+	middleWarrior := middleware.InitMiddleware(jwtUtil, session, logger)
+
+	chatServer := routers.InitRouting(router, db, middleWarrior, jwtUtil, session, loginLimiter, broker, presence, utils.InitMailer(), logger)
 	logger.Info().Msg("Routing Initialized")
 
 	docs.SwaggerInfo.BasePath = "/"
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 	logger.Info().Msg("Swagger Initialized")
 
-	if err := router.Run("0.0.0.0:8080"); err != nil {
-		panic(fmt.Sprintf("Failed to run client: %s", err.Error()))
+	server := &http.Server{
+		Addr:    "0.0.0.0:8080",
+		Handler: router,
+	}
+
+	stop, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			panic(fmt.Sprintf("Failed to run client: %s", err.Error()))
+		}
+	}()
+
+	<-stop.Done()
+	logger.Info().Msg("Shutting down ...")
+
+	// Сначала перестают приниматься новые запросы, затем закрываются подключения чата, которые Shutdown не отслеживает
+	shutdownTime := time.Duration(viper.GetInt(config.ShutdownTime)) * time.Second
+	if shutdownTime <= 0 {
+		shutdownTime = defaultShutdownTime
+	}
+
+	ctx, cancelShutdown := context.WithTimeout(context.Background(), shutdownTime)
+	defer cancelShutdown()
+
+	if err := server.Shutdown(ctx); err != nil {
+		logger.Error().Msg(fmt.Sprintf("Failed to shut down server: %v", err))
+	}
+	chatServer.Shutdown(ctx)
+
+	logger.Info().Msg("Server stopped")
+}
+
+// reloadJWTKeys подхватывает добавленные и удаленные ключи подписи без перезапуска
+func reloadJWTKeys(jwtUtil utils.JWT, logger zerolog.Logger) {
+	reloadTime := time.Duration(viper.GetInt(config.JWTKeysReloadTime)) * time.Minute
+	if reloadTime <= 0 {
+		return
+	}
+
+	for range time.Tick(reloadTime) {
+		if err := jwtUtil.ReloadKeys(); err != nil {
+			logger.Error().Msg(fmt.Sprintf("Failed to reload jwt keys: %v", err))
+		}
 	}
 }
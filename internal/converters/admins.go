@@ -0,0 +1,24 @@
+package converters
+
+import (
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+)
+
+type AdminConverter interface {
+	AdminDomainToDTO(admin domain.Admin) dto.Admin
+}
+
+type adminConverter struct{}
+
+func InitAdminConverter() AdminConverter {
+	return &adminConverter{}
+}
+
+func (a adminConverter) AdminDomainToDTO(admin domain.Admin) dto.Admin {
+	return dto.Admin{
+		ID:    admin.ID,
+		Email: admin.Email,
+		Roles: admin.Roles,
+	}
+}
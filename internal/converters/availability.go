@@ -0,0 +1,90 @@
+package converters
+
+import (
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+	"time"
+)
+
+type AvailabilityConverter interface {
+	AvailabilityIntervalsDTOToDomain(intervals []dto.AvailabilityInterval) []domain.AvailabilityInterval
+	AvailabilityExceptionCreateDTOToDomain(exception dto.AvailabilityExceptionCreate, trainerID int) domain.AvailabilityException
+
+	AvailabilityDomainToDTO(availability domain.Availability, loc *time.Location) dto.Availability
+	SlotsDomainToDTO(slots []domain.Slot, loc *time.Location) []dto.Slot
+}
+
+type availabilityConverter struct {
+}
+
+func InitAvailabilityConverter() AvailabilityConverter {
+	return &availabilityConverter{}
+}
+
+// DTO -> Domain
+
+func (a availabilityConverter) AvailabilityIntervalsDTOToDomain(intervals []dto.AvailabilityInterval) []domain.AvailabilityInterval {
+	result := make([]domain.AvailabilityInterval, len(intervals))
+
+	for i, interval := range intervals {
+		result[i] = domain.AvailabilityInterval{
+			Weekday:   interval.Weekday,
+			TimeStart: interval.TimeStart,
+			TimeEnd:   interval.TimeEnd,
+		}
+	}
+
+	return result
+}
+
+func (a availabilityConverter) AvailabilityExceptionCreateDTOToDomain(exception dto.AvailabilityExceptionCreate, trainerID int) domain.AvailabilityException {
+	return domain.AvailabilityException{
+		TrainerID: trainerID,
+		DateStart: exception.DateStart,
+		DateEnd:   exception.DateEnd,
+		Reason:    getNullString(exception.Reason),
+	}
+}
+
+// Domain -> DTO
+
+func (a availabilityConverter) AvailabilityDomainToDTO(availability domain.Availability, loc *time.Location) dto.Availability {
+	weekly := make([]dto.AvailabilityInterval, len(availability.Weekly))
+	for i, interval := range availability.Weekly {
+		weekly[i] = dto.AvailabilityInterval{
+			Weekday:   interval.Weekday,
+			TimeStart: interval.TimeStart,
+			TimeEnd:   interval.TimeEnd,
+		}
+	}
+
+	exceptions := make([]dto.AvailabilityException, len(availability.Exceptions))
+	for i, exception := range availability.Exceptions {
+		exceptions[i] = dto.AvailabilityException{
+			ID:        exception.ID,
+			DateStart: exception.DateStart,
+			DateEnd:   exception.DateEnd,
+			Reason:    getStringPointer(exception.Reason),
+		}
+	}
+
+	return dto.Availability{
+		Weekly:     weekly,
+		Exceptions: exceptions,
+		Busy:       a.SlotsDomainToDTO(availability.Busy, loc),
+	}
+}
+
+// SlotsDomainToDTO показывает промежутки в часовом поясе loc того, кто их запрашивает
+func (a availabilityConverter) SlotsDomainToDTO(slots []domain.Slot, loc *time.Location) []dto.Slot {
+	result := make([]dto.Slot, len(slots))
+
+	for i, slot := range slots {
+		result[i] = dto.Slot{
+			TimeStart: slot.TimeStart.In(loc),
+			TimeEnd:   slot.TimeEnd.In(loc),
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,59 @@
+package converters
+
+import (
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+	"time"
+)
+
+type CalendarConverter interface {
+	CalendarEventDomainToDTO(event domain.CalendarEvent, loc *time.Location) dto.CalendarEvent
+	CalendarEventsDomainToDTO(events []domain.CalendarEvent, loc *time.Location) []dto.CalendarEvent
+}
+
+type calendarConverter struct {
+}
+
+func InitCalendarConverter() CalendarConverter {
+	return &calendarConverter{}
+}
+
+// CalendarEventDomainToDTO показывает событие в часовом поясе loc владельца календаря
+func (c calendarConverter) CalendarEventDomainToDTO(event domain.CalendarEvent, loc *time.Location) dto.CalendarEvent {
+	timeStart := event.TimeStart.In(loc)
+
+	result := dto.CalendarEvent{
+		ID:             event.ID,
+		Type:           event.Type,
+		Title:          event.Title,
+		Date:           getDayStart(timeStart),
+		TimeStart:      timeStart,
+		TimeEnd:        event.TimeEnd.In(loc),
+		TrainingID:     getIntPointer(event.TrainingID),
+		ContractID:     getIntPointer(event.ContractID),
+		SeriesID:       getIntPointer(event.SeriesID),
+		OccurrenceDate: getTimePointer(event.OccurrenceDate),
+	}
+
+	// Собеседник есть только у занятий с тренером
+	if event.Counterpart.ID.Valid {
+		result.Counterpart = &dto.CalendarCounterpart{
+			ID:        int(event.Counterpart.ID.Int64),
+			FirstName: event.Counterpart.FirstName.String,
+			LastName:  event.Counterpart.LastName.String,
+			PhotoUrl:  getStringPointer(event.Counterpart.PhotoUrl),
+		}
+	}
+
+	return result
+}
+
+func (c calendarConverter) CalendarEventsDomainToDTO(events []domain.CalendarEvent, loc *time.Location) []dto.CalendarEvent {
+	result := make([]dto.CalendarEvent, len(events))
+
+	for i, event := range events {
+		result[i] = c.CalendarEventDomainToDTO(event, loc)
+	}
+
+	return result
+}
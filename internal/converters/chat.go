@@ -3,7 +3,7 @@ package converters
 import (
 	"BACKEND/internal/models/domain"
 	"BACKEND/internal/models/dto"
-	"time"
+	"gopkg.in/guregu/null.v3"
 )
 
 type ChatConverter interface {
@@ -12,9 +12,16 @@ type ChatConverter interface {
 	MessagePaginationDomainToDTO(pagination domain.MessagePagination) dto.MessagePagination
 	ChatDomainToDTO(chat domain.Chat) dto.Chat
 	ChatsDomainToDTO(chats []domain.Chat) []dto.Chat
+	MessageEditsDomainToDTO(edits []domain.MessageEdit) []dto.MessageEdit
+	AttachmentDomainToDTO(attachment domain.Attachment) dto.Attachment
+	AttachmentsDomainToDTO(attachments []domain.Attachment) []dto.Attachment
+	MessageFoundDomainToDTO(message domain.MessageFound) dto.MessageFound
+	MessageFoundPaginationDomainToDTO(pagination domain.MessageFoundPagination) dto.MessageFoundPagination
+	BlockedDomainToDTO(blocked []domain.BlockedAccount) []dto.BlockedAccount
+	PresenceDomainToDTO(presence domain.Presence) dto.Presence
+	PresencesDomainToDTO(presences []domain.Presence) []dto.Presence
 
 	MessageGetToMessageCreate(message domain.MessageGet, isTrainer bool, userID int) domain.MessageCreate
-	MessageCreateToMessage(message domain.MessageCreate, id int, t time.Time) domain.Message
 }
 
 type chatConverter struct {
@@ -28,14 +35,32 @@ func InitChatConverter() ChatConverter {
 }
 
 func (c chatConverter) MessageDomainToDTO(message domain.Message) dto.Message {
+	// Текст, услуга и вложения удаленного сообщения не отдаются
+	if message.DeletedAt != nil {
+		message.Message = nil
+		message.ServiceID = nil
+		message.Attachments = nil
+	}
+
 	return dto.Message{
-		ID:        message.ID,
-		UserID:    message.UserID,
-		TrainerID: message.TrainerID,
-		Message:   message.Message,
-		Service:   message.ServiceID,
-		IsToUser:  message.IsToUser,
-		Time:      message.Time,
+		ID:             message.ID,
+		ClientID:       message.ClientID,
+		UserID:         message.UserID,
+		TrainerID:      message.TrainerID,
+		Message:        message.Message,
+		Service:        message.ServiceID,
+		IsToUser:       message.IsToUser,
+		Time:           message.Time,
+		DeliveredAt:    message.DeliveredAt,
+		ReadAt:         message.ReadAt,
+		ReplyToID:      message.ReplyToID,
+		EditedAt:       message.EditedAt,
+		DeletedAt:      message.DeletedAt,
+		Attachments:    c.AttachmentsDomainToDTO(message.Attachments),
+		OfferStatus:    message.OfferStatus,
+		OfferExpiresAt: message.OfferExpiresAt,
+		ContractID:     message.ContractID,
+		IsSystem:       message.IsSystem,
 	}
 }
 
@@ -64,6 +89,7 @@ func (c chatConverter) ChatDomainToDTO(chat domain.Chat) dto.Chat {
 		LastName:        chat.LastName,
 		LastMessage:     chat.LastMessage,
 		TimeLastMessage: chat.TimeLastMessage,
+		UnreadCount:     chat.UnreadCount,
 	}
 }
 
@@ -77,13 +103,119 @@ func (c chatConverter) ChatsDomainToDTO(chats []domain.Chat) []dto.Chat {
 	return result
 }
 
+func (c chatConverter) MessageEditsDomainToDTO(edits []domain.MessageEdit) []dto.MessageEdit {
+	result := make([]dto.MessageEdit, len(edits))
+
+	for i, edit := range edits {
+		result[i] = dto.MessageEdit{
+			Message:  getStringPointer(edit.Message),
+			EditedAt: edit.EditedAt,
+		}
+	}
+
+	return result
+}
+
+func (c chatConverter) AttachmentDomainToDTO(attachment domain.Attachment) dto.Attachment {
+	return dto.Attachment{
+		ID:           attachment.ID,
+		Kind:         attachment.Kind,
+		FileUrl:      attachment.FileUrl,
+		ThumbnailUrl: getStringPointer(attachment.ThumbnailUrl),
+		Name:         attachment.Name,
+		ContentType:  attachment.ContentType,
+		Size:         attachment.Size,
+		CreatedAt:    attachment.CreatedAt,
+	}
+}
+
+func (c chatConverter) AttachmentsDomainToDTO(attachments []domain.Attachment) []dto.Attachment {
+	result := make([]dto.Attachment, len(attachments))
+
+	for i, attachment := range attachments {
+		result[i] = c.AttachmentDomainToDTO(attachment)
+	}
+
+	return result
+}
+
+func (c chatConverter) MessageFoundDomainToDTO(message domain.MessageFound) dto.MessageFound {
+	return dto.MessageFound{
+		ID:        message.ID,
+		UserID:    message.UserID,
+		TrainerID: message.TrainerID,
+		IsToUser:  message.IsToUser,
+		Time:      message.Time,
+		ChatID:    message.ChatID,
+		PhotoUrl:  getStringPointer(message.PhotoUrl),
+		FirstName: message.FirstName,
+		LastName:  message.LastName,
+		Highlight: message.Highlight,
+		Cursor:    message.ID,
+	}
+}
+
+func (c chatConverter) MessageFoundPaginationDomainToDTO(pagination domain.MessageFoundPagination) dto.MessageFoundPagination {
+	messages := make([]dto.MessageFound, 0, len(pagination.Messages))
+	for _, message := range pagination.Messages {
+		messages = append(messages, c.MessageFoundDomainToDTO(message))
+	}
+
+	return dto.MessageFoundPagination{
+		Messages: messages,
+		Cursor:   pagination.Cursor,
+	}
+}
+
+func (c chatConverter) BlockedDomainToDTO(blocked []domain.BlockedAccount) []dto.BlockedAccount {
+	result := make([]dto.BlockedAccount, len(blocked))
+
+	for i, account := range blocked {
+		result[i] = dto.BlockedAccount{
+			ID:        account.ID,
+			PhotoUrl:  getStringPointer(account.PhotoUrl),
+			FirstName: account.FirstName,
+			LastName:  account.LastName,
+			CreatedAt: account.CreatedAt,
+		}
+	}
+
+	return result
+}
+
+func (c chatConverter) PresenceDomainToDTO(presence domain.Presence) dto.Presence {
+	return dto.Presence{
+		ID:         presence.ID,
+		IsOnline:   presence.IsOnline,
+		LastSeenAt: getTimePointer(presence.LastSeenAt),
+	}
+}
+
+func (c chatConverter) PresencesDomainToDTO(presences []domain.Presence) []dto.Presence {
+	result := make([]dto.Presence, len(presences))
+
+	for i, presence := range presences {
+		result[i] = c.PresenceDomainToDTO(presence)
+	}
+
+	return result
+}
+
 func (c chatConverter) MessageGetToMessageCreate(message domain.MessageGet, isTrainer bool, userID int) domain.MessageCreate {
 	var messageCreate domain.MessageCreate
 
+	messageCreate.ClientID = getNullString(message.ClientID)
 	messageCreate.Message = getNullString(message.Message)
 	messageCreate.ServiceID = getNullInt(message.ServiceID)
+	messageCreate.ReplyToID = getNullInt(message.ReplyToID)
+	messageCreate.AttachmentIDs = message.AttachmentIDs
 	messageCreate.IsToUser = isTrainer
 
+	// Услуга в сообщении тренера - предложение
+	if isTrainer && message.ServiceID != nil && message.OfferExpiresAt != nil {
+		messageCreate.OfferExpiresAt = null.TimeFrom(*message.OfferExpiresAt)
+	}
+
 	if isTrainer {
 		messageCreate.UserID = message.To
 		messageCreate.TrainerID = userID
@@ -94,15 +226,3 @@ func (c chatConverter) MessageGetToMessageCreate(message domain.MessageGet, isTr
 
 	return messageCreate
 }
-
-func (c chatConverter) MessageCreateToMessage(message domain.MessageCreate, id int, t time.Time) domain.Message {
-	return domain.Message{
-		ID:        id,
-		UserID:    message.UserID,
-		TrainerID: message.TrainerID,
-		Message:   getStringPointer(message.Message),
-		ServiceID: getIntPointer(message.ServiceID),
-		IsToUser:  message.IsToUser,
-		Time:      t,
-	}
-}
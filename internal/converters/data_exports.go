@@ -0,0 +1,27 @@
+package converters
+
+import (
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+)
+
+type DataExportConverter interface {
+	DataExportDomainToDTO(export domain.DataExport) dto.DataExport
+}
+
+type dataExportConverter struct{}
+
+func InitDataExportConverter() DataExportConverter {
+	return &dataExportConverter{}
+}
+
+// Domain -> DTO
+
+func (d dataExportConverter) DataExportDomainToDTO(export domain.DataExport) dto.DataExport {
+	return dto.DataExport{
+		ID:        export.ID,
+		Status:    export.Status,
+		CreatedAt: export.CreatedAt,
+		ReadyAt:   getTimePointer(export.ReadyAt),
+	}
+}
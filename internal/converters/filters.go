@@ -3,11 +3,16 @@ package converters
 import (
 	"BACKEND/internal/models/domain"
 	"BACKEND/internal/models/dto"
+	"time"
 )
 
 type FilterConverter interface {
 	FilterTrainerDTOToDomain(filter dto.FiltersTrainerCovers) domain.FiltersTrainerCovers
 	FiltersProgressDTOToDomain(filter dto.FiltersProgress, userID int) domain.FiltersProgress
+	FiltersTrainerApplicationsDTOToDomain(filter dto.FiltersTrainerApplications) domain.FiltersTrainerApplications
+	FiltersMessageReportsDTOToDomain(filter dto.FiltersMessageReports) domain.FiltersMessageReports
+	FiltersSlotsDTOToDomain(filter dto.FiltersSlots, trainerID int, viewer domain.Caller) domain.FiltersSlots
+	FiltersCalendarDTOToDomain(filter dto.FiltersCalendar, accountID int, accountType string) domain.FiltersCalendar
 }
 
 type filterConverter struct {
@@ -26,6 +31,45 @@ func (f filterConverter) FilterTrainerDTOToDomain(filter dto.FiltersTrainerCover
 	}
 }
 
+func (f filterConverter) FiltersTrainerApplicationsDTOToDomain(filter dto.FiltersTrainerApplications) domain.FiltersTrainerApplications {
+	return domain.FiltersTrainerApplications{
+		Status: filter.Status,
+		Cursor: filter.Cursor,
+	}
+}
+
+func (f filterConverter) FiltersMessageReportsDTOToDomain(filter dto.FiltersMessageReports) domain.FiltersMessageReports {
+	return domain.FiltersMessageReports{
+		Status: filter.Status,
+		Cursor: filter.Cursor,
+	}
+}
+
+// FiltersSlotsDTOToDomain по умолчанию делит свободное время на слоты по часу
+func (f filterConverter) FiltersSlotsDTOToDomain(filter dto.FiltersSlots, trainerID int, viewer domain.Caller) domain.FiltersSlots {
+	duration := filter.Duration
+	if duration == 0 {
+		duration = 60
+	}
+
+	return domain.FiltersSlots{
+		TrainerID: trainerID,
+		Viewer:    viewer,
+		DateStart: filter.DateStart,
+		DateEnd:   filter.DateEnd,
+		Duration:  time.Duration(duration) * time.Minute,
+	}
+}
+
+func (f filterConverter) FiltersCalendarDTOToDomain(filter dto.FiltersCalendar, accountID int, accountType string) domain.FiltersCalendar {
+	return domain.FiltersCalendar{
+		AccountID:   accountID,
+		AccountType: accountType,
+		DateStart:   filter.DateStart,
+		DateEnd:     filter.DateEnd,
+	}
+}
+
 func (f filterConverter) FiltersProgressDTOToDomain(filter dto.FiltersProgress, userID int) domain.FiltersProgress {
 	return domain.FiltersProgress{
 		UserID:    userID,
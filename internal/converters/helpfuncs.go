@@ -3,6 +3,7 @@ package converters
 import (
 	"gopkg.in/guregu/null.v3"
 	"strconv"
+	"time"
 )
 
 func getStringPointer(s null.String) *string {
@@ -68,3 +69,21 @@ func getBoolPointer(b null.Bool) *bool {
 	}
 	return nil
 }
+
+func getTimePointer(t null.Time) *time.Time {
+	if t.Valid {
+		return &t.Time
+	}
+	return nil
+}
+
+// getLocalDateTime складывает день date и время суток clock в местное время без зоны, представленное в UTC.
+// В часовой пояс аккаунта его переводит сервис
+func getLocalDateTime(date, clock time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, time.UTC)
+}
+
+// getDayStart возвращает начало дня t в его часовом поясе
+func getDayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,52 @@
+package converters
+
+import (
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+)
+
+type ModerationConverter interface {
+	MessageReportDomainToDTO(report domain.MessageReport) dto.MessageReport
+	MessageReportPaginationDomainToDTO(reports domain.MessageReportPagination) dto.MessageReportPagination
+}
+
+type moderationConverter struct {
+	chatConverter ChatConverter
+}
+
+func InitModerationConverter() ModerationConverter {
+	return &moderationConverter{
+		chatConverter: InitChatConverter(),
+	}
+}
+
+func (m moderationConverter) MessageReportDomainToDTO(report domain.MessageReport) dto.MessageReport {
+	message := m.chatConverter.MessageDomainToDTO(report.Message)
+	// Модератор видит текст сообщения, даже если автор удалил его после жалобы
+	message.Message = report.Message.Message
+
+	return dto.MessageReport{
+		ID:         report.ID,
+		UserID:     getIntPointer(report.UserID),
+		TrainerID:  getIntPointer(report.TrainerID),
+		Reason:     report.Reason,
+		Status:     report.Status,
+		ReviewedBy: getIntPointer(report.ReviewedBy),
+		CreatedAt:  report.CreatedAt,
+		ReviewedAt: getTimePointer(report.ReviewedAt),
+		Message:    message,
+	}
+}
+
+func (m moderationConverter) MessageReportPaginationDomainToDTO(reports domain.MessageReportPagination) dto.MessageReportPagination {
+	result := make([]dto.MessageReport, len(reports.Reports))
+
+	for i, report := range reports.Reports {
+		result[i] = m.MessageReportDomainToDTO(report)
+	}
+
+	return dto.MessageReportPagination{
+		Reports: result,
+		Cursor:  reports.Cursor,
+	}
+}
@@ -0,0 +1,37 @@
+package converters
+
+import (
+	"BACKEND/internal/models/dto"
+	"BACKEND/pkg/utils"
+)
+
+type SessionConverter interface {
+	SessionDataToDTO(session utils.SessionData, currentSessionID string) dto.Session
+	SessionsDataToDTO(sessions []utils.SessionData, currentSessionID string) []dto.Session
+}
+
+type sessionConverter struct{}
+
+func InitSessionConverter() SessionConverter {
+	return &sessionConverter{}
+}
+
+func (s sessionConverter) SessionDataToDTO(session utils.SessionData, currentSessionID string) dto.Session {
+	return dto.Session{
+		ID:         session.ID,
+		Device:     session.Device,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		IsCurrent:  session.ID == currentSessionID,
+	}
+}
+
+func (s sessionConverter) SessionsDataToDTO(sessions []utils.SessionData, currentSessionID string) []dto.Session {
+	result := make([]dto.Session, 0, len(sessions))
+	for _, session := range sessions {
+		result = append(result, s.SessionDataToDTO(session, currentSessionID))
+	}
+
+	return result
+}
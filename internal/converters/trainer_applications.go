@@ -0,0 +1,86 @@
+package converters
+
+import (
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+)
+
+type TrainerApplicationConverter interface {
+	TrainerApplicationCreateDTOToDomain(application dto.TrainerApplicationCreate, certificates []domain.Certificate) domain.TrainerApplicationCreate
+
+	TrainerApplicationCoverDomainToDTO(application domain.TrainerApplicationCover) dto.TrainerApplicationCover
+	TrainerApplicationCoverPaginationDomainToDTO(applications domain.TrainerApplicationCoverPagination) dto.TrainerApplicationCoverPagination
+	TrainerApplicationDomainToDTO(application domain.TrainerApplication) dto.TrainerApplication
+}
+
+type trainerApplicationConverter struct {
+	baseConverter    BaseConverter
+	trainerConverter TrainerConverter
+}
+
+func InitTrainerApplicationConverter() TrainerApplicationConverter {
+	return &trainerApplicationConverter{
+		baseConverter:    InitBaseConverter(),
+		trainerConverter: InitTrainerConverter(),
+	}
+}
+
+// DTO -> Domain
+
+func (t trainerApplicationConverter) TrainerApplicationCreateDTOToDomain(application dto.TrainerApplicationCreate, certificates []domain.Certificate) domain.TrainerApplicationCreate {
+	return domain.TrainerApplicationCreate{
+		TrainerBase:       t.trainerConverter.TrainerBaseDTOToDomain(application.TrainerBase),
+		Email:             application.Email,
+		RoleIDs:           application.RoleIDs,
+		SpecializationIDs: application.SpecializationIDs,
+		Certificates:      certificates,
+	}
+}
+
+// Domain -> DTO
+
+func (t trainerApplicationConverter) TrainerApplicationCoverDomainToDTO(application domain.TrainerApplicationCover) dto.TrainerApplicationCover {
+	return dto.TrainerApplicationCover{
+		TrainerBase: t.trainerConverter.TrainerBaseDomainToDTO(application.TrainerBase),
+		ID:          application.ID,
+		Email:       application.Email,
+		Status:      application.Status,
+		CreatedAt:   application.CreatedAt,
+	}
+}
+
+func (t trainerApplicationConverter) TrainerApplicationCoverPaginationDomainToDTO(applications domain.TrainerApplicationCoverPagination) dto.TrainerApplicationCoverPagination {
+	result := make([]dto.TrainerApplicationCover, len(applications.Applications))
+
+	for i, application := range applications.Applications {
+		result[i] = t.TrainerApplicationCoverDomainToDTO(application)
+	}
+
+	return dto.TrainerApplicationCoverPagination{
+		Applications: result,
+		Cursor:       applications.Cursor,
+	}
+}
+
+func (t trainerApplicationConverter) TrainerApplicationDomainToDTO(application domain.TrainerApplication) dto.TrainerApplication {
+	certificates := make([]dto.Certificate, len(application.Certificates))
+
+	for i, certificate := range application.Certificates {
+		certificates[i] = dto.Certificate{
+			ID:   certificate.ID,
+			Name: certificate.Name,
+			Url:  certificate.Url,
+		}
+	}
+
+	return dto.TrainerApplication{
+		TrainerApplicationCover: t.TrainerApplicationCoverDomainToDTO(application.TrainerApplicationCover),
+		Roles:                   t.baseConverter.BasesDomainToDTO(application.Roles),
+		Specializations:         t.baseConverter.BasesDomainToDTO(application.Specializations),
+		Certificates:            certificates,
+		Reason:                  getStringPointer(application.Reason),
+		ReviewedBy:              getIntPointer(application.ReviewedBy),
+		ReviewedAt:              getTimePointer(application.ReviewedAt),
+		TrainerID:               getIntPointer(application.TrainerID),
+	}
+}
@@ -60,6 +60,7 @@ func (t trainerConverter) TrainerUpdateDTOToDomain(trainer dto.TrainerUpdate, tr
 		TrainerBase: t.TrainerBaseDTOToDomain(trainer.TrainerBase),
 		ID:          trainerID,
 		Email:       trainer.Email,
+		Timezone:    getNullString(trainer.Timezone),
 	}
 }
 
@@ -162,5 +163,6 @@ func (t trainerConverter) TrainerDomainToDTO(trainer domain.Trainer) dto.Trainer
 		Services:     t.ServicesDomainToDTO(trainer.Services),
 		Achievements: t.baseConverter.BasesStatusDomainToDTO(trainer.Achievements),
 		Email:        trainer.Email,
+		Timezone:     trainer.Timezone,
 	}
 }
@@ -3,6 +3,7 @@ package converters
 import (
 	"BACKEND/internal/models/domain"
 	"BACKEND/internal/models/dto"
+	"time"
 )
 
 type TrainingConverter interface {
@@ -22,10 +23,8 @@ type TrainingConverter interface {
 	TrainingCoverTrainerPaginationDomainToDTO(exercise domain.TrainingCoverTrainerPagination) dto.TrainingCoverTrainerPagination
 	TrainingDomainToDTO(training domain.Training) dto.Training
 	TrainingTrainerDomainToDTO(training domain.TrainingTrainer) dto.TrainingTrainer
-	TrainingDateDomainToDTO(training domain.UserTraining) dto.UserTraining
-	TrainingsDateDomainToDTO(trainings []domain.UserTraining) []dto.UserTraining
-	TrainingScheduleDomainToDTO(schedule domain.TrainingSchedule) dto.TrainingSchedule
-	TrainingSchedulesDomainToDTO(schedules []domain.TrainingSchedule) []dto.TrainingSchedule
+	TrainingDateDomainToDTO(training domain.UserTraining, loc *time.Location) dto.UserTraining
+	TrainingsDateDomainToDTO(trainings []domain.UserTraining, loc *time.Location) []dto.UserTraining
 	SchedulePlanDomainToDTO(schedule domain.SchedulePlan) dto.SchedulePlan
 	SchedulesPlanDomainToDTO(schedule []domain.SchedulePlan) []dto.SchedulePlan
 	PlanCoverDomainToDTO(plan domain.PlanCover) dto.PlanCover
@@ -44,6 +43,8 @@ type TrainingConverter interface {
 	TrainingCreateDTOToDomain(training dto.TrainingCreate, userID int) domain.TrainingCreate
 	TrainingCreateTrainerDTOToDomain(training dto.TrainingCreateTrainer, trainerID int) domain.TrainingCreateTrainer
 	ScheduleTrainingDTOToDomain(training dto.ScheduleTraining, userID int) domain.ScheduleTraining
+	TrainingSeriesUpdateDTOToDomain(series dto.TrainingSeriesUpdate, seriesID, userID int) domain.TrainingSeries
+	TrainingOccurrenceUpdateDTOToDomain(occurrence dto.TrainingOccurrenceUpdate, seriesID, userID int, occurrenceDate time.Time) domain.TrainingOccurrence
 	ExerciseStepDTOToDomain(exercise dto.ExerciseStep) domain.ExerciseStep
 	ExerciseStepsBasesDTOToDomain(exercises []dto.ExerciseStep) []domain.ExerciseStep
 	PlanCreateDTOToDomain(plan dto.PlanCreate, userID int) domain.PlanCreate
@@ -196,39 +197,25 @@ func (t trainingConverter) TrainingTrainerDomainToDTO(training domain.TrainingTr
 	}
 }
 
-func (t trainingConverter) TrainingDateDomainToDTO(training domain.UserTraining) dto.UserTraining {
+// TrainingDateDomainToDTO показывает время тренировки в часовом поясе loc того, кто ее запрашивает
+func (t trainingConverter) TrainingDateDomainToDTO(training domain.UserTraining, loc *time.Location) dto.UserTraining {
+	timeStart := training.TimeStart.In(loc)
+
 	return dto.UserTraining{
 		Training:   t.TrainingDomainToDTO(training.Training),
 		Exercises:  t.ExercisesDomainToDTO(training.Exercises),
 		TrainingID: training.TrainingID,
-		Date:       training.Date,
-		TimeStart:  training.TimeStart,
-		TimeEnd:    training.TimeEnd,
+		Date:       getDayStart(timeStart),
+		TimeStart:  timeStart,
+		TimeEnd:    training.TimeEnd.In(loc),
 	}
 }
 
-func (t trainingConverter) TrainingsDateDomainToDTO(trainings []domain.UserTraining) []dto.UserTraining {
+func (t trainingConverter) TrainingsDateDomainToDTO(trainings []domain.UserTraining, loc *time.Location) []dto.UserTraining {
 	result := make([]dto.UserTraining, len(trainings))
 
 	for i, training := range trainings {
-		result[i] = t.TrainingDateDomainToDTO(training)
-	}
-
-	return result
-}
-
-func (t trainingConverter) TrainingScheduleDomainToDTO(schedule domain.TrainingSchedule) dto.TrainingSchedule {
-	return dto.TrainingSchedule{
-		Date:        schedule.Date,
-		TrainingIDs: schedule.TrainingIDs,
-	}
-}
-
-func (t trainingConverter) TrainingSchedulesDomainToDTO(schedules []domain.TrainingSchedule) []dto.TrainingSchedule {
-	result := make([]dto.TrainingSchedule, len(schedules))
-
-	for i, schedule := range schedules {
-		result[i] = t.TrainingScheduleDomainToDTO(schedule)
+		result[i] = t.TrainingDateDomainToDTO(training, loc)
 	}
 
 	return result
@@ -422,10 +409,31 @@ func (t trainingConverter) ScheduleTrainingDTOToDomain(training dto.ScheduleTrai
 	return domain.ScheduleTraining{
 		UserID:     userID,
 		TrainingID: training.TrainingID,
-		Date:       training.Date,
-		TimeStart:  training.TimeStart,
-		TimeEnd:    training.TimeEnd,
+		TimeStart:  getLocalDateTime(training.Date, training.TimeStart),
+		TimeEnd:    getLocalDateTime(training.Date, training.TimeEnd),
 		Exercises:  t.ExercisesDetailBasesDTOToDomain(training.Exercises),
+		RRule:      getNullString(training.RRule),
+	}
+}
+
+func (t trainingConverter) TrainingSeriesUpdateDTOToDomain(series dto.TrainingSeriesUpdate, seriesID, userID int) domain.TrainingSeries {
+	return domain.TrainingSeries{
+		ID:        seriesID,
+		UserID:    userID,
+		DateStart: series.Date,
+		TimeStart: series.TimeStart,
+		TimeEnd:   series.TimeEnd,
+		RRule:     series.RRule,
+	}
+}
+
+func (t trainingConverter) TrainingOccurrenceUpdateDTOToDomain(occurrence dto.TrainingOccurrenceUpdate, seriesID, userID int, occurrenceDate time.Time) domain.TrainingOccurrence {
+	return domain.TrainingOccurrence{
+		SeriesID:       seriesID,
+		UserID:         userID,
+		OccurrenceDate: occurrenceDate,
+		TimeStart:      getLocalDateTime(occurrence.Date, occurrence.TimeStart),
+		TimeEnd:        getLocalDateTime(occurrence.Date, occurrence.TimeEnd),
 	}
 }
 
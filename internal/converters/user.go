@@ -47,6 +47,7 @@ func (u userConverter) UserUpdateDTOToDomain(user dto.UserUpdate, userID int) do
 		UserBase: u.UserBaseDTOToDomain(user.UserBase),
 		ID:       userID,
 		Email:    user.Email,
+		Timezone: getNullString(user.Timezone),
 	}
 }
 
@@ -90,5 +91,6 @@ func (u userConverter) UserDomainToDTO(user domain.User) dto.User {
 	return dto.User{
 		UserCover: u.UserCoverDomainToDTO(user.UserCover),
 		Email:     user.Email,
+		Timezone:  user.Timezone,
 	}
 }
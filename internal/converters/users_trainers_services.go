@@ -3,6 +3,7 @@ package converters
 import (
 	"BACKEND/internal/models/domain"
 	"BACKEND/internal/models/dto"
+	"time"
 )
 
 type ServicesConverter interface {
@@ -16,11 +17,9 @@ type ServicesConverter interface {
 	ServiceTrainerDomainToDTO(service domain.ServiceTrainer) dto.ServiceTrainer
 	ServicesTrainerDomainToDTO(services []domain.ServiceTrainer) []dto.ServiceTrainer
 	ServiceTrainerPaginationDomainToDTO(service domain.ServiceTrainerPagination) dto.ServiceTrainerPagination
-	ScheduleDomainToDTO(schedule domain.TrainingSchedule) dto.TrainingSchedule
-	SchedulesDomainToDTO(schedules []domain.TrainingSchedule) []dto.TrainingSchedule
-	ScheduleServiceDomainToDTO(schedule domain.ScheduleService) dto.ScheduleService
-	ScheduleServiceUserDomainToDTO(schedule domain.ScheduleServiceUser) dto.ScheduleServiceUser
-	SchedulesServiceUserDomainToDTO(schedules []domain.ScheduleServiceUser) []dto.ScheduleServiceUser
+	ScheduleServiceDomainToDTO(schedule domain.ScheduleService, loc *time.Location) dto.ScheduleService
+	ScheduleServiceUserDomainToDTO(schedule domain.ScheduleServiceUser, loc *time.Location) dto.ScheduleServiceUser
+	SchedulesServiceUserDomainToDTO(schedules []domain.ScheduleServiceUser, loc *time.Location) []dto.ScheduleServiceUser
 }
 
 type servicesConverter struct {
@@ -46,9 +45,8 @@ func (s servicesConverter) UserTrainerServiceCreateTrainerDTOToDomain(service dt
 func (s servicesConverter) ScheduleServiceDTOToDomain(schedule dto.ScheduleService) domain.ScheduleService {
 	return domain.ScheduleService{
 		ScheduleID: schedule.ScheduleID,
-		Date:       schedule.Date,
-		TimeStart:  schedule.TimeStart,
-		TimeEnd:    schedule.TimeEnd,
+		TimeStart:  getLocalDateTime(schedule.Date, schedule.TimeStart),
+		TimeEnd:    getLocalDateTime(schedule.Date, schedule.TimeEnd),
 	}
 }
 
@@ -118,44 +116,30 @@ func (s servicesConverter) ServiceTrainerPaginationDomainToDTO(service domain.Se
 	}
 }
 
-func (s servicesConverter) ScheduleDomainToDTO(schedule domain.TrainingSchedule) dto.TrainingSchedule {
-	return dto.TrainingSchedule{
-		Date:        schedule.Date,
-		TrainingIDs: schedule.TrainingIDs,
-	}
-}
-
-func (s servicesConverter) SchedulesDomainToDTO(schedules []domain.TrainingSchedule) []dto.TrainingSchedule {
-	result := make([]dto.TrainingSchedule, len(schedules))
-
-	for i, schedule := range schedules {
-		result[i] = s.ScheduleDomainToDTO(schedule)
-	}
-
-	return result
-}
+// ScheduleServiceDomainToDTO показывает время занятия в часовом поясе loc того, кто его запрашивает
+func (s servicesConverter) ScheduleServiceDomainToDTO(schedule domain.ScheduleService, loc *time.Location) dto.ScheduleService {
+	timeStart := schedule.TimeStart.In(loc)
 
-func (s servicesConverter) ScheduleServiceDomainToDTO(schedule domain.ScheduleService) dto.ScheduleService {
 	return dto.ScheduleService{
 		ScheduleID: schedule.ScheduleID,
-		Date:       schedule.Date,
-		TimeStart:  schedule.TimeStart,
-		TimeEnd:    schedule.TimeEnd,
+		Date:       getDayStart(timeStart),
+		TimeStart:  timeStart,
+		TimeEnd:    schedule.TimeEnd.In(loc),
 	}
 }
 
-func (s servicesConverter) ScheduleServiceUserDomainToDTO(schedule domain.ScheduleServiceUser) dto.ScheduleServiceUser {
+func (s servicesConverter) ScheduleServiceUserDomainToDTO(schedule domain.ScheduleServiceUser, loc *time.Location) dto.ScheduleServiceUser {
 	return dto.ScheduleServiceUser{
 		ServiceUser:     s.ServiceUserDomainToDTO(schedule.ServiceUser),
-		ScheduleService: s.ScheduleServiceDomainToDTO(schedule.ScheduleService),
+		ScheduleService: s.ScheduleServiceDomainToDTO(schedule.ScheduleService, loc),
 	}
 }
 
-func (s servicesConverter) SchedulesServiceUserDomainToDTO(schedules []domain.ScheduleServiceUser) []dto.ScheduleServiceUser {
+func (s servicesConverter) SchedulesServiceUserDomainToDTO(schedules []domain.ScheduleServiceUser, loc *time.Location) []dto.ScheduleServiceUser {
 	result := make([]dto.ScheduleServiceUser, len(schedules))
 
 	for i, schedule := range schedules {
-		result[i] = s.ScheduleServiceUserDomainToDTO(schedule)
+		result[i] = s.ScheduleServiceUserDomainToDTO(schedule, loc)
 	}
 
 	return result
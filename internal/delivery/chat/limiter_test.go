@@ -0,0 +1,62 @@
+package chat
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterAllow(t *testing.T) {
+	tests := []struct {
+		name  string
+		rate  float64
+		burst int
+		// Если elapsed задан, корзина сначала опустошается, а последнее пополнение сдвигается на elapsed назад
+		elapsed     time.Duration
+		attempts    int
+		wantAllowed int
+	}{
+		{name: "no limit", rate: 0, burst: 1, attempts: 100, wantAllowed: 100},
+		{name: "negative rate disables limit", rate: -1, burst: 1, attempts: 10, wantAllowed: 10},
+		{name: "full bucket allows burst", rate: 1, burst: 3, attempts: 5, wantAllowed: 3},
+		{name: "partial refill", rate: 2, burst: 5, elapsed: time.Second, attempts: 5, wantAllowed: 2},
+		{name: "fractional tokens are not spent", rate: 1, burst: 5, elapsed: 500 * time.Millisecond, attempts: 3, wantAllowed: 0},
+		{name: "refill is capped by burst", rate: 10, burst: 3, elapsed: 10 * time.Second, attempts: 5, wantAllowed: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLimiter(tt.rate, tt.burst)
+			if tt.elapsed > 0 {
+				l.tokens = 0
+				l.last = time.Now().Add(-tt.elapsed)
+			}
+
+			allowed := 0
+			for i := 0; i < tt.attempts; i++ {
+				if l.allow() {
+					allowed++
+				}
+			}
+
+			if allowed != tt.wantAllowed {
+				t.Errorf("allowed %d of %d, want %d", allowed, tt.attempts, tt.wantAllowed)
+			}
+		})
+	}
+}
+
+func TestLimiterRecoversAfterDenial(t *testing.T) {
+	l := newLimiter(1, 1)
+
+	if !l.allow() {
+		t.Fatal("first event must be allowed")
+	}
+	if l.allow() {
+		t.Fatal("second event must be denied until refill")
+	}
+
+	l.last = l.last.Add(-time.Second)
+	if !l.allow() {
+		t.Error("event must be allowed after a second of refill")
+	}
+}
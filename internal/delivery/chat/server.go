@@ -2,135 +2,311 @@ package chat
 
 import (
 	"BACKEND/internal/converters"
-	"BACKEND/internal/models/domain"
+	"BACKEND/internal/delivery/middleware"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/dto"
 	"BACKEND/internal/services"
-	"BACKEND/pkg/responses"
+	"BACKEND/pkg/config"
 	"BACKEND/pkg/utils"
+	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 )
 
-var upgrader = websocket.Upgrader{
-	ReadBufferSize:  1024,
-	WriteBufferSize: 1024,
-	CheckOrigin: func(r *http.Request) bool {
-		return true
-	},
+const (
+	defaultPingTime       = 30 * time.Second
+	defaultWriteTime      = 10 * time.Second
+	defaultMaxMessageSize = 32 << 10
+	defaultSendQueue      = 256
+)
+
+// Config ограничения подключений чата
+type Config struct {
+	// Подключение в сети, пока от него были события или pong за это время
+	PresenceTime time.Duration
+	PingTime     time.Duration
+	// Подключение закрывается, если от клиента не было событий и pong за это время
+	IdleTime       time.Duration
+	WriteTime      time.Duration
+	MaxMessageSize int64
+	// Событий от подключения в секунду и серия подряд
+	RateLimit float64
+	RateBurst int
+	// Размер очереди событий на отправку, при переполнении подключение закрывается
+	SendQueue int
+	// Разрешенные заголовки Origin, пустой список - только с того же хоста
+	AllowedOrigins []string
+}
+
+func LoadConfig() Config {
+	var origins []string
+	for _, origin := range strings.Split(viper.GetString(config.ChatAllowedOrigins), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+
+	cfg := Config{
+		PresenceTime:   time.Duration(viper.GetInt(config.PresenceTime)) * time.Second,
+		PingTime:       time.Duration(viper.GetInt(config.ChatPingTime)) * time.Second,
+		IdleTime:       time.Duration(viper.GetInt(config.ChatIdleTime)) * time.Second,
+		WriteTime:      time.Duration(viper.GetInt(config.ChatWriteTime)) * time.Second,
+		MaxMessageSize: viper.GetInt64(config.ChatMaxMessageSize),
+		RateLimit:      viper.GetFloat64(config.ChatRateLimit),
+		RateBurst:      viper.GetInt(config.ChatRateBurst),
+		SendQueue:      viper.GetInt(config.ChatSendQueue),
+		AllowedOrigins: origins,
+	}
+
+	// Значения по умолчанию для конфигов без новых переменных
+	if cfg.PingTime <= 0 {
+		cfg.PingTime = defaultPingTime
+	}
+	if cfg.IdleTime <= cfg.PingTime {
+		cfg.IdleTime = cfg.PingTime * 5 / 2
+	}
+	if cfg.WriteTime <= 0 {
+		cfg.WriteTime = defaultWriteTime
+	}
+	if cfg.MaxMessageSize <= 0 {
+		cfg.MaxMessageSize = defaultMaxMessageSize
+	}
+	if cfg.RateBurst <= 0 {
+		cfg.RateBurst = int(cfg.RateLimit) + 1
+	}
+	if cfg.SendQueue <= 0 {
+		cfg.SendQueue = defaultSendQueue
+	}
+
+	return cfg
+}
+
+// connKey идентифицирует аккаунт, к которому относятся подключения
+type connKey struct {
+	isTrainer bool
+	id        int
+}
+
+// Канал брокера, через который экземпляры сервера обмениваются сообщениями
+const messagesChannel = "chat:messages"
+
+// outcome событие чата user_id и trainer_id на отправку вместе с id подключения, с которого оно пришло.
+// IsToUser - событие адресовано пользователю, копия уходит на остальные устройства тренера, и наоборот
+type outcome struct {
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+	UserID    int             `json:"user_id"`
+	TrainerID int             `json:"trainer_id"`
+	IsToUser  bool            `json:"is_to_user"`
+	From      string          `json:"from"`
 }
 
 type Server struct {
-	mu             sync.Mutex
-	users          []*User
-	messagesToSend chan *domain.Message
-	addUsers       chan *User
-	delUsers       chan *User
-	errs           chan error
+	mu       sync.Mutex
+	users    map[connKey]map[*User]struct{}
+	delUsers chan *User
+	errs     chan error
+	// Подключения, еще не удаленные с сервера, ожидаются при остановке
+	conns sync.WaitGroup
 
 	service   services.Chat
+	broker    utils.Broker
+	config    Config
+	upgrader  websocket.Upgrader
 	converter converters.ChatConverter
 	jwtUtil   utils.JWT
+	session   utils.Session
 	logger    zerolog.Logger
 }
 
 func NewServer(
 	service services.Chat,
+	broker utils.Broker,
+	config Config,
 	jwtUtil utils.JWT,
+	session utils.Session,
 	logger zerolog.Logger,
 ) *Server {
-	return &Server{
-		mu:             sync.Mutex{},
-		users:          []*User{},
-		messagesToSend: make(chan *domain.Message, 100),
-		addUsers:       make(chan *User, 100),
-		delUsers:       make(chan *User, 100),
-		errs:           make(chan error, 100),
-		service:        service,
-		converter:      converters.InitChatConverter(),
-		jwtUtil:        jwtUtil,
-		logger:         logger,
+	s := &Server{
+		mu:        sync.Mutex{},
+		users:     map[connKey]map[*User]struct{}{},
+		delUsers:  make(chan *User, 100),
+		errs:      make(chan error, 100),
+		service:   service,
+		broker:    broker,
+		config:    config,
+		converter: converters.InitChatConverter(),
+		jwtUtil:   jwtUtil,
+		session:   session,
+		logger:    logger,
+	}
+
+	s.upgrader = websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 1024,
+	}
+	// Без списка действует проверка gorilla: Origin совпадает с хостом запроса или не передан
+	if len(config.AllowedOrigins) != 0 {
+		s.upgrader.CheckOrigin = s.checkOrigin
+	}
+
+	return s
+}
+
+// checkOrigin пропускает клиентов без Origin (мобильные приложения) и с Origin из CHAT_ALLOWED_ORIGINS
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	for _, allowed := range s.config.AllowedOrigins {
+		if strings.EqualFold(origin, allowed) {
+			return true
+		}
 	}
+
+	return false
 }
 
+// addUser добавляет подключение сразу, а не через Listen, чтобы его удаление не обработалось раньше добавления
 func (s *Server) addUser(user *User) {
-	s.addUsers <- user
+	key := user.key()
+
+	s.mu.Lock()
+	if s.users[key] == nil {
+		s.users[key] = map[*User]struct{}{}
+	}
+	s.users[key][user] = struct{}{}
+	devices := len(s.users[key])
+	s.mu.Unlock()
+
+	s.conns.Add(1)
+	s.logger.Info().Msg(fmt.Sprintf("User %d (is trainer: %t) added to server, devices: %d", user.id, user.isTrainer, devices))
 }
 
 func (s *Server) delUser(user *User) {
 	s.delUsers <- user
 }
 
-func (s *Server) sendMessage(message *domain.Message) {
-	s.messagesToSend <- message
+// publish отправляет событие в брокер, доставляют его все экземпляры сервера своим подключениям
+func (s *Server) publish(ctx context.Context, eventType string, data interface{}, userID, trainerID int, isToUser bool, from string) error {
+	dataBytes, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(outcome{
+		Type:      eventType,
+		Data:      dataBytes,
+		UserID:    userID,
+		TrainerID: trainerID,
+		IsToUser:  isToUser,
+		From:      from,
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.broker.Publish(ctx, messagesChannel, payload)
+}
+
+// Notify рассылает изменение сообщения, сделанное не через WS, во все подключения обоих собеседников.
+// Изменение уже сохранено, поэтому ошибка рассылки только логируется
+func (s *Server) Notify(ctx context.Context, eventType string, message dto.Message) {
+	if err := s.publish(ctx, eventType, message, message.UserID, message.TrainerID, message.IsToUser, ""); err != nil {
+		s.logger.Error().Msg(fmt.Sprintf("WS error: %s", err.Error()))
+	}
 }
 
 func (s *Server) err(err error) {
 	s.errs <- err
 }
 
-func (s *Server) send(message *domain.Message) {
-	var userTo *User
+// fanOut отправляет сообщение во все подключения аккаунта, кроме except, и возвращает число получателей
+func (s *Server) fanOut(key connKey, message OutcomeMessage, except string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	if message.IsToUser {
-		for _, user := range s.users {
-			if !user.isTrainer && user.id == message.UserID {
-				userTo = user
-				break
-			}
-		}
-	} else {
-		for _, user := range s.users {
-			if user.isTrainer && user.id == message.TrainerID {
-				userTo = user
-				break
-			}
+	sent := 0
+	for user := range s.users[key] {
+		if user.connID == except {
+			continue
 		}
+		user.send(message)
+		sent++
 	}
 
-	if userTo == nil {
-		if message.IsToUser {
-			s.logger.Info().Msg(fmt.Sprintf("User %d is offline", message.UserID))
-		} else {
-			s.logger.Info().Msg(fmt.Sprintf("User %d is offline", message.TrainerID))
-		}
-		return
+	return sent
+}
+
+func (s *Server) send(out outcome) {
+	message := OutcomeMessage{Type: out.Type, Data: out.Data}
+
+	// Получатель и отправитель события
+	to := connKey{isTrainer: false, id: out.UserID}
+	from := connKey{isTrainer: true, id: out.TrainerID}
+	if !out.IsToUser {
+		to, from = from, to
 	}
 
-	userTo.income <- message
-	if message.IsToUser {
-		s.logger.Info().Msg(fmt.Sprintf("Message to user %d is send", message.UserID))
+	// Офлайн получатель получит сообщения при следующем подключении
+	if s.fanOut(to, message, "") == 0 {
+		s.logger.Info().Msg(fmt.Sprintf("User %d (is trainer: %t) is offline", to.id, to.isTrainer))
 	} else {
-		s.logger.Info().Msg(fmt.Sprintf("Message to user %d is send", message.TrainerID))
+		s.logger.Info().Msg(fmt.Sprintf("Event %s to user %d (is trainer: %t) is send", out.Type, to.id, to.isTrainer))
 	}
+
+	// Копия события на остальные устройства отправителя
+	s.fanOut(from, message, out.From)
 }
 
 func (s *Server) Listen() {
+	incoming, err := s.broker.Subscribe(context.Background(), messagesChannel)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to subscribe to chat broker: %s", err.Error()))
+	}
+
 	s.logger.Info().Msg("Start listen ...")
 	for {
 		select {
-		case user := <-s.addUsers:
+		case user := <-s.delUsers:
+			key := user.key()
 			s.mu.Lock()
-			s.users = append(s.users, user)
+			_, ok := s.users[key][user]
+			if ok {
+				delete(s.users[key], user)
+				if len(s.users[key]) == 0 {
+					delete(s.users, key)
+				}
+			}
 			s.mu.Unlock()
-			s.logger.Info().Msg(fmt.Sprintf("User %d (is trainer: %t) added to server\n", user.id, user.isTrainer))
-		case user := <-s.delUsers:
-			for i, delUser := range s.users {
-				if delUser.id == user.id {
-					s.mu.Lock()
-					s.users = append(s.users[:i], s.users[i+1:]...)
-					s.mu.Unlock()
-					delUser.conn.Close()
-					s.logger.Info().Msg(fmt.Sprintf("User %d (is trainer: %t) deleted from server\n", user.id, user.isTrainer))
-					break
+			if ok {
+				user.conn.Close()
+				if err := s.service.SetOffline(context.Background(), user.id, user.userType(), user.connID); err != nil {
+					s.logger.Error().Msg(fmt.Sprintf("WS error: %s", err.Error()))
 				}
+				s.logger.Info().Msg(fmt.Sprintf("User %d (is trainer: %t) deleted from server", user.id, user.isTrainer))
+				s.conns.Done()
 			}
 		case err := <-s.errs:
 			s.logger.Error().Msg(fmt.Sprintf("WS error: %s\n", err.Error()))
-		case message := <-s.messagesToSend:
-			s.send(message)
+		case payload := <-incoming:
+			var out outcome
+			if err = json.Unmarshal(payload, &out); err != nil {
+				s.logger.Error().Msg(fmt.Sprintf("Bad message from chat broker: %s", err.Error()))
+				continue
+			}
+			s.send(out)
 		}
 	}
 }
@@ -139,29 +315,65 @@ func (s *Server) ChatHandler(c *gin.Context) {
 	accessToken := c.GetHeader("Sec-WebSocket-Protocol")
 	if accessToken == "" {
 		s.logger.Error().Msg("No jwt provided")
-		c.AbortWithStatusJSON(http.StatusBadRequest, responses.MessageResponse{Message: "No jwt provided"})
+		middleware.Abort(c, errs.NeedToAuth)
 		return
 	}
 
 	userData, isValid, err := s.jwtUtil.Authorize(accessToken, utils.User, utils.Trainer)
 	if err != nil {
 		s.logger.Error().Msg(fmt.Sprintf("Troubles while getting user info from jwt: %v", err))
-		c.AbortWithStatusJSON(http.StatusBadRequest, responses.MessageResponse{Message: "Bad jwt provided"})
+		middleware.Abort(c, errs.ErrBadJWT)
 		return
 	}
 
 	if !isValid {
 		s.logger.Error().Msg("Access token is expired or invalid or user type mismatch")
-		c.AbortWithStatusJSON(http.StatusUnauthorized, responses.MessageResponse{Message: "Access token is expired or invalid"})
+		middleware.Abort(c, errs.ErrInvalidJWT)
+		return
+	}
+
+	session, isActive, err := s.session.Check(c.Request.Context(), userData.SessionID)
+	if err != nil {
+		s.logger.Error().Msg(fmt.Sprintf("Troubles while checking session: %v", err))
+		middleware.Abort(c, err)
 		return
 	}
 
-	conn, err := upgrader.Upgrade(c.Writer, c.Request, http.Header{
+	if !isActive {
+		s.logger.Error().Msg(fmt.Sprintf("Session %s is revoked", userData.SessionID))
+		middleware.Abort(c, errs.ErrSessionRevoked)
+		return
+	}
+
+	if session.Suspended() {
+		s.logger.Error().Msg(fmt.Sprintf("%s %d is suspended", userData.UserType, userData.ID))
+		middleware.Abort(c, errs.ErrSuspended)
+		return
+	}
+
+	if !session.IsVerified {
+		s.logger.Error().Msg(fmt.Sprintf("%s %d is not verified", userData.UserType, userData.ID))
+		middleware.Abort(c, errs.ErrNotVerified)
+		return
+	}
+
+	// id последнего полученного сообщения, чтобы дослать все после него. Без него досылаются недоставленные
+	var lastID int
+	if lastIDStr := c.Query("last_id"); lastIDStr != "" {
+		lastID, err = strconv.Atoi(lastIDStr)
+		if err != nil || lastID < 0 {
+			middleware.Abort(c, errs.ErrBadQuery)
+			return
+		}
+	}
+
+	// При ошибке Upgrade уже ответил клиенту
+	conn, err := s.upgrader.Upgrade(c.Writer, c.Request, http.Header{
 		"Sec-WebSocket-Protocol": []string{accessToken},
 	})
-
 	if err != nil {
-		s.errs <- err
+		s.logger.Error().Msg(fmt.Sprintf("WS upgrade error: %s", err.Error()))
+		return
 	}
 
 	s.logger.Info().Msg(fmt.Sprintf("User %d connected", userData.ID))
@@ -171,8 +383,40 @@ func (s *Server) ChatHandler(c *gin.Context) {
 		isTrainer = true
 	}
 
-	user := NewUser(userData.ID, isTrainer, conn, s)
+	user := NewUser(userData.ID, isTrainer, userData.SessionID, lastID, errs.ParseLanguage(c.GetHeader("Accept-Language")), conn, s)
 
 	s.addUser(user)
 	user.GetStarted()
 }
+
+// Shutdown закрывает все подключения с кодом 1001, чтобы клиенты переподключились к другому экземпляру,
+// и ждет их удаления с сервера не дольше ctx
+func (s *Server) Shutdown(ctx context.Context) {
+	s.mu.Lock()
+	var users []*User
+	for _, conns := range s.users {
+		for user := range conns {
+			users = append(users, user)
+		}
+	}
+	s.mu.Unlock()
+
+	message := websocket.FormatCloseMessage(websocket.CloseGoingAway, "server is shutting down")
+	for _, user := range users {
+		user.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(s.config.WriteTime))
+		user.Done()
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		s.conns.Wait()
+		close(closed)
+	}()
+
+	select {
+	case <-closed:
+		s.logger.Info().Msg(fmt.Sprintf("All %d chat connections are closed", len(users)))
+	case <-ctx.Done():
+		s.logger.Error().Msg("Chat connections were not closed in time")
+	}
+}
@@ -1,35 +1,124 @@
 package chat
 
 import (
+	"BACKEND/internal/errs"
 	"BACKEND/internal/models/domain"
+	"BACKEND/pkg/responses"
+	"BACKEND/pkg/utils"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	"sync"
+	"time"
+)
+
+// Типы событий протокола чата
+const (
+	// MessageEvent от клиента - новое сообщение, от сервера - сообщение собеседника или копия своего с другого устройства
+	MessageEvent = "message"
+	// AckEvent подтверждение сервером сохранения сообщения, отправленного этим подключением
+	AckEvent = "ack"
+	// DeliveredEvent от клиента - сообщения доставлены на устройство, от сервера - собеседник получил сообщения
+	DeliveredEvent = "delivered"
+	// ReadEvent от клиента - сообщения прочитаны, от сервера - собеседник прочитал сообщения
+	ReadEvent = "read"
+	// TypingEvent собеседник начал или закончил набирать сообщение, не сохраняется
+	TypingEvent = "typing"
+	// PresenceEvent собеседник открыл или закрыл чат, не сохраняется
+	PresenceEvent = "presence"
+	// EditEvent от клиента - изменить текст своего сообщения, от сервера - измененное сообщение
+	EditEvent = "edit"
+	// DeleteEvent от клиента - удалить свое сообщение у всех, от сервера - удаленное сообщение
+	DeleteEvent = "delete"
+	// OfferEvent от пользователя - принять или отклонить предложение услуги, от сервера - предложение с новым статусом
+	OfferEvent = "offer"
+	// ErrorEvent ошибка обработки события клиента, подключение при этом не закрывается
+	ErrorEvent = "error"
 )
 
 type IncomeMessage struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+type OutcomeMessage struct {
 	Type string      `json:"type"`
 	Data interface{} `json:"data"`
 }
 
 type User struct {
+	// Уникальный id подключения, у одного аккаунта их может быть несколько
+	connID string
+	// Сессия, с которой открыто подключение
+	sessionID string
 	id        int
 	isTrainer bool
-	conn      *websocket.Conn
-	server    *Server
-	income    chan *domain.Message
-	done      chan bool
+	// id последнего полученного клиентом сообщения, 0 - не передан
+	lastID int
+	// Время последнего продления статуса в сети
+	touchedAt time.Time
+	// Язык сообщений ErrorEvent
+	lang    errs.Language
+	conn    *websocket.Conn
+	server  *Server
+	limiter *limiter
+	// Очередь событий на отправку клиенту
+	income   chan OutcomeMessage
+	done     chan struct{}
+	doneOnce sync.Once
 }
 
-func NewUser(id int, isTrainer bool, conn *websocket.Conn, server *Server) *User {
+func NewUser(id int, isTrainer bool, sessionID string, lastID int, lang errs.Language, conn *websocket.Conn, server *Server) *User {
 	return &User{
+		connID:    uuid.NewString(),
+		sessionID: sessionID,
 		id:        id,
 		isTrainer: isTrainer,
+		lastID:    lastID,
+		lang:      lang,
 		conn:      conn,
 		server:    server,
-		income:    make(chan *domain.Message, 100),
-		done:      make(chan bool, 100),
+		limiter:   newLimiter(server.config.RateLimit, server.config.RateBurst),
+		income:    make(chan OutcomeMessage, server.config.SendQueue),
+		done:      make(chan struct{}),
+	}
+}
+
+func (u *User) key() connKey {
+	return connKey{isTrainer: u.isTrainer, id: u.id}
+}
+
+func (u *User) userType() string {
+	if u.isTrainer {
+		return utils.Trainer
+	}
+	return utils.User
+}
+
+// chat возвращает user_id и trainer_id чата с собеседником to
+func (u *User) chat(to int) (int, int) {
+	if u.isTrainer {
+		return to, u.id
+	}
+	return u.id, to
+}
+
+// touch продлевает статус в сети не чаще трети PRESENCE_TIME
+func (u *User) touch() error {
+	if time.Since(u.touchedAt) < u.server.config.PresenceTime/3 {
+		return nil
 	}
+
+	if err := u.server.service.SetOnline(context.Background(), u.id, u.userType(), u.connID); err != nil {
+		return err
+	}
+
+	u.touchedAt = time.Now()
+
+	return nil
 }
 
 func (u *User) GetStarted() {
@@ -37,72 +126,377 @@ func (u *User) GetStarted() {
 	go u.write()
 }
 
+// send ставит событие в очередь без ожидания. Переполненная очередь - клиент не успевает читать, его подключение закрывается
+func (u *User) send(message OutcomeMessage) {
+	select {
+	case u.income <- message:
+	default:
+		u.server.logger.Error().Msg(fmt.Sprintf("User %d (is trainer: %t) is too slow, connection %s is closed", u.id, u.isTrainer, u.connID))
+		u.Done()
+	}
+}
+
+// reply ставит в очередь ответ на событие клиента, ожидая место в ней, пока подключение открыто
+func (u *User) reply(message OutcomeMessage) {
+	select {
+	case u.income <- message:
+	case <-u.done:
+	}
+}
+
+// listen отправляет клиенту события из очереди и ping
 func (u *User) listen() {
+	defer u.server.delUser(u)
+
+	ticker := time.NewTicker(u.server.config.PingTime)
+	defer ticker.Stop()
+
 	for {
 		select {
 		case message := <-u.income:
-			err := u.conn.WriteJSON(message)
-			if err != nil {
+			if err := u.conn.SetWriteDeadline(time.Now().Add(u.server.config.WriteTime)); err != nil {
+				u.server.err(err)
+				u.Done()
+				return
+			}
+
+			if err := u.conn.WriteJSON(message); err != nil {
+				u.server.err(err)
+				u.Done()
+				return
+			}
+		case <-ticker.C:
+			if err := u.checkSession(); err != nil {
+				u.closeSession(err)
+				return
+			}
+
+			if err := u.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(u.server.config.WriteTime)); err != nil {
 				u.server.err(err)
 				u.Done()
 				return
 			}
 		case <-u.done:
-			u.server.delUser(u)
 			return
 		}
 	}
 }
 
+// checkSession возвращает ошибку из каталога, если сессию подключения отозвали или аккаунт заблокировали.
+// Ошибка хранилища сессий только логируется, чтобы его недоступность не закрывала все подключения
+func (u *User) checkSession() error {
+	session, isActive, err := u.server.session.Check(context.Background(), u.sessionID)
+	if err != nil {
+		u.server.err(err)
+		return nil
+	}
+
+	if !isActive {
+		return errs.ErrSessionRevoked
+	}
+
+	if session.Suspended() {
+		return errs.ErrSuspended
+	}
+
+	return nil
+}
+
+// closeSession закрывает подключение с кодом 1008 и кодом ошибки из каталога в причине
+func (u *User) closeSession(err error) {
+	u.server.logger.Info().Msg(fmt.Sprintf("User %d (is trainer: %t) connection %s is closed: %s", u.id, u.isTrainer, u.connID, err.Error()))
+
+	reason := ""
+	var catalogErr *errs.Error
+	if errors.As(err, &catalogErr) {
+		reason = catalogErr.Code
+	}
+
+	message := websocket.FormatCloseMessage(websocket.ClosePolicyViolation, reason)
+	u.conn.WriteControl(websocket.CloseMessage, message, time.Now().Add(u.server.config.WriteTime))
+	u.Done()
+}
+
+// write читает и обрабатывает события клиента
 func (u *User) write() {
+	defer u.Done()
+
+	u.conn.SetReadLimit(u.server.config.MaxMessageSize)
+
+	// Подключение живо, пока от клиента приходят события или pong
+	if err := u.conn.SetReadDeadline(time.Now().Add(u.server.config.IdleTime)); err != nil {
+		u.server.err(err)
+		return
+	}
+	u.conn.SetPongHandler(func(string) error {
+		if err := u.conn.SetReadDeadline(time.Now().Add(u.server.config.IdleTime)); err != nil {
+			return err
+		}
+		return u.touch()
+	})
+
+	if err := u.touch(); err != nil {
+		u.server.err(err)
+		return
+	}
+
+	// Досылка пропущенных, пока клиент был не в сети
+	if err := u.replay(); err != nil {
+		u.server.err(err)
+		return
+	}
+
 	for {
-		select {
-		case <-u.done:
-			u.server.delUser(u)
-			return
-		default:
-			var incomeMessage IncomeMessage
-			err := u.conn.ReadJSON(&incomeMessage)
-			if err != nil {
+		_, data, err := u.conn.ReadMessage()
+		if err != nil {
+			// Закрытие клиентом, остановка сервера и простой подключения ошибками не считаются
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
 				u.server.err(err)
-				u.Done()
-				return
-			} else {
-				switch incomeMessage.Type {
-				case "message":
-					messageBytes, err := json.Marshal(incomeMessage.Data)
-					if err != nil {
-						u.server.err(err)
-						u.Done()
-						return
-					}
-
-					var messageGet domain.MessageGet
-					if err = json.Unmarshal(messageBytes, &messageGet); err != nil {
-						u.server.err(err)
-						u.Done()
-						return
-					}
-
-					// Создание записи в БД
-					messageCreate := u.server.converter.MessageGetToMessageCreate(messageGet, u.isTrainer, u.id)
-
-					createdID, time, err := u.server.service.CreateMessage(context.Background(), messageCreate)
-					if err != nil {
-						u.server.err(err)
-						u.Done()
-						return
-					}
-
-					message := u.server.converter.MessageCreateToMessage(messageCreate, createdID, time)
-
-					u.server.sendMessage(&message)
-				}
 			}
+			return
+		}
+
+		if err = u.conn.SetReadDeadline(time.Now().Add(u.server.config.IdleTime)); err != nil {
+			u.server.err(err)
+			return
+		}
+
+		var incomeMessage IncomeMessage
+		if json.Unmarshal(data, &incomeMessage) != nil {
+			err = errs.ErrBadBody
+		} else if !u.limiter.allow() {
+			err = errs.ErrTooManyEvents
+		} else if err = u.touch(); err != nil {
+			u.server.err(err)
+			return
+		} else {
+			switch incomeMessage.Type {
+			case MessageEvent:
+				err = u.handleMessage(incomeMessage.Data)
+			case DeliveredEvent, ReadEvent:
+				err = u.handleReceipt(incomeMessage.Type, incomeMessage.Data)
+			case TypingEvent:
+				err = u.handleTyping(incomeMessage.Data)
+			case PresenceEvent:
+				err = u.handlePresence(incomeMessage.Data)
+			case EditEvent:
+				err = u.handleEdit(incomeMessage.Data)
+			case DeleteEvent:
+				err = u.handleDelete(incomeMessage.Data)
+			case OfferEvent:
+				err = u.handleOffer(incomeMessage.Data)
+			default:
+				u.server.logger.Error().Msg(fmt.Sprintf("Unknown event type %q from user %d (is trainer: %t)", incomeMessage.Type, u.id, u.isTrainer))
+			}
+		}
+
+		// Ошибки из каталога - ошибки клиента, о них сообщается без закрытия подключения
+		var catalogErr *errs.Error
+		if errors.As(err, &catalogErr) {
+			u.reply(OutcomeMessage{Type: ErrorEvent, Data: responses.ErrorResponse{
+				Code:    catalogErr.Code,
+				Message: catalogErr.Message(u.lang),
+			}})
+			continue
+		}
+
+		if err != nil {
+			u.server.err(err)
+			return
+		}
+	}
+}
+
+func (u *User) handleMessage(data json.RawMessage) error {
+	var messageGet domain.MessageGet
+	if err := json.Unmarshal(data, &messageGet); err != nil {
+		return errs.ErrBadBody
+	}
+
+	// Заблокированный после подключения аккаунт не может писать и отключается, не дожидаясь ping
+	if err := u.checkSession(); err != nil {
+		u.closeSession(err)
+		return err
+	}
+
+	// Создание записи в БД
+	messageCreate := u.server.converter.MessageGetToMessageCreate(messageGet, u.isTrainer, u.id)
+
+	message, isCreated, err := u.server.service.CreateMessage(context.Background(), messageCreate)
+	if err != nil {
+		return err
+	}
+
+	u.reply(OutcomeMessage{Type: AckEvent, Data: message})
+
+	// Повторная отправка уже сохраненного сообщения - собеседник его получил
+	if !isCreated {
+		return nil
+	}
+
+	return u.server.publish(context.Background(), MessageEvent, message, message.UserID, message.TrainerID, message.IsToUser, u.connID)
+}
+
+func (u *User) handleReceipt(eventType string, data json.RawMessage) error {
+	var receiptGet domain.ReceiptGet
+	if err := json.Unmarshal(data, &receiptGet); err != nil {
+		return errs.ErrBadBody
+	}
+
+	var receipt domain.Receipt
+	var err error
+
+	if eventType == ReadEvent {
+		receipt, err = u.server.service.MarkRead(context.Background(), u.id, u.userType(), receiptGet.ID)
+	} else {
+		receipt, err = u.server.service.MarkDelivered(context.Background(), u.id, u.userType(), receiptGet.ID)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Все сообщения уже были отмечены
+	if receipt.MessageID == 0 {
+		return nil
+	}
+
+	// Отметка уходит авторам сообщений и на остальные устройства отметившего
+	return u.server.publish(context.Background(), eventType, receipt, receipt.UserID, receipt.TrainerID, !receipt.IsToUser, u.connID)
+}
+
+func (u *User) handleTyping(data json.RawMessage) error {
+	var typingGet domain.TypingGet
+	if err := json.Unmarshal(data, &typingGet); err != nil {
+		return errs.ErrBadBody
+	}
+
+	userID, trainerID := u.chat(typingGet.To)
+	typing := domain.Typing{
+		UserID:    userID,
+		TrainerID: trainerID,
+		IsToUser:  u.isTrainer,
+		IsTyping:  typingGet.IsTyping,
+	}
+
+	// Состояние сообщается только собеседнику, с которым есть переписка или договор и нет блокировки
+	isOpen, err := u.server.service.IsChatOpen(context.Background(), userID, trainerID)
+	if err != nil || !isOpen {
+		return err
+	}
+
+	return u.server.publish(context.Background(), TypingEvent, typing, userID, trainerID, u.isTrainer, u.connID)
+}
+
+func (u *User) handlePresence(data json.RawMessage) error {
+	var presenceGet domain.PresenceGet
+	if err := json.Unmarshal(data, &presenceGet); err != nil {
+		return errs.ErrBadBody
+	}
+
+	userID, trainerID := u.chat(presenceGet.To)
+	presence := domain.PresenceChange{
+		UserID:    userID,
+		TrainerID: trainerID,
+		IsToUser:  u.isTrainer,
+		IsOnline:  presenceGet.IsOnline,
+	}
+
+	// Состояние сообщается только собеседнику, с которым есть переписка или договор и нет блокировки
+	isOpen, err := u.server.service.IsChatOpen(context.Background(), userID, trainerID)
+	if err != nil || !isOpen {
+		return err
+	}
+
+	return u.server.publish(context.Background(), PresenceEvent, presence, userID, trainerID, u.isTrainer, u.connID)
+}
+
+func (u *User) handleEdit(data json.RawMessage) error {
+	var editGet domain.MessageEditGet
+	if err := json.Unmarshal(data, &editGet); err != nil || editGet.Message == "" {
+		return errs.ErrBadBody
+	}
+
+	message, err := u.server.service.EditMessage(context.Background(), u.id, u.userType(), editGet.ID, editGet.Message)
+	if err != nil {
+		return err
+	}
+
+	u.reply(OutcomeMessage{Type: EditEvent, Data: message})
+
+	return u.server.publish(context.Background(), EditEvent, message, message.UserID, message.TrainerID, message.IsToUser, u.connID)
+}
+
+func (u *User) handleDelete(data json.RawMessage) error {
+	var deleteGet domain.MessageDeleteGet
+	if err := json.Unmarshal(data, &deleteGet); err != nil {
+		return errs.ErrBadBody
+	}
+
+	message, err := u.server.service.DeleteMessage(context.Background(), u.id, u.userType(), deleteGet.ID)
+	if err != nil {
+		return err
+	}
+
+	u.reply(OutcomeMessage{Type: DeleteEvent, Data: message})
+
+	return u.server.publish(context.Background(), DeleteEvent, message, message.UserID, message.TrainerID, message.IsToUser, u.connID)
+}
+
+func (u *User) handleOffer(data json.RawMessage) error {
+	var answerGet domain.OfferAnswerGet
+	if err := json.Unmarshal(data, &answerGet); err != nil {
+		return errs.ErrBadBody
+	}
+
+	// Отвечает на предложение только пользователь
+	if u.isTrainer {
+		return errs.ErrForbidden
+	}
+
+	offer, system, err := u.server.service.AnswerOffer(context.Background(), u.id, answerGet.ID, answerGet.Accept)
+	if err != nil {
+		return err
+	}
+
+	u.reply(OutcomeMessage{Type: OfferEvent, Data: offer})
+
+	if err = u.server.publish(context.Background(), OfferEvent, offer, offer.UserID, offer.TrainerID, offer.IsToUser, u.connID); err != nil {
+		return err
+	}
+
+	// Системное сообщение о заключенном договоре
+	if system.ID == 0 {
+		return nil
+	}
+
+	u.reply(OutcomeMessage{Type: MessageEvent, Data: system})
+
+	return u.server.publish(context.Background(), MessageEvent, system, system.UserID, system.TrainerID, system.IsToUser, u.connID)
+}
+
+// replay отправляет в подключение сообщения, пропущенные клиентом
+func (u *User) replay() error {
+	cursor := 0
+	for {
+		page, err := u.server.service.GetMissedMessages(context.Background(), u.id, u.userType(), u.lastID, cursor)
+		if err != nil {
+			return err
+		}
+
+		for _, message := range page.Messages {
+			u.reply(OutcomeMessage{Type: MessageEvent, Data: message})
+		}
+
+		if page.Cursor == 0 {
+			return nil
 		}
+		cursor = page.Cursor
 	}
 }
 
+// Done закрывает подключение, повторные вызовы ничего не делают
 func (u *User) Done() {
-	u.done <- true
+	u.doneOnce.Do(func() {
+		close(u.done)
+	})
 }
@@ -15,50 +15,29 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/auth/login/admin": {
-            "post": {
-                "description": "Authorize admin with X-API-KEY",
-                "consumes": [
-                    "application/json"
-                ],
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Public keys for access token verification (RFC 7517). Tokens carry the key id in the ` + "`" + `kid` + "`" + ` header",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "Authorization"
                 ],
-                "summary": "Admin Authorization",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Admin apikey",
-                        "name": "X-API-KEY",
-                        "in": "header",
-                        "required": true
-                    }
-                ],
+                "summary": "Get JWKS",
                 "responses": {
                     "200": {
-                        "description": "Return tokens",
-                        "schema": {
-                            "$ref": "#/definitions/responses.TokenResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Invalid X-API-KEY",
+                        "description": "Return public keys",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/utils.JWKS"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/api/auth/login/trainer": {
+        "/api/account/deletion": {
             "post": {
-                "description": "Authorize trainer",
+                "description": "Schedule deletion of the current account after the grace period. Until then deletion can be canceled, after it the account and its data are deleted and the chat history is anonymised",
                 "consumes": [
                     "application/json"
                 ],
@@ -66,85 +45,56 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authorization"
+                    "Account"
                 ],
-                "summary": "Trainer Authorization",
+                "summary": "Request account deletion",
                 "parameters": [
                     {
-                        "description": "Authorization request body",
-                        "name": "auth",
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Current password",
+                        "name": "password",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.Auth"
+                            "$ref": "#/definitions/dto.DeletionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Return tokens",
+                        "description": "Return time of deletion",
                         "schema": {
-                            "$ref": "#/definitions/responses.TokenResponse"
+                            "$ref": "#/definitions/dto.DeletionSchedule"
                         }
                     },
                     "400": {
-                        "description": "Bad body provided",
+                        "description": "Bad body or JWT provided, password is wrong",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            }
-        },
-        "/api/auth/login/user": {
-            "post": {
-                "description": "Authorize user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authorization"
-                ],
-                "summary": "User Authorization",
-                "parameters": [
-                    {
-                        "description": "Authorization request body",
-                        "name": "auth",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.Auth"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Return tokens",
+                    "401": {
+                        "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.TokenResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad body provided",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
                     }
                 }
-            }
-        },
-        "/api/auth/refresh": {
-            "get": {
-                "description": "Refreshes the access and refresh tokens using the provided refresh token.",
+            },
+            "delete": {
+                "description": "Cancel scheduled deletion of the current account",
                 "consumes": [
                     "application/json"
                 ],
@@ -152,40 +102,52 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authorization"
+                    "Account"
                 ],
-                "summary": "Refresh Tokens",
+                "summary": "Cancel account deletion",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Refresh token",
-                        "name": "refresh_token",
-                        "in": "query",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Return tokens",
+                        "description": "Deletion canceled successfully"
+                    },
+                    "400": {
+                        "description": "Bad JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.TokenResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad query provided",
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Deletion is not requested",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/auth/register/trainer": {
+        "/api/account/export": {
             "post": {
-                "description": "Register trainer",
+                "description": "Start preparing a zip archive with profile, trainings, schedules, progress, plans, services and chat messages in JSON. When the archive is ready, an email is sent",
                 "consumes": [
                     "application/json"
                 ],
@@ -193,9 +155,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authorization"
+                    "Account"
                 ],
-                "summary": "Trainer Register",
+                "summary": "Request personal data export",
                 "parameters": [
                     {
                         "type": "string",
@@ -203,88 +165,45 @@ const docTemplate = `{
                         "name": "access_token",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "description": "Register request body",
-                        "name": "auth",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.TrainerCreate"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Return created trainer's id",
+                    "202": {
+                        "description": "Return created export's id",
                         "schema": {
                             "$ref": "#/definitions/responses.CreatedIDResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad body or JWT provided",
+                        "description": "Bad JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error"
-                    }
-                }
-            }
-        },
-        "/api/auth/register/user": {
-            "post": {
-                "description": "Register user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authorization"
-                ],
-                "summary": "User Register",
-                "parameters": [
-                    {
-                        "description": "Register request body",
-                        "name": "auth",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.UserCreate"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Return tokens",
+                    "409": {
+                        "description": "Another export is being prepared",
                         "schema": {
-                            "$ref": "#/definitions/responses.TokenResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad body provided",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error"
                     }
                 }
             }
         },
-        "/api/chat/trainer": {
+        "/api/account/export/{export_id}": {
             "get": {
-                "description": "Get all chats for a trainer",
+                "description": "Get status of the personal data export",
                 "consumes": [
                     "application/json"
                 ],
@@ -292,9 +211,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chats"
+                    "Account"
                 ],
-                "summary": "Get Trainer Chats",
+                "summary": "Get personal data export",
                 "parameters": [
                     {
                         "type": "string",
@@ -304,53 +223,57 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Search term",
-                        "name": "search",
-                        "in": "query"
+                        "type": "integer",
+                        "description": "Export ID",
+                        "name": "export_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of chats",
+                        "description": "Return export",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.Chat"
-                            }
+                            "$ref": "#/definitions/dto.DataExport"
                         }
                     },
                     "400": {
-                        "description": "Bad JWT provided",
+                        "description": "Invalid export ID or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No export with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/chat/trainer/{user_id}": {
+        "/api/account/export/{export_id}/download": {
             "get": {
-                "description": "Get messages for a chat between a user and a trainer - trainer",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Download the zip archive of the ready personal data export",
                 "produces": [
-                    "application/json"
+                    "application/zip"
                 ],
                 "tags": [
-                    "Chats"
+                    "Account"
                 ],
-                "summary": "Get Chat Messages Trainer",
+                "summary": "Download personal data export",
                 "parameters": [
                     {
                         "type": "string",
@@ -361,46 +284,55 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "User ID",
-                        "name": "user_id",
+                        "description": "Export ID",
+                        "name": "export_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Cursor for pagination",
-                        "name": "cursor",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of messages with pagination",
+                        "description": "Zip archive with JSON files",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessagePagination"
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Bad path or JWT provided",
+                        "description": "Invalid export ID or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No export with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Export is not ready",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/chat/user": {
+        "/api/admin/me": {
             "get": {
-                "description": "Get all chats for a user",
+                "description": "Get current admin with roles",
                 "consumes": [
                     "application/json"
                 ],
@@ -408,9 +340,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chats"
+                    "Admins"
                 ],
-                "summary": "Get User Chats",
+                "summary": "Get Admin Me",
                 "parameters": [
                     {
                         "type": "string",
@@ -418,45 +350,39 @@ const docTemplate = `{
                         "name": "access_token",
                         "in": "header",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search term",
-                        "name": "search",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of chats",
+                        "description": "Return admin",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.Chat"
-                            }
+                            "$ref": "#/definitions/dto.Admin"
                         }
                     },
                     "400": {
                         "description": "Bad JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/chat/user/{trainer_id}": {
+        "/api/admin/message-report": {
             "get": {
-                "description": "Get messages for a chat between a user and a trainer - user",
+                "description": "Get reports on chat messages with the reported message, oldest first. Text of messages deleted after the report is shown",
                 "consumes": [
                     "application/json"
                 ],
@@ -464,9 +390,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Chats"
+                    "Moderation"
                 ],
-                "summary": "Get Chat Messages User",
+                "summary": "Get Message Reports",
                 "parameters": [
                     {
                         "type": "string",
@@ -476,11 +402,10 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Trainer ID",
-                        "name": "trainer_id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "Filter by status: pending, resolved or dismissed",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
                         "type": "integer",
@@ -491,32 +416,41 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of messages with pagination",
+                        "description": "List of reports with pagination",
                         "schema": {
-                            "$ref": "#/definitions/dto.MessagePagination"
+                            "$ref": "#/definitions/dto.MessageReportPagination"
                         }
                     },
                     "400": {
-                        "description": "Bad path or JWT provided",
+                        "description": "Invalid query or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/role": {
-            "get": {
-                "description": "Get roles",
+        "/api/admin/message-report/{report_id}/review": {
+            "post": {
+                "description": "Close pending report as resolved or dismissed",
                 "consumes": [
                     "application/json"
                 ],
@@ -524,38 +458,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Moderation"
                 ],
-                "summary": "Get Roles",
-                "responses": {
-                    "200": {
-                        "description": "Return roles",
+                "summary": "Review Message Report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Report ID",
+                        "name": "report_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Review result",
+                        "name": "review",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.Base"
-                            }
+                            "$ref": "#/definitions/dto.ReportReview"
                         }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Report reviewed successfully"
                     },
                     "400": {
-                        "description": "Invalid body or jwt provided",
+                        "description": "Invalid report ID, body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No report with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Report is already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/api/admin/suspension/trainer/{trainer_id}": {
             "post": {
-                "description": "Create a new role",
+                "description": "Suspend trainer until the given time or forever if it is not set. Suspended trainer can not log in, use the API or send messages",
                 "consumes": [
                     "application/json"
                 ],
@@ -563,9 +539,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Moderation"
                 ],
-                "summary": "Create Role",
+                "summary": "Suspend Trainer",
                 "parameters": [
                     {
                         "type": "string",
@@ -575,41 +551,60 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Role data to create",
-                        "name": "role",
+                        "type": "integer",
+                        "description": "Trainer ID",
+                        "name": "trainer_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Suspension end and reason",
+                        "name": "suspension",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.BaseBase"
+                            "$ref": "#/definitions/dto.Suspension"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Role successfully created",
-                        "schema": {
-                            "$ref": "#/definitions/responses.CreatedIDResponse"
-                        }
+                    "200": {
+                        "description": "Trainer suspended successfully"
                     },
                     "400": {
-                        "description": "Invalid body or jwt provided",
+                        "description": "Invalid trainer ID, body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No trainer with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             },
             "delete": {
-                "description": "Delete roles by provided IDs",
+                "description": "Lift trainer suspension",
                 "consumes": [
                     "application/json"
                 ],
@@ -617,9 +612,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Roles"
+                    "Moderation"
                 ],
-                "summary": "Delete Roles",
+                "summary": "Unsuspend Trainer",
                 "parameters": [
                     {
                         "type": "string",
@@ -629,43 +624,53 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Role IDs to delete",
-                        "name": "role_ids",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "integer"
-                            }
-                        }
+                        "type": "integer",
+                        "description": "Trainer ID",
+                        "name": "trainer_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Roles successfully deleted"
+                        "description": "Trainer unsuspended successfully"
                     },
                     "400": {
-                        "description": "Invalid body or jwt provided",
+                        "description": "Invalid trainer ID or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No trainer with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/service": {
+        "/api/admin/suspension/user/{user_id}": {
             "post": {
-                "description": "Create a new service between user and trainer",
+                "description": "Suspend user until the given time or forever if it is not set. Suspended user can not log in, use the API or send messages",
                 "consumes": [
                     "application/json"
                 ],
@@ -673,9 +678,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Moderation"
                 ],
-                "summary": "Create Service",
+                "summary": "Suspend User",
                 "parameters": [
                     {
                         "type": "string",
@@ -685,43 +690,60 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Service data to create",
-                        "name": "service",
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Suspension end and reason",
+                        "name": "suspension",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UserTrainerServiceCreateTrainer"
+                            "$ref": "#/definitions/dto.Suspension"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Service created successfully",
-                        "schema": {
-                            "$ref": "#/definitions/responses.CreatedIDResponse"
-                        }
+                    "200": {
+                        "description": "User suspended successfully"
                     },
                     "400": {
-                        "description": "Invalid body or JWT provided",
+                        "description": "Invalid user ID, body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No user with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
-            }
-        },
-        "/api/service/schedule": {
-            "get": {
-                "description": "Get schedules by an array of schedule IDs",
+            },
+            "delete": {
+                "description": "Lift user suspension",
                 "consumes": [
                     "application/json"
                 ],
@@ -729,45 +751,65 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Moderation"
                 ],
-                "summary": "Get Schedules by IDs",
+                "summary": "Unsuspend User",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Array of schedule IDs",
-                        "name": "schedule_ids",
-                        "in": "query",
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Return schedules for the given IDs",
+                        "description": "User unsuspended successfully"
+                    },
+                    "400": {
+                        "description": "Invalid user ID or JWT provided",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.ScheduleServiceUser"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid query parameters provided",
+                    "401": {
+                        "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No user with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "post": {
-                "description": "Schedule a new service between user and trainer",
+            }
+        },
+        "/api/admin/trainer-application": {
+            "get": {
+                "description": "Get trainer applications with pagination, optionally filtered by status",
                 "consumes": [
                     "application/json"
                 ],
@@ -775,42 +817,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Trainer Applications"
                 ],
-                "summary": "Schedule Service",
+                "summary": "Get Trainer Applications",
                 "parameters": [
                     {
-                        "description": "Schedule data to create",
-                        "name": "schedule",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ScheduleService"
-                        }
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "approved",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Application status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor for pagination",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Schedule created successfully",
+                    "200": {
+                        "description": "List of applications with pagination",
                         "schema": {
-                            "$ref": "#/definitions/responses.CreatedIDResponse"
+                            "$ref": "#/definitions/dto.TrainerApplicationCoverPagination"
                         }
                     },
                     "400": {
-                        "description": "Invalid body provided",
+                        "description": "Invalid query parameters or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not responsible for trainer onboarding",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/service/schedule/{month}": {
+        "/api/admin/trainer-application/{application_id}": {
             "get": {
-                "description": "Get schedule for the specified month",
+                "description": "Get trainer application with roles, specializations and certificates",
                 "consumes": [
                     "application/json"
                 ],
@@ -818,9 +890,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Trainer Applications"
                 ],
-                "summary": "Get Schedule",
+                "summary": "Get Trainer Application",
                 "parameters": [
                     {
                         "type": "string",
@@ -831,43 +903,55 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Month (1-12)",
-                        "name": "month",
+                        "description": "Application ID",
+                        "name": "application_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Return schedule for the month",
+                        "description": "Return application",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.TrainingSchedule"
-                            }
+                            "$ref": "#/definitions/dto.TrainerApplication"
                         }
                     },
                     "400": {
-                        "description": "Invalid month or JWT provided",
+                        "description": "Invalid application ID or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not responsible for trainer onboarding",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No application with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/service/schedule/{schedule_id}": {
-            "delete": {
-                "description": "Delete a scheduled service by its ID",
+        "/api/admin/trainer-application/{application_id}/approve": {
+            "post": {
+                "description": "Approve pending application: creates the trainer with roles and specializations and emails a link to set the password",
                 "consumes": [
                     "application/json"
                 ],
@@ -875,37 +959,74 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Trainer Applications"
                 ],
-                "summary": "Delete Scheduled Service",
+                "summary": "Approve Trainer Application",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Schedule ID",
-                        "name": "schedule_id",
+                        "description": "Application ID",
+                        "name": "application_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Schedule deleted successfully"
+                    "201": {
+                        "description": "Return created trainer's id",
+                        "schema": {
+                            "$ref": "#/definitions/responses.CreatedIDResponse"
+                        }
                     },
                     "400": {
-                        "description": "Invalid schedule ID provided",
+                        "description": "Invalid application ID or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not responsible for trainer onboarding",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No application with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Application is already reviewed or trainer already exists",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/service/status/{service_id}": {
-            "put": {
-                "description": "Update the status of a service\ntype = 1 - payment status\ntype = 2 - trainer confirm status\ntype = 3 - user confirm status",
+        "/api/admin/trainer-application/{application_id}/reject": {
+            "post": {
+                "description": "Reject pending application, the reason is sent to the applicant",
                 "consumes": [
                     "application/json"
                 ],
@@ -913,52 +1034,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Trainer Applications"
                 ],
-                "summary": "Update Service Status",
+                "summary": "Reject Trainer Application",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "integer",
-                        "description": "Service ID",
-                        "name": "service_id",
+                        "description": "Application ID",
+                        "name": "application_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Status data to update",
-                        "name": "status",
+                        "description": "Rejection reason",
+                        "name": "reason",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateStatusService"
+                            "$ref": "#/definitions/dto.ApplicationReject"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Status updated successfully"
+                        "description": "Application rejected successfully"
                     },
                     "400": {
-                        "description": "Invalid body or JWT provided",
+                        "description": "Invalid application ID, body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not responsible for trainer onboarding",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No application with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Application is already reviewed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/service/trainer": {
-            "get": {
-                "description": "Get services for a trainer with pagination",
+        "/api/auth/2fa/disable": {
+            "post": {
+                "description": "Disable two-factor authentication with a code from the authenticator app or a recovery code",
                 "consumes": [
                     "application/json"
                 ],
@@ -966,9 +1115,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Two-factor"
                 ],
-                "summary": "Get Trainer Services",
+                "summary": "Disable Two-factor Authentication",
                 "parameters": [
                     {
                         "type": "string",
@@ -978,40 +1127,43 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Cursor for pagination",
-                        "name": "cursor",
-                        "in": "query"
+                        "description": "Code from the authenticator app or recovery code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorCode"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of user services with pagination",
-                        "schema": {
-                            "$ref": "#/definitions/dto.ServiceUserPagination"
-                        }
+                        "description": "Two-factor authentication disabled successfully"
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Bad body or JWT provided, invalid code or two-factor authentication is not enabled",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/service/user": {
-            "get": {
-                "description": "Get services for a user with pagination",
+        "/api/auth/2fa/enable": {
+            "post": {
+                "description": "Confirm setup with a code from the authenticator app. Returns recovery codes, they are shown only once",
                 "consumes": [
                     "application/json"
                 ],
@@ -1019,9 +1171,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Two-factor"
                 ],
-                "summary": "Get User Services",
+                "summary": "Enable Two-factor Authentication",
                 "parameters": [
                     {
                         "type": "string",
@@ -1031,40 +1183,46 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Cursor for pagination",
-                        "name": "cursor",
-                        "in": "query"
+                        "description": "Code from the authenticator app",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorCode"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of trainer services with pagination",
+                        "description": "Return recovery codes",
                         "schema": {
-                            "$ref": "#/definitions/dto.ServiceTrainerPagination"
+                            "$ref": "#/definitions/dto.RecoveryCodes"
                         }
                     },
                     "400": {
-                        "description": "Invalid query parameters",
+                        "description": "Bad body or JWT provided, invalid code or two-factor authentication is not set up",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/service/{id}": {
-            "get": {
-                "description": "Get a service by its ID",
+        "/api/auth/2fa/recovery": {
+            "post": {
+                "description": "Replace all recovery codes with new ones. Old codes stop working",
                 "consumes": [
                     "application/json"
                 ],
@@ -1072,46 +1230,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Two-factor"
                 ],
-                "summary": "Get Service by ID",
+                "summary": "Regenerate Recovery Codes",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Service ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
                         "required": true
+                    },
+                    {
+                        "description": "Code from the authenticator app or recovery code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorCode"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Service details",
+                        "description": "Return new recovery codes",
                         "schema": {
-                            "$ref": "#/definitions/dto.Service"
+                            "$ref": "#/definitions/dto.RecoveryCodes"
                         }
                     },
                     "400": {
-                        "description": "Invalid service ID",
+                        "description": "Bad body or JWT provided, invalid code or two-factor authentication is not enabled",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Service not found",
+                    "401": {
+                        "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/service/{service_id}": {
-            "delete": {
-                "description": "Delete a service by its ID",
+        "/api/auth/2fa/setup": {
+            "post": {
+                "description": "Generate a TOTP secret and otpauth:// provisioning URI for a QR code. Two-factor authentication is not enabled until confirmed with a code",
                 "consumes": [
                     "application/json"
                 ],
@@ -1119,37 +1289,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Services"
+                    "Two-factor"
                 ],
-                "summary": "Delete Service",
+                "summary": "Setup Two-factor Authentication",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Service ID",
-                        "name": "service_id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Service deleted successfully"
+                        "description": "Return secret and provisioning URI",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorSetup"
+                        }
                     },
                     "400": {
-                        "description": "Invalid service ID provided",
+                        "description": "Bad JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication is already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/specialization": {
-            "get": {
-                "description": "Get specializations",
+        "/api/auth/login/2fa": {
+            "post": {
+                "description": "Second login step for trainers and admins with two-factor authentication.\nAccepts a code from the authenticator app or one of the recovery codes. Challenge token is single-use",
                 "consumes": [
                     "application/json"
                 ],
@@ -1157,38 +1345,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Specializations"
+                    "Authorization"
+                ],
+                "summary": "Two-factor Authorization",
+                "parameters": [
+                    {
+                        "description": "Challenge token and code",
+                        "name": "auth",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorLogin"
+                        }
+                    }
                 ],
-                "summary": "Get Specializations",
                 "responses": {
                     "200": {
-                        "description": "Return specializations",
+                        "description": "Return tokens",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.Base"
-                            }
+                            "$ref": "#/definitions/responses.TokenResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid body or jwt provided",
+                        "description": "Bad body provided, challenge token is invalid or expired or code is invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "JWT is expired or invalid",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
-                    },
-                    "500": {
-                        "description": "Internal server error"
                     }
                 }
-            },
+            }
+        },
+        "/api/auth/login/admin": {
             "post": {
-                "description": "Create a new specialization",
+                "description": "Authorize admin. Admin accounts are created with the ` + "`" + `create-admin` + "`" + ` command.\nIf two-factor authentication is enabled, returns a challenge token for ` + "`" + `/api/auth/login/2fa` + "`" + ` instead of tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -1196,53 +1391,69 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Specializations"
+                    "Authorization"
                 ],
-                "summary": "Create Specialization",
+                "summary": "Admin Authorization",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Access token",
-                        "name": "access_token",
-                        "in": "header",
-                        "required": true
+                        "description": "Device label for sessions list",
+                        "name": "X-Device-Label",
+                        "in": "header"
                     },
                     {
-                        "description": "Specializations data to create",
-                        "name": "specialization",
+                        "description": "Authorization request body",
+                        "name": "auth",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.BaseBase"
+                            "$ref": "#/definitions/dto.Auth"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Specializations successfully created",
+                    "200": {
+                        "description": "Return tokens",
                         "schema": {
-                            "$ref": "#/definitions/responses.CreatedIDResponse"
+                            "$ref": "#/definitions/responses.TokenResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Two-factor code required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorChallenge"
                         }
                     },
                     "400": {
-                        "description": "Invalid body or jwt provided",
+                        "description": "Bad body provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "JWT is expired or invalid",
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts, login is temporarily locked",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete specializations by provided IDs",
+            }
+        },
+        "/api/auth/login/trainer": {
+            "post": {
+                "description": "Authorize trainer. If two-factor authentication is enabled, returns a challenge token for ` + "`" + `/api/auth/login/2fa` + "`" + ` instead of tokens",
                 "consumes": [
                     "application/json"
                 ],
@@ -1250,55 +1461,3446 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Specializations"
+                    "Authorization"
                 ],
-                "summary": "Delete Specializations",
+                "summary": "Trainer Authorization",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Access token",
-                        "name": "access_token",
-                        "in": "header",
-                        "required": true
+                        "description": "Device label for sessions list",
+                        "name": "X-Device-Label",
+                        "in": "header"
                     },
                     {
-                        "description": "Specialization IDs to delete",
-                        "name": "specialization_ids",
+                        "description": "Authorization request body",
+                        "name": "auth",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "integer"
-                            }
+                            "$ref": "#/definitions/dto.Auth"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Specializations successfully deleted"
+                        "description": "Return tokens",
+                        "schema": {
+                            "$ref": "#/definitions/responses.TokenResponse"
+                        }
+                    },
+                    "202": {
+                        "description": "Two-factor code required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorChallenge"
+                        }
                     },
                     "400": {
-                        "description": "Invalid body or jwt provided",
+                        "description": "Bad body provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "JWT is expired or invalid",
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts, login is temporarily locked",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/trainer": {
-            "get": {
-                "description": "Get trainer covers with pagination",
+        "/api/auth/login/user": {
+            "post": {
+                "description": "Authorize user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "User Authorization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device label for sessions list",
+                        "name": "X-Device-Label",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Authorization request body",
+                        "name": "auth",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.Auth"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Return tokens",
+                        "schema": {
+                            "$ref": "#/definitions/responses.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad body provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid email or password",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts, login is temporarily locked",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/logout": {
+            "post": {
+                "description": "Revoke current session: its refresh and access tokens stop working immediately",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session revoked successfully"
+                    },
+                    "400": {
+                        "description": "Bad JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/logout/all": {
+            "post": {
+                "description": "Revoke all sessions of the current account, including the current one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "Logout everywhere",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sessions revoked successfully"
+                    },
+                    "400": {
+                        "description": "Bad JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/password/forgot": {
+            "post": {
+                "description": "Send a password reset link. Responds with 200 even if the email is not registered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "Forgot password",
+                "parameters": [
+                    {
+                        "description": "Account email and type",
+                        "name": "forgot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PasswordForgot"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reset mail sent if the account exists"
+                    },
+                    "400": {
+                        "description": "Bad body provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/password/reset": {
+            "post": {
+                "description": "Set a new password with the token from the reset mail. All sessions of the account are revoked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "reset",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PasswordReset"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed successfully"
+                    },
+                    "400": {
+                        "description": "Bad body provided or token is invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/refresh": {
+            "get": {
+                "description": "Refreshes the access and refresh tokens using the provided refresh token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "Refresh Tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Refresh token",
+                        "name": "refresh_token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Return tokens",
+                        "schema": {
+                            "$ref": "#/definitions/responses.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad query provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Refresh token is expired or revoked",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/register/trainer": {
+            "post": {
+                "description": "Register trainer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "Trainer Register",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Register request body",
+                        "name": "auth",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrainerCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Return created trainer's id",
+                        "schema": {
+                            "$ref": "#/definitions/responses.CreatedIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad body or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not responsible for trainer onboarding",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Trainer with such email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/register/user": {
+            "post": {
+                "description": "Register user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "User Register",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Device label for sessions list",
+                        "name": "X-Device-Label",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Register request body",
+                        "name": "auth",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Return tokens",
+                        "schema": {
+                            "$ref": "#/definitions/responses.TokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad body provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "User with such email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/session": {
+            "get": {
+                "description": "Get active sessions of the current account, most recently used first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "Get active sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Return sessions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Session"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/session/{session_id}": {
+            "delete": {
+                "description": "Revoke one of the current account's sessions, e.g. with a stolen refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "Revoke session",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "session_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Session revoked successfully"
+                    },
+                    "400": {
+                        "description": "Bad JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No session with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/verify": {
+            "post": {
+                "description": "Confirm email with the token from the verification mail. Token is single-use",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "Verify email",
+                "parameters": [
+                    {
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.Token"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email verified successfully"
+                    },
+                    "400": {
+                        "description": "Bad body provided or token is invalid or expired",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/auth/verify/send": {
+            "post": {
+                "description": "Send a new email verification link to the current account",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authorization"
+                ],
+                "summary": "Send email verification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Verification mail sent successfully"
+                    },
+                    "400": {
+                        "description": "Bad JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email is already verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/calendar": {
+            "get": {
+                "description": "Get calendar events from date_start to date_end inclusive, at most 366 days, ordered by start. For a user these are own trainings\n(` + "`" + `type: training` + "`" + `) and sessions with trainers (` + "`" + `type: session` + "`" + `), for a trainer - sessions with clients. Sessions have the contract and the counterpart.\nDays and times are in the caller's profile time zone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Get Calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day, YYYY-MM-DD",
+                        "name": "date_start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, YYYY-MM-DD",
+                        "name": "date_end",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Calendar events",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.CalendarEvent"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/calendar/feed": {
+            "post": {
+                "description": "Create a secret calendar subscription URL for Google/Apple Calendar, replacing the previous one. The URL is shown only once.\nThe feed contains the same events as GET /api/calendar from 90 days ago to a year ahead, times are in UTC",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Create Calendar Feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Subscription URL",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CalendarFeed"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Revoke the calendar subscription URL",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Delete Calendar Feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Feed deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Feed is not created",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/calendar/feed/{token}": {
+            "get": {
+                "description": "Calendar subscription in iCalendar format. Authorization is the secret token in the URL",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Get Calendar Feed",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Feed token, optionally with .ics suffix",
+                        "name": "token",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "No feed with such token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/calendar/training/{user_training_id}": {
+            "get": {
+                "description": "Download a scheduled training as an iCalendar file",
+                "produces": [
+                    "text/calendar"
+                ],
+                "tags": [
+                    "Calendar"
+                ],
+                "summary": "Download Training",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User Training ID",
+                        "name": "user_training_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "iCalendar",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user training ID or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Scheduled training belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No scheduled training with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/attachment": {
+            "post": {
+                "description": "Upload a file to attach to a message: pass returned id in ` + "`" + `attachment_ids` + "`" + ` of a WS ` + "`" + `message` + "`" + ` event. Images get a thumbnail. Attachments that are not sent within a day are deleted",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Upload Chat Attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image jpeg/jpg/png/gif or voice note ogg/oga/opus/mp3/m4a/webm under 10MB, file pdf/txt under 20MB",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Uploaded attachment",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad file or JWT provided, file is too large",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/blocks": {
+            "get": {
+                "description": "Get accounts blocked by the user or trainer, latest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Get Chat Block List",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Blocked accounts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BlockedAccount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/message/{message_id}": {
+            "put": {
+                "description": "Replace text of own message, previous text is saved to edit history. The change is sent to both participants over WS as an ` + "`" + `edit` + "`" + ` event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Edit Chat Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Message ID",
+                        "name": "message_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New text",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Edited message",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path, body or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No own message with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Offer in the message is already accepted or declined",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete own message for everyone within MESSAGE_DELETE_TIME after sending. The change is sent to both participants over WS as a ` + "`" + `delete` + "`" + ` event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Delete Chat Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Message ID",
+                        "name": "message_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted message",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Message"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No own message with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Time to delete the message has expired or offer in it is already accepted or declined",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/message/{message_id}/edits": {
+            "get": {
+                "description": "Get previous versions of a message from own chat, oldest first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Get Chat Message Edits",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Message ID",
+                        "name": "message_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Edit history",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.MessageEdit"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No message with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/message/{message_id}/report": {
+            "post": {
+                "description": "Report abuse in a message received from the chat counterpart. Reports are reviewed by content moderators",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Report Chat Message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Message ID",
+                        "name": "message_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Report reason",
+                        "name": "report",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageReportCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Report successfully created",
+                        "schema": {
+                            "$ref": "#/definitions/responses.CreatedIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path, body or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No received message with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Message is already reported and waits for review",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/search": {
+            "get": {
+                "description": "Full-text search over messages of all own chats or of the chat with ` + "`" + `to` + "`" + `, newest first. Russian and English word forms are matched, ` + "`" + `\"phrase\"` + "`" + `, ` + "`" + `or` + "`" + ` and ` + "`" + `-word` + "`" + ` are supported. Matches in ` + "`" + `highlight` + "`" + ` are wrapped in ` + "`" + `\u003cmark\u003e\u003c/mark\u003e` + "`" + `, the rest of the text is HTML-escaped. Pass result ` + "`" + `cursor` + "`" + ` to the chat messages endpoint to open the chat at the message",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Search Chat Messages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query, up to 256 characters",
+                        "name": "query",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Counterpart ID: trainer for a user, user for a trainer",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor for pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Found messages with pagination",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageFoundPagination"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad query or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/trainer": {
+            "get": {
+                "description": "Get all chats for a trainer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Get Trainer Chats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of chats",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Chat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/trainer/presence": {
+            "get": {
+                "description": "Get online status and last seen time of users for a trainer's chat list. Unknown IDs are skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Get Users Presence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "User IDs, up to 100",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of presences",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Presence"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad query or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/trainer/{user_id}": {
+            "get": {
+                "description": "Get messages for a chat between a user and a trainer - trainer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Get Chat Messages Trainer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor for pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of messages with pagination",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessagePagination"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/trainer/{user_id}/block": {
+            "post": {
+                "description": "Add a user to own block list: neither side can send messages, offers can not be accepted, typing and presence are not shared",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Block User In Chat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User successfully blocked"
+                    },
+                    "400": {
+                        "description": "Bad path or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No user with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a user from own block list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Unblock User In Chat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User successfully unblocked"
+                    },
+                    "400": {
+                        "description": "Bad path or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/user": {
+            "get": {
+                "description": "Get all chats for a user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Get User Chats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of chats",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Chat"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/user/presence": {
+            "get": {
+                "description": "Get online status and last seen time of trainers for a user's chat list. Unknown IDs are skipped",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Get Trainers Presence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Trainer IDs, up to 100",
+                        "name": "ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of presences",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Presence"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad query or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/user/{trainer_id}": {
+            "get": {
+                "description": "Get messages for a chat between a user and a trainer - user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Get Chat Messages User",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trainer ID",
+                        "name": "trainer_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor for pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of messages with pagination",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessagePagination"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad path or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/chat/user/{trainer_id}/block": {
+            "post": {
+                "description": "Add a trainer to own block list: neither side can send messages, offers can not be accepted, typing and presence are not shared",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Block Trainer In Chat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trainer ID",
+                        "name": "trainer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trainer successfully blocked"
+                    },
+                    "400": {
+                        "description": "Bad path or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No trainer with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a trainer from own block list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chats"
+                ],
+                "summary": "Unblock Trainer In Chat",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trainer ID",
+                        "name": "trainer_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Trainer successfully unblocked"
+                    },
+                    "400": {
+                        "description": "Bad path or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/role": {
+            "get": {
+                "description": "Get roles",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Get Roles",
+                "responses": {
+                    "200": {
+                        "description": "Return roles",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Base"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or jwt provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Create Role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Role data to create",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BaseBase"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Role successfully created",
+                        "schema": {
+                            "$ref": "#/definitions/responses.CreatedIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or jwt provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Role with such name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete roles by provided IDs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roles"
+                ],
+                "summary": "Delete Roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Role IDs to delete",
+                        "name": "role_ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Roles successfully deleted"
+                    },
+                    "400": {
+                        "description": "Invalid body or jwt provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service": {
+            "post": {
+                "description": "Create a new service between user and trainer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Create Service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Service data to create",
+                        "name": "service",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UserTrainerServiceCreateTrainer"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Service created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.CreatedIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Service belongs to another trainer or email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No service with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service/schedule": {
+            "get": {
+                "description": "Get schedules by an array of schedule IDs. Date and times are in the caller's profile time zone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get Schedules by IDs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Array of schedule IDs",
+                        "name": "schedule_ids",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Return schedules for the given IDs",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.ScheduleServiceUser"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not a participant of one of the schedules",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No schedule with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Schedule a session of the contract. Time must be within trainer availability (see slots) and must not overlap other sessions of the trainer.\nDate and times are in the caller's profile time zone",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Schedule Service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Schedule data to create",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleService"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Schedule created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.CreatedIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not a participant of the service or email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No service with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Trainer is not available at this time or time overlaps another session",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service/schedule/{schedule_id}": {
+            "delete": {
+                "description": "Delete a scheduled service by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Delete Scheduled Service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Schedule ID",
+                        "name": "schedule_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Schedule deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid schedule ID or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not a participant of the schedule",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No schedule with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service/status/{service_id}": {
+            "put": {
+                "description": "Update the status of a service\ntype = 1 - payment status\ntype = 2 - trainer confirm status\ntype = 3 - user confirm status\ntype 1 and 2 can be changed by trainer only, type 3 - by user only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Update Service Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "service_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status data to update",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateStatusService"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Status updated successfully"
+                    },
+                    "400": {
+                        "description": "Invalid body or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not a participant of the service or can't change this status or email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No service with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service/trainer": {
+            "get": {
+                "description": "Get services for a trainer with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get Trainer Services",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor for pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of user services with pagination",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceUserPagination"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service/user": {
+            "get": {
+                "description": "Get services for a user with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get User Services",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor for pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of trainer services with pagination",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceTrainerPagination"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service/{id}": {
+            "get": {
+                "description": "Get a service by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Get Service by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Service"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid service ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Service not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/service/{service_id}": {
+            "delete": {
+                "description": "Delete a service by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Services"
+                ],
+                "summary": "Delete Service",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Service ID",
+                        "name": "service_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Service deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid service ID or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Caller is not a participant of the service",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No service with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/specialization": {
+            "get": {
+                "description": "Get specializations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Specializations"
+                ],
+                "summary": "Get Specializations",
+                "responses": {
+                    "200": {
+                        "description": "Return specializations",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Base"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or jwt provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new specialization",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Specializations"
+                ],
+                "summary": "Create Specialization",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Specializations data to create",
+                        "name": "specialization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.BaseBase"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Specializations successfully created",
+                        "schema": {
+                            "$ref": "#/definitions/responses.CreatedIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or jwt provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Specialization with such name already exists",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete specializations by provided IDs",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Specializations"
+                ],
+                "summary": "Delete Specializations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Specialization IDs to delete",
+                        "name": "specialization_ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Specializations successfully deleted"
+                    },
+                    "400": {
+                        "description": "Invalid body or jwt provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trainer": {
+            "get": {
+                "description": "Get trainer covers with pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trainers"
+                ],
+                "summary": "Get Trainer Covers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Cursor for pagination",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Role IDs",
+                        "name": "role_ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Specialization IDs",
+                        "name": "specialization_ids",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of trainer covers with pagination",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrainerCoverPagination"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trainer/achievement": {
+            "post": {
+                "description": "Create a new achievement for the trainer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trainers"
+                ],
+                "summary": "Create Trainer's Achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Achievement data to create",
+                        "name": "achievement",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AchievementCreate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Achievement successfully created",
+                        "schema": {
+                            "$ref": "#/definitions/responses.CreatedIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid body or jwt provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trainer/achievement/{achievement_id}": {
+            "delete": {
+                "description": "Delete an existing achievement for the trainer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trainers"
+                ],
+                "summary": "Delete Trainer's Achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Achievement ID",
+                        "name": "achievement_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Achievement successfully deleted"
+                    },
+                    "400": {
+                        "description": "Invalid body or jwt provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trainer/achievement/{achievement_id}/status": {
+            "put": {
+                "description": "Update the status of an existing achievement for the trainer",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trainers"
+                ],
+                "summary": "Update Trainer's Achievement Status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Achievement ID",
+                        "name": "achievement_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Achievement status to update",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AchievementStatusUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Achievement status successfully updated"
+                    },
+                    "400": {
+                        "description": "Invalid body or jwt provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trainer/application": {
+            "post": {
+                "description": "Apply to become a trainer. The application is reviewed by an admin, on approval the applicant receives an email with a link to set the password",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trainer Applications"
+                ],
+                "summary": "Submit Trainer Application",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "JSON object dto.TrainerApplicationCreate",
+                        "name": "application",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Up to 5 certificates with type pdf/jpeg/jpg/png under 5MB each",
+                        "name": "certificates",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Return created application's id",
+                        "schema": {
+                            "$ref": "#/definitions/responses.CreatedIDResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad application or certificates provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Email already belongs to a trainer or has a pending application",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trainer/availability": {
+            "get": {
+                "description": "Get own weekly availability template, exceptions and imported busy time that are not over yet",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Availability"
+                ],
+                "summary": "Get Trainer Availability",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Weekly template, exceptions and imported busy time",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Availability"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace own weekly availability template. Weekday is ISO: 1 is Monday, 7 is Sunday; only time of time_start and time_end is used.\nIntervals of one day must not overlap. Already scheduled sessions are kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -1306,65 +4908,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Trainers"
+                    "Availability"
                 ],
-                "summary": "Get Trainer Covers",
+                "summary": "Update Trainer Availability",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search term",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Cursor for pagination",
-                        "name": "cursor",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Role IDs",
-                        "name": "role_ids",
-                        "in": "query"
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Specialization IDs",
-                        "name": "specialization_ids",
-                        "in": "query"
+                        "description": "Weekly template",
+                        "name": "availability",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.AvailabilityUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of trainer covers with pagination",
+                        "description": "Availability updated successfully"
+                    },
+                    "400": {
+                        "description": "Invalid body or JWT provided, intervals overlap",
                         "schema": {
-                            "$ref": "#/definitions/dto.TrainerCoverPagination"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid query parameters",
+                    "401": {
+                        "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/trainer/achievement": {
+        "/api/trainer/availability/exception": {
             "post": {
-                "description": "Create a new achievement for the trainer",
+                "description": "Mark days from date_start to date_end inclusive as unavailable: vacation, day off. Already scheduled sessions are kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -1372,9 +4964,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Trainers"
+                    "Availability"
                 ],
-                "summary": "Create Trainer's Achievement",
+                "summary": "Create Availability Exception",
                 "parameters": [
                     {
                         "type": "string",
@@ -1384,43 +4976,46 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Achievement data to create",
-                        "name": "achievement",
+                        "description": "Exception days",
+                        "name": "exception",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.AchievementCreate"
+                            "$ref": "#/definitions/dto.AvailabilityExceptionCreate"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Achievement successfully created",
+                        "description": "Exception created successfully",
                         "schema": {
                             "$ref": "#/definitions/responses.CreatedIDResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid body or jwt provided",
+                        "description": "Invalid body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/trainer/achievement/{achievement_id}": {
+        "/api/trainer/availability/exception/{exception_id}": {
             "delete": {
-                "description": "Delete an existing achievement for the trainer",
+                "description": "Delete own availability exception",
                 "consumes": [
                     "application/json"
                 ],
@@ -1428,9 +5023,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Trainers"
+                    "Availability"
                 ],
-                "summary": "Delete Trainer's Achievement",
+                "summary": "Delete Availability Exception",
                 "parameters": [
                     {
                         "type": "string",
@@ -1441,47 +5036,56 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Achievement ID",
-                        "name": "achievement_id",
+                        "description": "Exception ID",
+                        "name": "exception_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement successfully deleted"
+                        "description": "Exception deleted successfully"
                     },
                     "400": {
-                        "description": "Invalid body or jwt provided",
+                        "description": "Invalid exception ID or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No own exception with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/api/trainer/achievement/{achievement_id}/status": {
-            "put": {
-                "description": "Update the status of an existing achievement for the trainer",
+        "/api/trainer/availability/import": {
+            "post": {
+                "description": "Import an iCalendar (.ics) file to block busy time for a year ahead: its events are excluded from free slots and cannot be booked.\nThe import replaces the previously imported future busy time. Cancelled and transparent events are skipped, recurring events are expanded\nfor the RRULE subset FREQ=DAILY|WEEKLY|MONTHLY. Times without a time zone are treated as the trainer's profile time zone",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Trainers"
+                    "Availability"
                 ],
-                "summary": "Update Trainer's Achievement Status",
+                "summary": "Import Busy Time",
                 "parameters": [
                     {
                         "type": "string",
@@ -1491,40 +5095,82 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Achievement ID",
-                        "name": "achievement_id",
-                        "in": "path",
+                        "type": "file",
+                        "description": "Calendar .ics under 2MB",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Number of imported busy intervals",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BusyImport"
+                        }
                     },
-                    {
-                        "description": "Achievement status to update",
-                        "name": "status",
-                        "in": "body",
-                        "required": true,
+                    "400": {
+                        "description": "Bad file, calendar or JWT provided, file is too large or has too many events",
                         "schema": {
-                            "$ref": "#/definitions/dto.AchievementStatusUpdate"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
+                }
+            },
+            "delete": {
+                "description": "Delete future busy time imported from a calendar",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Availability"
+                ],
+                "summary": "Clear Imported Busy Time",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement status successfully updated"
+                        "description": "Imported busy time deleted successfully"
                     },
                     "400": {
-                        "description": "Invalid body or jwt provided",
+                        "description": "Invalid JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -1567,17 +5213,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid body or jwt provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -1614,17 +5263,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -1664,17 +5316,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid photo or jwt provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -1720,17 +5375,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid body or jwt provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -1773,17 +5431,32 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid body or jwt provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No service of the trainer with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -1827,17 +5500,26 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid body or jwt provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email is not verified",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -1878,17 +5560,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid body or jwt provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -1934,17 +5619,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid body or jwt provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -1981,17 +5669,106 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No trainer with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trainer/{trainer_id}/slots": {
+            "get": {
+                "description": "Get free slots of the trainer from date_start to date_end inclusive, at most 62 days: working time by the weekly template\nwithout exception days, scheduled sessions and past time, split into slots of ` + "`" + `duration` + "`" + ` minutes.\nDays and slot times are in the caller's profile time zone, the template is in the trainer's one",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Availability"
+                ],
+                "summary": "Get Trainer Free Slots",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Trainer ID",
+                        "name": "trainer_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "First day, YYYY-MM-DD",
+                        "name": "date_start",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day, YYYY-MM-DD",
+                        "name": "date_end",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Slot length in minutes from 15 to 480, 60 by default",
+                        "name": "duration",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Free slots",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Slot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid trainer ID, query or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2033,11 +5810,14 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid query parameters",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -2081,17 +5861,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2137,24 +5920,33 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
         "/api/training/date": {
             "get": {
-                "description": "Get trainings by user training IDs",
+                "description": "Get trainings by user training IDs. Date and times are in the caller's profile time zone",
                 "consumes": [
                     "application/json"
                 ],
@@ -2166,6 +5958,13 @@ const docTemplate = `{
                 ],
                 "summary": "Get Trainings Date",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "array",
                         "items": {
@@ -2182,26 +5981,41 @@ const docTemplate = `{
                     "200": {
                         "description": "Return trainings with dates",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.UserTraining"
-                            }
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.UserTraining"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user training IDs or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid user training IDs",
+                    "403": {
+                        "description": "One of scheduled trainings belongs to another user",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "No schedule training with such ID",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2243,11 +6057,14 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid query parameters",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -2294,17 +6111,26 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin is not a content moderator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2344,17 +6170,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad query or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -2398,17 +6227,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2427,6 +6259,13 @@ const docTemplate = `{
                 ],
                 "summary": "Create Plan Trainer",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "description": "User ID",
@@ -2452,13 +6291,28 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Bad body provided",
+                        "description": "Bad body or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not a client of the trainer or email is not verified",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2477,6 +6331,13 @@ const docTemplate = `{
                 ],
                 "summary": "Get Plan",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "description": "Plan ID",
@@ -2493,19 +6354,34 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid plan ID",
+                        "description": "Invalid plan ID or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Plan belongs to another user",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "No plan with such ID",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -2522,6 +6398,13 @@ const docTemplate = `{
                 ],
                 "summary": "Delete Plan",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "description": "Plan ID",
@@ -2535,19 +6418,34 @@ const docTemplate = `{
                         "description": "Plan deleted successfully"
                     },
                     "400": {
-                        "description": "Invalid plan ID",
+                        "description": "Invalid plan ID or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Plan belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No plan with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2610,24 +6508,86 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad query or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
         "/api/training/schedule": {
-            "get": {
-                "description": "Get schedule for the specified month",
+            "post": {
+                "description": "Schedule training. With rrule (RFC 5545 subset: FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY, COUNT, UNTIL) a recurring series starting at date is created instead and {\"series_id\"} is returned.\nDate and times are in the user's profile time zone, a series repeats in it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trainings"
+                ],
+                "summary": "Schedule Training",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Scheduled training data to create",
+                        "name": "training",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ScheduleTraining"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Scheduled training successfully created",
+                        "schema": {
+                            "$ref": "#/definitions/responses.CreatedIDIDsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad body, recurrence rule or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/training/schedule/series/{series_id}": {
+            "put": {
+                "description": "Change the recurrence rule, start date and time of the whole series. Saved and cancelled occurrences are kept",
                 "consumes": [
                     "application/json"
                 ],
@@ -2637,7 +6597,7 @@ const docTemplate = `{
                 "tags": [
                     "Trainings"
                 ],
-                "summary": "Get Schedule",
+                "summary": "Update Training Series",
                 "parameters": [
                     {
                         "type": "string",
@@ -2648,41 +6608,190 @@ const docTemplate = `{
                     },
                     {
                         "type": "integer",
-                        "description": "Month (1-12)",
-                        "name": "month",
-                        "in": "query",
+                        "description": "Series ID",
+                        "name": "series_id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New series data",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrainingSeriesUpdate"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Return schedule for the month",
+                        "description": "Series updated successfully"
+                    },
+                    "400": {
+                        "description": "Bad body, recurrence rule, series ID or JWT provided",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.TrainingSchedule"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No own series with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete the whole series. Occurrences that were saved separately stay as ordinary scheduled trainings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trainings"
+                ],
+                "summary": "Delete Training Series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "series_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Series deleted successfully"
+                    },
+                    "400": {
+                        "description": "Invalid series ID or JWT provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "JWT is expired or invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No own series with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/training/schedule/series/{series_id}/occurrence/{date}": {
+            "put": {
+                "description": "Move a single occurrence of the series, the rest of the series is not changed. The occurrence is saved as a scheduled training with exercises of the series, its id is returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trainings"
+                ],
+                "summary": "Update Series Occurrence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "series_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Original occurrence date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New occurrence date and time",
+                        "name": "occurrence",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TrainingOccurrenceUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Occurrence saved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/responses.CreatedIDResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad month or JWT provided",
+                        "description": "Bad body, path or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No own series with such ID or no occurrence on this date",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             },
-            "post": {
-                "description": "Schedule training",
+            "delete": {
+                "description": "Cancel a single occurrence of the series, the rest of the series is not changed",
                 "consumes": [
                     "application/json"
                 ],
@@ -2692,7 +6801,7 @@ const docTemplate = `{
                 "tags": [
                     "Trainings"
                 ],
-                "summary": "Schedule Training",
+                "summary": "Cancel Series Occurrence",
                 "parameters": [
                     {
                         "type": "string",
@@ -2702,36 +6811,47 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Scheduled training data to create",
-                        "name": "training",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dto.ScheduleTraining"
-                        }
+                        "type": "integer",
+                        "description": "Series ID",
+                        "name": "series_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Original occurrence date (YYYY-MM-DD)",
+                        "name": "date",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Scheduled training successfully created",
-                        "schema": {
-                            "$ref": "#/definitions/responses.CreatedIDResponse"
-                        }
+                    "200": {
+                        "description": "Occurrence cancelled successfully"
                     },
                     "400": {
-                        "description": "Bad body or JWT provided",
+                        "description": "Invalid path or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No own series with such ID or no occurrence on this date",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2750,6 +6870,13 @@ const docTemplate = `{
                 ],
                 "summary": "Delete Scheduled Training",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "description": "User Training ID",
@@ -2763,19 +6890,34 @@ const docTemplate = `{
                         "description": "Scheduled training deleted successfully"
                     },
                     "400": {
-                        "description": "Invalid user training ID",
+                        "description": "Invalid user training ID or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Scheduled training belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No scheduled training with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2824,17 +6966,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad query or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             },
@@ -2878,17 +7023,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2937,17 +7085,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad query or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -2966,6 +7117,13 @@ const docTemplate = `{
                 ],
                 "summary": "Delete User Training",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Access token",
+                        "name": "access_token",
+                        "in": "header",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "description": "Training ID",
@@ -2979,19 +7137,34 @@ const docTemplate = `{
                         "description": "Training deleted successfully"
                     },
                     "400": {
-                        "description": "Invalid training ID",
+                        "description": "Invalid training ID or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Training belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No training with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -3028,17 +7201,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid training ID",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "No training with such ID",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -3095,17 +7271,32 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Scheduled training belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No scheduled training with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -3142,17 +7333,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid training ID",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "No training with such ID",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -3194,11 +7388,14 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid query parameters",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -3241,17 +7438,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid body or jwt provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -3288,17 +7488,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad body or JWT provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -3338,17 +7541,20 @@ const docTemplate = `{
                     "400": {
                         "description": "Invalid photo or jwt provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "JWT is expired or invalid",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
@@ -3385,85 +7591,349 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad body provided",
                         "schema": {
-                            "$ref": "#/definitions/responses.MessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No user with such ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error"
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.AchievementCreate": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "maxLength": 150,
+                    "minLength": 2
+                }
+            }
+        },
+        "dto.AchievementStatusUpdate": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.Admin": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.ApplicationReject": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 2
+                }
+            }
+        },
+        "dto.Attachment": {
+            "type": "object",
+            "properties": {
+                "content_type": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Auth": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                }
+            }
+        },
+        "dto.Availability": {
+            "type": "object",
+            "properties": {
+                "busy": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Slot"
+                    }
+                },
+                "exceptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AvailabilityException"
+                    }
+                },
+                "weekly": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.AvailabilityInterval"
+                    }
+                }
+            }
+        },
+        "dto.AvailabilityException": {
+            "type": "object",
+            "properties": {
+                "date_end": {
+                    "type": "string"
+                },
+                "date_start": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AvailabilityExceptionCreate": {
+            "type": "object",
+            "required": [
+                "date_end",
+                "date_start"
+            ],
+            "properties": {
+                "date_end": {
+                    "type": "string"
+                },
+                "date_start": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "dto.AvailabilityInterval": {
+            "type": "object",
+            "required": [
+                "time_end",
+                "time_start"
+            ],
+            "properties": {
+                "time_end": {
+                    "type": "string"
+                },
+                "time_start": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "type": "integer",
+                    "maximum": 7,
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.AvailabilityUpdate": {
+            "type": "object",
+            "properties": {
+                "weekly": {
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "$ref": "#/definitions/dto.AvailabilityInterval"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.AchievementCreate": {
+        },
+        "dto.Base": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BaseBase": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BaseStatus": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "is_confirmed": {
+                    "type": "boolean"
+                },
                 "name": {
-                    "type": "string",
-                    "maxLength": 150,
-                    "minLength": 2
+                    "type": "string"
                 }
             }
         },
-        "dto.AchievementStatusUpdate": {
+        "dto.BlockedAccount": {
             "type": "object",
             "properties": {
-                "status": {
-                    "type": "boolean"
+                "created_at": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "photo_url": {
+                    "type": "string"
                 }
             }
         },
-        "dto.Auth": {
+        "dto.BusyImport": {
             "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
             "properties": {
-                "email": {
+                "imported": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CalendarCounterpart": {
+            "type": "object",
+            "properties": {
+                "first_name": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "maxLength": 64,
-                    "minLength": 8
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "photo_url": {
+                    "type": "string"
                 }
             }
         },
-        "dto.Base": {
+        "dto.CalendarEvent": {
             "type": "object",
             "properties": {
+                "contract_id": {
+                    "type": "integer"
+                },
+                "counterpart": {
+                    "$ref": "#/definitions/dto.CalendarCounterpart"
+                },
+                "date": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "name": {
+                "occurrence_date": {
+                    "type": "string"
+                },
+                "series_id": {
+                    "type": "integer"
+                },
+                "time_end": {
+                    "type": "string"
+                },
+                "time_start": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "training_id": {
+                    "type": "integer"
+                },
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "dto.BaseBase": {
+        "dto.CalendarFeed": {
             "type": "object",
             "properties": {
-                "name": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "dto.BaseStatus": {
+        "dto.Certificate": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "integer"
                 },
-                "is_confirmed": {
-                    "type": "boolean"
-                },
                 "name": {
                     "type": "string"
+                },
+                "url": {
+                    "type": "string"
                 }
             }
         },
@@ -3487,6 +7957,45 @@ const docTemplate = `{
                 },
                 "time_last_message": {
                     "type": "string"
+                },
+                "unread_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.DataExport": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ready_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DeletionRequest": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DeletionSchedule": {
+            "type": "object",
+            "properties": {
+                "delete_at": {
+                    "type": "string"
                 }
             }
         },
@@ -3686,15 +8195,52 @@ const docTemplate = `{
         "dto.Message": {
             "type": "object",
             "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Attachment"
+                    }
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "contract_id": {
+                    "type": "integer"
+                },
+                "deleted_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
+                "is_system": {
+                    "type": "boolean"
+                },
                 "is_to_user": {
                     "type": "boolean"
                 },
                 "message": {
                     "type": "string"
                 },
+                "offer_expires_at": {
+                    "type": "string"
+                },
+                "offer_status": {
+                    "description": "pending, accepted, declined или expired - только у предложений услуги",
+                    "type": "string"
+                },
+                "read_at": {
+                    "type": "string"
+                },
+                "reply_to_id": {
+                    "type": "integer"
+                },
                 "service_id": {
                     "type": "integer"
                 },
@@ -3709,6 +8255,71 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.MessageEdit": {
+            "type": "object",
+            "properties": {
+                "edited_at": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MessageFound": {
+            "type": "object",
+            "properties": {
+                "chat_id": {
+                    "description": "Собеседник: тренер для пользователя, пользователь для тренера",
+                    "type": "integer"
+                },
+                "cursor": {
+                    "description": "Курсор GetChatMessage, с которого начинается страница с этим сообщением",
+                    "type": "integer"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "highlight": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_to_user": {
+                    "type": "boolean"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "string"
+                },
+                "trainer_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MessageFoundPagination": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "type": "integer"
+                },
+                "objects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MessageFound"
+                    }
+                }
+            }
+        },
         "dto.MessagePagination": {
             "type": "object",
             "properties": {
@@ -3718,11 +8329,117 @@ const docTemplate = `{
                 "objects": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.Message"
+                        "$ref": "#/definitions/dto.Message"
+                    }
+                }
+            }
+        },
+        "dto.MessageReport": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "$ref": "#/definitions/dto.Message"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reviewed_at": {
+                    "type": "string"
+                },
+                "reviewed_by": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "trainer_id": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MessageReportCreate": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 1000
+                }
+            }
+        },
+        "dto.MessageReportPagination": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "type": "integer"
+                },
+                "objects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.MessageReport"
                     }
                 }
             }
         },
+        "dto.MessageUpdate": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "maxLength": 4096
+                }
+            }
+        },
+        "dto.PasswordForgot": {
+            "type": "object",
+            "required": [
+                "email",
+                "user_type"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "user_type": {
+                    "type": "string",
+                    "enum": [
+                        "user",
+                        "trainer"
+                    ]
+                }
+            }
+        },
+        "dto.PasswordReset": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 64,
+                    "minLength": 8
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.Plan": {
             "type": "object",
             "properties": {
@@ -3777,6 +8494,20 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.Presence": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "is_online": {
+                    "type": "boolean"
+                },
+                "last_seen_at": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.Progress": {
             "type": "object",
             "properties": {
@@ -3822,6 +8553,32 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.RecoveryCodes": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.ReportReview": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "resolved",
+                        "dismissed"
+                    ]
+                }
+            }
+        },
         "dto.ScheduleService": {
             "type": "object",
             "properties": {
@@ -3898,6 +8655,9 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "rrule": {
+                    "type": "string"
+                },
                 "time_end": {
                     "type": "string"
                 },
@@ -3983,70 +8743,295 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.ServiceUpdate": {
+        "dto.ServiceUpdate": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "integer"
+                },
+                "profile_access": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.ServiceUser": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "is_payed": {
+                    "type": "boolean"
+                },
+                "service": {
+                    "$ref": "#/definitions/dto.Service"
+                },
+                "service_id": {
+                    "type": "integer"
+                },
+                "trainer_confirm": {
+                    "type": "boolean"
+                },
+                "trainer_id": {
+                    "type": "integer"
+                },
+                "user": {
+                    "$ref": "#/definitions/dto.UserCover"
+                },
+                "user_confirm": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.ServiceUserPagination": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "type": "integer"
+                },
+                "objects": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ServiceUser"
+                    }
+                }
+            }
+        },
+        "dto.Session": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "device": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "is_current": {
+                    "type": "boolean"
+                },
+                "last_used_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Slot": {
+            "type": "object",
+            "properties": {
+                "time_end": {
+                    "type": "string"
+                },
+                "time_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Suspension": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "minLength": 2
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Token": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Trainer": {
+            "type": "object",
+            "required": [
+                "age",
+                "experience",
+                "first_name",
+                "last_name",
+                "sex"
+            ],
+            "properties": {
+                "achievements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BaseStatus"
+                    }
+                },
+                "age": {
+                    "type": "integer",
+                    "maximum": 150,
+                    "minimum": 18
+                },
+                "email": {
+                    "type": "string"
+                },
+                "experience": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 0
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "photo_url": {
+                    "type": "string"
+                },
+                "quote": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Base"
+                    }
+                },
+                "services": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Service"
+                    }
+                },
+                "sex": {
+                    "type": "integer",
+                    "enum": [
+                        1,
+                        2
+                    ]
+                },
+                "specializations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Base"
+                    }
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TrainerApplication": {
             "type": "object",
+            "required": [
+                "age",
+                "experience",
+                "first_name",
+                "last_name",
+                "sex"
+            ],
             "properties": {
-                "id": {
-                    "type": "integer"
+                "age": {
+                    "type": "integer",
+                    "maximum": 150,
+                    "minimum": 18
                 },
-                "name": {
+                "certificates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Certificate"
+                    }
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "price": {
-                    "type": "integer"
+                "email": {
+                    "type": "string"
+                },
+                "experience": {
+                    "type": "integer",
+                    "maximum": 50,
+                    "minimum": 0
+                },
+                "first_name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
                 },
-                "profile_access": {
-                    "type": "boolean"
-                }
-            }
-        },
-        "dto.ServiceUser": {
-            "type": "object",
-            "properties": {
                 "id": {
                     "type": "integer"
                 },
-                "is_payed": {
-                    "type": "boolean"
+                "last_name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
                 },
-                "service": {
-                    "$ref": "#/definitions/dto.Service"
+                "quote": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "service_id": {
-                    "type": "integer"
+                "reason": {
+                    "type": "string"
                 },
-                "trainer_confirm": {
-                    "type": "boolean"
+                "reviewed_at": {
+                    "type": "string"
                 },
-                "trainer_id": {
+                "reviewed_by": {
                     "type": "integer"
                 },
-                "user": {
-                    "$ref": "#/definitions/dto.UserCover"
-                },
-                "user_confirm": {
-                    "type": "boolean"
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Base"
+                    }
                 },
-                "user_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "dto.ServiceUserPagination": {
-            "type": "object",
-            "properties": {
-                "cursor": {
-                    "type": "integer"
+                "sex": {
+                    "type": "integer",
+                    "enum": [
+                        1,
+                        2
+                    ]
                 },
-                "objects": {
+                "specializations": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.ServiceUser"
+                        "$ref": "#/definitions/dto.Base"
                     }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "trainer_id": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.Trainer": {
+        "dto.TrainerApplicationCover": {
             "type": "object",
             "required": [
                 "age",
@@ -4056,17 +9041,14 @@ const docTemplate = `{
                 "sex"
             ],
             "properties": {
-                "achievements": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.BaseStatus"
-                    }
-                },
                 "age": {
                     "type": "integer",
                     "maximum": 150,
                     "minimum": 18
                 },
+                "created_at": {
+                    "type": "string"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -4088,25 +9070,10 @@ const docTemplate = `{
                     "maxLength": 50,
                     "minLength": 2
                 },
-                "photo_url": {
-                    "type": "string"
-                },
                 "quote": {
                     "type": "string",
                     "maxLength": 100
                 },
-                "roles": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.Base"
-                    }
-                },
-                "services": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.Service"
-                    }
-                },
                 "sex": {
                     "type": "integer",
                     "enum": [
@@ -4114,10 +9081,21 @@ const docTemplate = `{
                         2
                     ]
                 },
-                "specializations": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TrainerApplicationCoverPagination": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "type": "integer"
+                },
+                "objects": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dto.Base"
+                        "$ref": "#/definitions/dto.TrainerApplicationCover"
                     }
                 }
             }
@@ -4294,6 +9272,9 @@ const docTemplate = `{
                         1,
                         2
                     ]
+                },
+                "timezone": {
+                    "type": "string"
                 }
             }
         },
@@ -4439,17 +9420,34 @@ const docTemplate = `{
                 }
             }
         },
-        "dto.TrainingSchedule": {
+        "dto.TrainingOccurrenceUpdate": {
             "type": "object",
             "properties": {
                 "date": {
                     "type": "string"
                 },
-                "user_training_ids": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "time_end": {
+                    "type": "string"
+                },
+                "time_start": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TrainingSeriesUpdate": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "rrule": {
+                    "type": "string"
+                },
+                "time_end": {
+                    "type": "string"
+                },
+                "time_start": {
+                    "type": "string"
                 }
             }
         },
@@ -4479,6 +9477,54 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.TwoFactorChallenge": {
+            "type": "object",
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.TwoFactorCode": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TwoFactorLogin": {
+            "type": "object",
+            "required": [
+                "challenge_token",
+                "code"
+            ],
+            "properties": {
+                "challenge_token": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TwoFactorSetup": {
+            "type": "object",
+            "properties": {
+                "secret": {
+                    "type": "string"
+                },
+                "uri": {
+                    "type": "string"
+                }
+            }
+        },
         "dto.UpdateStatusService": {
             "type": "object",
             "properties": {
@@ -4529,6 +9575,9 @@ const docTemplate = `{
                         1,
                         2
                     ]
+                },
+                "timezone": {
+                    "type": "string"
                 }
             }
         },
@@ -4705,6 +9754,23 @@ const docTemplate = `{
                         1,
                         2
                     ]
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "responses.CreatedIDIDsResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 }
             }
         },
@@ -4727,9 +9793,32 @@ const docTemplate = `{
                 }
             }
         },
-        "responses.MessageResponse": {
+        "responses.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.FieldError"
+                    }
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "responses.FieldError": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
                 "message": {
                     "type": "string"
                 }
@@ -4745,6 +9834,46 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "utils.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "utils.JWKS": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.JWK"
+                    }
+                }
+            }
         }
     }
 }`
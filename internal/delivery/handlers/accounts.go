@@ -0,0 +1,174 @@
+package handlers
+
+import (
+	"BACKEND/internal/delivery/middleware"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/services"
+	"BACKEND/internal/validators"
+	"BACKEND/pkg/responses"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"strconv"
+)
+
+type AccountHandler struct {
+	accountService      services.Accounts
+	personalDataService services.PersonalData
+	validate            *validator.Validate
+}
+
+func InitAccountHandler(
+	accountService services.Accounts,
+	personalDataService services.PersonalData,
+	validate *validator.Validate,
+) *AccountHandler {
+	return &AccountHandler{
+		accountService:      accountService,
+		personalDataService: personalDataService,
+		validate:            validate,
+	}
+}
+
+// RequestDeletion
+// @Summary Request account deletion
+// @Description Schedule deletion of the current account after the grace period. Until then deletion can be canceled, after it the account and its data are deleted and the chat history is anonymised
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param password body dto.DeletionRequest true "Current password"
+// @Success 200 {object} dto.DeletionSchedule "Return time of deletion"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided, password is wrong"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/account/deletion [post]
+func (a AccountHandler) RequestDeletion(c *gin.Context) {
+	var request dto.DeletionRequest
+
+	if err := c.ShouldBindJSON(&request); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err := a.validate.Struct(request); err != nil {
+		c.Error(validators.ValidationError(err, &dto.DeletionRequest{}))
+		return
+	}
+
+	schedule, err := a.accountService.RequestDeletion(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType), request.Password)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schedule)
+}
+
+// CancelDeletion
+// @Summary Cancel account deletion
+// @Description Cancel scheduled deletion of the current account
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 200 "Deletion canceled successfully"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 409 {object} responses.ErrorResponse "Deletion is not requested"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/account/deletion [delete]
+func (a AccountHandler) CancelDeletion(c *gin.Context) {
+	err := a.accountService.CancelDeletion(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// RequestExport
+// @Summary Request personal data export
+// @Description Start preparing a zip archive with profile, trainings, schedules, progress, plans, services and chat messages in JSON. When the archive is ready, an email is sent
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 202 {object} responses.CreatedIDResponse "Return created export's id"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 409 {object} responses.ErrorResponse "Another export is being prepared"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/account/export [post]
+func (a AccountHandler) RequestExport(c *gin.Context) {
+	exportID, err := a.personalDataService.RequestExport(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, responses.CreatedIDResponse{ID: exportID})
+}
+
+// GetExport
+// @Summary Get personal data export
+// @Description Get status of the personal data export
+// @Tags Account
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param export_id path int true "Export ID"
+// @Success 200 {object} dto.DataExport "Return export"
+// @Failure 400 {object} responses.ErrorResponse "Invalid export ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 404 {object} responses.ErrorResponse "No export with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/account/export/{export_id} [get]
+func (a AccountHandler) GetExport(c *gin.Context) {
+	exportID, err := strconv.Atoi(c.Param("export_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	export, err := a.personalDataService.GetExport(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType), exportID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, export)
+}
+
+// DownloadExport
+// @Summary Download personal data export
+// @Description Download the zip archive of the ready personal data export
+// @Tags Account
+// @Produce application/zip
+// @Param access_token header string true "Access token"
+// @Param export_id path int true "Export ID"
+// @Success 200 {file} file "Zip archive with JSON files"
+// @Failure 400 {object} responses.ErrorResponse "Invalid export ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 404 {object} responses.ErrorResponse "No export with such ID"
+// @Failure 409 {object} responses.ErrorResponse "Export is not ready"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/account/export/{export_id}/download [get]
+func (a AccountHandler) DownloadExport(c *gin.Context) {
+	exportID, err := strconv.Atoi(c.Param("export_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	filePath, err := a.personalDataService.GetExportFile(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType), exportID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.FileAttachment(filePath, fmt.Sprintf("data-export-%d.zip", exportID))
+}
@@ -0,0 +1,46 @@
+package handlers
+
+import (
+	"BACKEND/internal/delivery/middleware"
+	"BACKEND/internal/services"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type AdminHandler struct {
+	service services.Admins
+}
+
+func InitAdminHandler(
+	service services.Admins,
+) *AdminHandler {
+	return &AdminHandler{
+		service: service,
+	}
+}
+
+// Me
+// @Summary Get Admin Me
+// @Description Get current admin with roles
+// @Tags Admins
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 200 {object} dto.Admin "Return admin"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/admin/me [get]
+func (a AdminHandler) Me(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	adminID := c.GetInt(middleware.UserID)
+
+	admin, err := a.service.GetByID(ctx, adminID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, admin)
+}
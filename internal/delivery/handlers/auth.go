@@ -2,44 +2,52 @@ package handlers
 
 import (
 	"BACKEND/internal/converters"
+	"BACKEND/internal/delivery/middleware"
 	"BACKEND/internal/errs"
 	"BACKEND/internal/models/dto"
 	"BACKEND/internal/services"
 	"BACKEND/internal/validators"
-	"BACKEND/pkg/config"
 	"BACKEND/pkg/responses"
 	"BACKEND/pkg/utils"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
-	"github.com/spf13/viper"
 	"net/http"
 )
 
+// DeviceLabel - заголовок с названием устройства для списка сессий, по умолчанию берется User-Agent
+const DeviceLabel = "X-Device-Label"
+
 type AuthHandler struct {
 	userService      services.Users
 	trainerService   services.Trainers
+	adminService     services.Admins
 	tokenService     services.Tokens
+	accountService   services.Accounts
+	twoFactorService services.TwoFactor
 	userConverter    converters.UserConverter
 	trainerConverter converters.TrainerConverter
 	validate         *validator.Validate
-	APIKEY           string
 }
 
 func InitAuthHandler(
 	userService services.Users,
 	trainerService services.Trainers,
+	adminService services.Admins,
 	tokenService services.Tokens,
+	accountService services.Accounts,
+	twoFactorService services.TwoFactor,
 	validate *validator.Validate,
 ) *AuthHandler {
 	return &AuthHandler{
 		userService:      userService,
 		trainerService:   trainerService,
+		adminService:     adminService,
 		tokenService:     tokenService,
+		accountService:   accountService,
+		twoFactorService: twoFactorService,
 		userConverter:    converters.InitUserConverter(),
 		trainerConverter: converters.InitTrainerConverter(),
 		validate:         validate,
-		APIKEY:           viper.GetString(config.APIKEY),
 	}
 }
 
@@ -49,21 +57,22 @@ func InitAuthHandler(
 // @Tags Authorization
 // @Accept json
 // @Produce json
+// @Param X-Device-Label header string false "Device label for sessions list"
 // @Param auth body dto.UserCreate true "Register request body"
 // @Success 201 {object} responses.TokenResponse "Return tokens"
-// @Failure 400 {object} responses.MessageResponse "Bad body provided"
-// @Failure 500 "Internal Server Error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body provided"
+// @Failure 409 {object} responses.ErrorResponse "User with such email already exists"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/auth/register/user [post]
 func (a AuthHandler) RegisterUser(c *gin.Context) {
 	var user dto.UserCreate
 	if err := c.ShouldBindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	if err := a.validate.Struct(user); err != nil {
-		customErr := validators.CustomErrorMessage(err, &dto.UserCreate{})
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: customErr})
+		c.Error(validators.ValidationError(err, &dto.UserCreate{}))
 		return
 	}
 
@@ -71,18 +80,16 @@ func (a AuthHandler) RegisterUser(c *gin.Context) {
 
 	id, err := a.userService.Register(ctx, a.userConverter.UserCreateDTOToDomain(user))
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrAlreadyExist):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
-	tokens, err := a.tokenService.Create(ctx, id, utils.User)
+	// Ошибка отправки уже залогирована, письмо можно запросить повторно
+	_ = a.accountService.SendVerification(ctx, id, utils.User)
+
+	tokens, err := a.tokenService.Create(ctx, newSessionData(c, id, utils.User, false))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -95,40 +102,37 @@ func (a AuthHandler) RegisterUser(c *gin.Context) {
 // @Tags Authorization
 // @Accept json
 // @Produce json
+// @Param X-Device-Label header string false "Device label for sessions list"
 // @Param auth body dto.Auth true "Authorization request body"
 // @Success 200 {object} responses.TokenResponse "Return tokens"
-// @Failure 400 {object} responses.MessageResponse "Bad body provided"
-// @Failure 500 "Internal Server Error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body provided"
+// @Failure 401 {object} responses.ErrorResponse "Invalid email or password"
+// @Failure 429 {object} responses.ErrorResponse "Too many failed attempts, login is temporarily locked"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/auth/login/user [post]
 func (a AuthHandler) AuthorizeUser(c *gin.Context) {
 	var user dto.Auth
 	if err := c.ShouldBindJSON(&user); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	if err := a.validate.Struct(user); err != nil {
-		customErr := validators.CustomErrorMessage(err, &dto.Auth{})
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: customErr})
+		c.Error(validators.ValidationError(err, &dto.Auth{}))
 		return
 	}
 
 	ctx := c.Request.Context()
 
-	id, err := a.userService.Login(ctx, user)
+	id, isVerified, err := a.userService.Login(ctx, user, c.ClientIP())
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.InvalidEmail), errors.Is(err, errs.InvalidPassword):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
-	tokens, err := a.tokenService.Create(ctx, id, utils.User)
+	tokens, err := a.tokenService.Create(ctx, newSessionData(c, id, utils.User, isVerified))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -144,20 +148,21 @@ func (a AuthHandler) AuthorizeUser(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param auth body dto.TrainerCreate true "Register request body"
 // @Success 201 {object} responses.CreatedIDResponse "Return created trainer's id"
-// @Failure 400 {object} responses.MessageResponse "Bad body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal Server Error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided"
+// @Failure 409 {object} responses.ErrorResponse "Trainer with such email already exists"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not responsible for trainer onboarding"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/auth/register/trainer [post]
 func (a AuthHandler) RegisterTrainer(c *gin.Context) {
 	var trainer dto.TrainerCreate
 	if err := c.ShouldBindJSON(&trainer); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	if err := a.validate.Struct(trainer); err != nil {
-		customErr := validators.CustomErrorMessage(err, &dto.TrainerCreate{})
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: customErr})
+		c.Error(validators.ValidationError(err, &dto.TrainerCreate{}))
 		return
 	}
 
@@ -165,86 +170,131 @@ func (a AuthHandler) RegisterTrainer(c *gin.Context) {
 
 	id, err := a.trainerService.Register(ctx, a.trainerConverter.TrainerCreateDTOToDomain(trainer))
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrAlreadyExist):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
+	// Ошибка отправки уже залогирована, тренер может запросить письмо повторно
+	_ = a.accountService.SendVerification(ctx, id, utils.Trainer)
+
 	c.JSON(http.StatusCreated, responses.CreatedIDResponse{ID: id})
 }
 
 // AuthorizeTrainer
 // @Summary Trainer Authorization
-// @Description Authorize trainer
+// @Description Authorize trainer. If two-factor authentication is enabled, returns a challenge token for `/api/auth/login/2fa` instead of tokens
 // @Tags Authorization
 // @Accept json
 // @Produce json
+// @Param X-Device-Label header string false "Device label for sessions list"
 // @Param auth body dto.Auth true "Authorization request body"
 // @Success 200 {object} responses.TokenResponse "Return tokens"
-// @Failure 400 {object} responses.MessageResponse "Bad body provided"
-// @Failure 500 "Internal Server Error"
+// @Success 202 {object} dto.TwoFactorChallenge "Two-factor code required"
+// @Failure 400 {object} responses.ErrorResponse "Bad body provided"
+// @Failure 401 {object} responses.ErrorResponse "Invalid email or password"
+// @Failure 429 {object} responses.ErrorResponse "Too many failed attempts, login is temporarily locked"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/auth/login/trainer [post]
 func (a AuthHandler) AuthorizeTrainer(c *gin.Context) {
 	var trainer dto.Auth
 	if err := c.ShouldBindJSON(&trainer); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	if err := a.validate.Struct(trainer); err != nil {
-		customErr := validators.CustomErrorMessage(err, &dto.Auth{})
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: customErr})
+		c.Error(validators.ValidationError(err, &dto.Auth{}))
 		return
 	}
 
 	ctx := c.Request.Context()
 
-	id, err := a.trainerService.Login(ctx, trainer)
+	id, isVerified, err := a.trainerService.Login(ctx, trainer, c.ClientIP())
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.InvalidEmail), errors.Is(err, errs.InvalidPassword):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
-	tokens, err := a.tokenService.Create(ctx, id, utils.Trainer)
-	if err != nil {
-		c.Status(http.StatusInternalServerError)
-		return
-	}
-
-	c.JSON(http.StatusOK, tokens)
+	a.loginWithTwoFactor(c, newSessionData(c, id, utils.Trainer, isVerified))
 }
 
 // AuthorizeAdmin
 // @Summary Admin Authorization
-// @Description Authorize admin with X-API-KEY
+// @Description Authorize admin. Admin accounts are created with the `create-admin` command.
+// @Description If two-factor authentication is enabled, returns a challenge token for `/api/auth/login/2fa` instead of tokens
 // @Tags Authorization
 // @Accept json
 // @Produce json
-// @Param X-API-KEY header string true "Admin apikey"
+// @Param X-Device-Label header string false "Device label for sessions list"
+// @Param auth body dto.Auth true "Authorization request body"
 // @Success 200 {object} responses.TokenResponse "Return tokens"
-// @Failure 401 {object} responses.MessageResponse "Invalid X-API-KEY"
-// @Failure 500 "Internal Server Error"
+// @Success 202 {object} dto.TwoFactorChallenge "Two-factor code required"
+// @Failure 400 {object} responses.ErrorResponse "Bad body provided"
+// @Failure 401 {object} responses.ErrorResponse "Invalid email or password"
+// @Failure 429 {object} responses.ErrorResponse "Too many failed attempts, login is temporarily locked"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/auth/login/admin [post]
 func (a AuthHandler) AuthorizeAdmin(c *gin.Context) {
-	apiKey := c.GetHeader("X-API-KEY")
+	var admin dto.Auth
+	if err := c.ShouldBindJSON(&admin); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err := a.validate.Struct(admin); err != nil {
+		c.Error(validators.ValidationError(err, &dto.Auth{}))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	id, roles, err := a.adminService.Login(ctx, admin, c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	session := newSessionData(c, id, utils.Admin, true)
+	session.Roles = roles
 
-	if apiKey != a.APIKEY {
-		c.JSON(http.StatusUnauthorized, responses.MessageResponse{Message: "Api key is invalid"})
+	a.loginWithTwoFactor(c, session)
+}
+
+// AuthorizeTwoFactor
+// @Summary Two-factor Authorization
+// @Description Second login step for trainers and admins with two-factor authentication.
+// @Description Accepts a code from the authenticator app or one of the recovery codes. Challenge token is single-use
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param auth body dto.TwoFactorLogin true "Challenge token and code"
+// @Success 200 {object} responses.TokenResponse "Return tokens"
+// @Failure 400 {object} responses.ErrorResponse "Bad body provided, challenge token is invalid or expired or code is invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/login/2fa [post]
+func (a AuthHandler) AuthorizeTwoFactor(c *gin.Context) {
+	var login dto.TwoFactorLogin
+	if err := c.ShouldBindJSON(&login); err != nil {
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
-	tokens, err := a.tokenService.Create(c.Request.Context(), 0, utils.Admin)
+	if err := a.validate.Struct(login); err != nil {
+		c.Error(validators.ValidationError(err, &dto.TwoFactorLogin{}))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	session, err := a.twoFactorService.VerifyChallenge(ctx, login.ChallengeToken, login.Code)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
+		return
+	}
+
+	tokens, err := a.tokenService.Create(ctx, session)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
@@ -259,28 +309,288 @@ func (a AuthHandler) AuthorizeAdmin(c *gin.Context) {
 // @Produce json
 // @Param refresh_token query string true "Refresh token"
 // @Success 200 {object} responses.TokenResponse "Return tokens"
-// @Failure 400 {object} responses.MessageResponse "Bad query provided"
-// @Failure 500 "Internal Server Error"
+// @Failure 400 {object} responses.ErrorResponse "Bad query provided"
+// @Failure 401 {object} responses.ErrorResponse "Refresh token is expired or revoked"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/auth/refresh [get]
 func (a AuthHandler) Refresh(c *gin.Context) {
 	refreshToken, ok := c.GetQuery("refresh_token")
 	if !ok {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	tokens, err := a.tokenService.Refresh(ctx, refreshToken, c.ClientIP())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, tokens)
+}
+
+// Logout
+// @Summary Logout
+// @Description Revoke current session: its refresh and access tokens stop working immediately
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 200 "Session revoked successfully"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/logout [post]
+func (a AuthHandler) Logout(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := a.tokenService.Logout(ctx, c.GetInt(middleware.UserID), c.GetString(middleware.UserType), c.GetString(middleware.SessionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// LogoutAll
+// @Summary Logout everywhere
+// @Description Revoke all sessions of the current account, including the current one
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 200 "Sessions revoked successfully"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/logout/all [post]
+func (a AuthHandler) LogoutAll(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := a.tokenService.LogoutAll(ctx, c.GetInt(middleware.UserID), c.GetString(middleware.UserType))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// GetSessions
+// @Summary Get active sessions
+// @Description Get active sessions of the current account, most recently used first
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 200 {object} []dto.Session "Return sessions"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/session [get]
+func (a AuthHandler) GetSessions(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	sessions, err := a.tokenService.GetSessions(ctx, c.GetInt(middleware.UserID), c.GetString(middleware.UserType), c.GetString(middleware.SessionID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, sessions)
+}
+
+// DeleteSession
+// @Summary Revoke session
+// @Description Revoke one of the current account's sessions, e.g. with a stolen refresh token
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param session_id path string true "Session ID"
+// @Success 200 "Session revoked successfully"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 404 {object} responses.ErrorResponse "No session with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/session/{session_id} [delete]
+func (a AuthHandler) DeleteSession(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := a.tokenService.Logout(ctx, c.GetInt(middleware.UserID), c.GetString(middleware.UserType), c.Param("session_id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// SendVerification
+// @Summary Send email verification
+// @Description Send a new email verification link to the current account
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 200 "Verification mail sent successfully"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 409 {object} responses.ErrorResponse "Email is already verified"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/verify/send [post]
+func (a AuthHandler) SendVerification(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	err := a.accountService.SendVerification(ctx, c.GetInt(middleware.UserID), c.GetString(middleware.UserType))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// Verify
+// @Summary Verify email
+// @Description Confirm email with the token from the verification mail. Token is single-use
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param token body dto.Token true "Verification token"
+// @Success 200 "Email verified successfully"
+// @Failure 400 {object} responses.ErrorResponse "Bad body provided or token is invalid or expired"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/verify [post]
+func (a AuthHandler) Verify(c *gin.Context) {
+	var token dto.Token
+	if err := c.ShouldBindJSON(&token); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err := a.validate.Struct(token); err != nil {
+		c.Error(validators.ValidationError(err, &dto.Token{}))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	err := a.accountService.Verify(ctx, token.Token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// ForgotPassword
+// @Summary Forgot password
+// @Description Send a password reset link. Responds with 200 even if the email is not registered
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param forgot body dto.PasswordForgot true "Account email and type"
+// @Success 200 "Reset mail sent if the account exists"
+// @Failure 400 {object} responses.ErrorResponse "Bad body provided"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/password/forgot [post]
+func (a AuthHandler) ForgotPassword(c *gin.Context) {
+	var forgot dto.PasswordForgot
+	if err := c.ShouldBindJSON(&forgot); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err := a.validate.Struct(forgot); err != nil {
+		c.Error(validators.ValidationError(err, &dto.PasswordForgot{}))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	err := a.accountService.SendPasswordReset(ctx, forgot.Email, forgot.UserType)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// ResetPassword
+// @Summary Reset password
+// @Description Set a new password with the token from the reset mail. All sessions of the account are revoked
+// @Tags Authorization
+// @Accept json
+// @Produce json
+// @Param reset body dto.PasswordReset true "Reset token and new password"
+// @Success 200 "Password changed successfully"
+// @Failure 400 {object} responses.ErrorResponse "Bad body provided or token is invalid or expired"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/password/reset [post]
+func (a AuthHandler) ResetPassword(c *gin.Context) {
+	var reset dto.PasswordReset
+	if err := c.ShouldBindJSON(&reset); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err := a.validate.Struct(reset); err != nil {
+		c.Error(validators.ValidationError(err, &dto.PasswordReset{}))
 		return
 	}
 
 	ctx := c.Request.Context()
 
-	tokens, err := a.tokenService.Refresh(ctx, refreshToken)
+	err := a.accountService.ResetPassword(ctx, reset.Token, reset.Password)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.NeedToAuth):
-			c.JSON(http.StatusUnauthorized, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// loginWithTwoFactor выдает токены или, если включена двухфакторная аутентификация, токен второго шага
+func (a AuthHandler) loginWithTwoFactor(c *gin.Context, session utils.SessionData) {
+	ctx := c.Request.Context()
+
+	challenge, isRequired, err := a.twoFactorService.CreateChallenge(ctx, session)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	if isRequired {
+		c.JSON(http.StatusAccepted, challenge)
+		return
+	}
+
+	tokens, err := a.tokenService.Create(ctx, session)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, tokens)
 }
+
+func newSessionData(c *gin.Context, id int, userType string, isVerified bool) utils.SessionData {
+	device := c.GetHeader(DeviceLabel)
+	if device == "" {
+		device = c.Request.UserAgent()
+	}
+
+	return utils.SessionData{
+		UserID:     id,
+		UserType:   userType,
+		Device:     device,
+		IP:         c.ClientIP(),
+		IsVerified: isVerified,
+	}
+}
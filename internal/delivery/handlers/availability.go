@@ -0,0 +1,277 @@
+package handlers
+
+import (
+	"BACKEND/internal/converters"
+	"BACKEND/internal/delivery/middleware"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/services"
+	"BACKEND/internal/validators"
+	"BACKEND/pkg/responses"
+	"errors"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Наибольший размер импортируемого календаря
+const maxCalendarFileSize = 2 << 20
+
+type AvailabilityHandler struct {
+	service         services.Availability
+	converter       converters.AvailabilityConverter
+	filterConverter converters.FilterConverter
+	validate        *validator.Validate
+}
+
+func InitAvailabilityHandler(
+	service services.Availability,
+	validate *validator.Validate,
+) *AvailabilityHandler {
+	return &AvailabilityHandler{
+		service:         service,
+		converter:       converters.InitAvailabilityConverter(),
+		filterConverter: converters.InitFilterConverter(),
+		validate:        validate,
+	}
+}
+
+// GetAvailability
+// @Summary Get Trainer Availability
+// @Description Get own weekly availability template, exceptions and imported busy time that are not over yet
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 200 {object} dto.Availability "Weekly template, exceptions and imported busy time"
+// @Failure 400 {object} responses.ErrorResponse "Invalid JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/trainer/availability [get]
+func (a AvailabilityHandler) GetAvailability(c *gin.Context) {
+	availability, err := a.service.GetAvailability(c.Request.Context(), c.GetInt(middleware.UserID))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, availability)
+}
+
+// UpdateAvailability
+// @Summary Update Trainer Availability
+// @Description Replace own weekly availability template. Weekday is ISO: 1 is Monday, 7 is Sunday; only time of time_start and time_end is used.
+// @Description Intervals of one day must not overlap. Already scheduled sessions are kept
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param availability body dto.AvailabilityUpdate true "Weekly template"
+// @Success 200 "Availability updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or JWT provided, intervals overlap"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/trainer/availability [put]
+func (a AvailabilityHandler) UpdateAvailability(c *gin.Context) {
+	var availability dto.AvailabilityUpdate
+
+	if err := c.ShouldBindJSON(&availability); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err := a.validate.Struct(availability); err != nil {
+		c.Error(validators.ValidationError(err, &dto.AvailabilityUpdate{}))
+		return
+	}
+
+	err := a.service.UpdateAvailability(c.Request.Context(), c.GetInt(middleware.UserID), a.converter.AvailabilityIntervalsDTOToDomain(availability.Weekly))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// CreateException
+// @Summary Create Availability Exception
+// @Description Mark days from date_start to date_end inclusive as unavailable: vacation, day off. Already scheduled sessions are kept
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param exception body dto.AvailabilityExceptionCreate true "Exception days"
+// @Success 201 {object} responses.CreatedIDResponse "Exception created successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/trainer/availability/exception [post]
+func (a AvailabilityHandler) CreateException(c *gin.Context) {
+	var exception dto.AvailabilityExceptionCreate
+
+	if err := c.ShouldBindJSON(&exception); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err := a.validate.Struct(exception); err != nil {
+		c.Error(validators.ValidationError(err, &dto.AvailabilityExceptionCreate{}))
+		return
+	}
+
+	id, err := a.service.CreateException(c.Request.Context(), a.converter.AvailabilityExceptionCreateDTOToDomain(exception, c.GetInt(middleware.UserID)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.CreatedIDResponse{ID: id})
+}
+
+// DeleteException
+// @Summary Delete Availability Exception
+// @Description Delete own availability exception
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param exception_id path int true "Exception ID"
+// @Success 200 "Exception deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid exception ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 404 {object} responses.ErrorResponse "No own exception with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/trainer/availability/exception/{exception_id} [delete]
+func (a AvailabilityHandler) DeleteException(c *gin.Context) {
+	exceptionID, err := strconv.Atoi(c.Param("exception_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	if err = a.service.DeleteException(c.Request.Context(), c.GetInt(middleware.UserID), exceptionID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// GetSlots
+// @Summary Get Trainer Free Slots
+// @Description Get free slots of the trainer from date_start to date_end inclusive, at most 62 days: working time by the weekly template
+// @Description without exception days, scheduled sessions and past time, split into slots of `duration` minutes.
+// @Description Days and slot times are in the caller's profile time zone, the template is in the trainer's one
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param trainer_id path int true "Trainer ID"
+// @Param date_start query string true "First day, YYYY-MM-DD"
+// @Param date_end query string true "Last day, YYYY-MM-DD"
+// @Param duration query int false "Slot length in minutes from 15 to 480, 60 by default"
+// @Success 200 {array} dto.Slot "Free slots"
+// @Failure 400 {object} responses.ErrorResponse "Invalid trainer ID, query or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/trainer/{trainer_id}/slots [get]
+func (a AvailabilityHandler) GetSlots(c *gin.Context) {
+	trainerID, err := strconv.Atoi(c.Param("trainer_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	var filters dto.FiltersSlots
+
+	if err = c.ShouldBindQuery(&filters); err != nil {
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	if err = a.validate.Struct(filters); err != nil {
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	slots, err := a.service.GetSlots(c.Request.Context(), a.filterConverter.FiltersSlotsDTOToDomain(filters, trainerID, middleware.Caller(c)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, slots)
+}
+
+// ImportBusy
+// @Summary Import Busy Time
+// @Description Import an iCalendar (.ics) file to block busy time for a year ahead: its events are excluded from free slots and cannot be booked.
+// @Description The import replaces the previously imported future busy time. Cancelled and transparent events are skipped, recurring events are expanded
+// @Description for the RRULE subset FREQ=DAILY|WEEKLY|MONTHLY. Times without a time zone are treated as the trainer's profile time zone
+// @Tags Availability
+// @Accept multipart/form-data
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param file formData file true "Calendar .ics under 2MB"
+// @Success 200 {object} dto.BusyImport "Number of imported busy intervals"
+// @Failure 400 {object} responses.ErrorResponse "Bad file, calendar or JWT provided, file is too large or has too many events"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/trainer/availability/import [post]
+func (a AvailabilityHandler) ImportBusy(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxCalendarFileSize+1<<20)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.Error(errs.ErrFileTooLarge)
+			return
+		}
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if !strings.EqualFold(filepath.Ext(file.Filename), ".ics") {
+		c.Error(errs.ErrBadFile)
+		return
+	}
+
+	if file.Size > maxCalendarFileSize {
+		c.Error(errs.ErrFileTooLarge)
+		return
+	}
+
+	imported, err := a.service.ImportBusy(c.Request.Context(), c.GetInt(middleware.UserID), file)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, dto.BusyImport{Imported: imported})
+}
+
+// ClearBusy
+// @Summary Clear Imported Busy Time
+// @Description Delete future busy time imported from a calendar
+// @Tags Availability
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 200 "Imported busy time deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/trainer/availability/import [delete]
+func (a AvailabilityHandler) ClearBusy(c *gin.Context) {
+	if err := a.service.ClearBusy(c.Request.Context(), c.GetInt(middleware.UserID)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
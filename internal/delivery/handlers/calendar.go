@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"BACKEND/internal/converters"
+	"BACKEND/internal/delivery/middleware"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/services"
+	"BACKEND/pkg/utils"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Наибольший период календаря за один запрос
+const maxCalendarDays = 366
+
+type CalendarHandler struct {
+	service         services.Calendar
+	filterConverter converters.FilterConverter
+	validate        *validator.Validate
+}
+
+func InitCalendarHandler(
+	service services.Calendar,
+	validate *validator.Validate,
+) *CalendarHandler {
+	return &CalendarHandler{
+		service:         service,
+		filterConverter: converters.InitFilterConverter(),
+		validate:        validate,
+	}
+}
+
+// GetEvents
+// @Summary Get Calendar
+// @Description Get calendar events from date_start to date_end inclusive, at most 366 days, ordered by start. For a user these are own trainings
+// @Description (`type: training`) and sessions with trainers (`type: session`), for a trainer - sessions with clients. Sessions have the contract and the counterpart.
+// @Description Days and times are in the caller's profile time zone
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param date_start query string true "First day, YYYY-MM-DD"
+// @Param date_end query string true "Last day, YYYY-MM-DD"
+// @Success 200 {array} dto.CalendarEvent "Calendar events"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/calendar [get]
+func (h CalendarHandler) GetEvents(c *gin.Context) {
+	var filters dto.FiltersCalendar
+
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	if err := h.validate.Struct(filters); err != nil {
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	if filters.DateEnd.Before(filters.DateStart) || filters.DateEnd.Sub(filters.DateStart) >= maxCalendarDays*24*time.Hour {
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	events, err := h.service.GetEvents(c.Request.Context(),
+		h.filterConverter.FiltersCalendarDTOToDomain(filters, c.GetInt(middleware.UserID), c.GetString(middleware.UserType)))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
+
+// CreateFeed
+// @Summary Create Calendar Feed
+// @Description Create a secret calendar subscription URL for Google/Apple Calendar, replacing the previous one. The URL is shown only once.
+// @Description The feed contains the same events as GET /api/calendar from 90 days ago to a year ahead, times are in UTC
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 201 {object} dto.CalendarFeed "Subscription URL"
+// @Failure 400 {object} responses.ErrorResponse "Invalid JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/calendar/feed [post]
+func (h CalendarHandler) CreateFeed(c *gin.Context) {
+	feed, err := h.service.CreateFeed(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, feed)
+}
+
+// DeleteFeed
+// @Summary Delete Calendar Feed
+// @Description Revoke the calendar subscription URL
+// @Tags Calendar
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 200 "Feed deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 404 {object} responses.ErrorResponse "Feed is not created"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/calendar/feed [delete]
+func (h CalendarHandler) DeleteFeed(c *gin.Context) {
+	if err := h.service.DeleteFeed(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType)); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// GetFeed
+// @Summary Get Calendar Feed
+// @Description Calendar subscription in iCalendar format. Authorization is the secret token in the URL
+// @Tags Calendar
+// @Produce text/calendar
+// @Param token path string true "Feed token, optionally with .ics suffix"
+// @Success 200 {string} string "iCalendar"
+// @Failure 404 {object} responses.ErrorResponse "No feed with such token"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/calendar/feed/{token} [get]
+func (h CalendarHandler) GetFeed(c *gin.Context) {
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+
+	calendar, err := h.service.GetFeed(c.Request.Context(), token)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Data(http.StatusOK, utils.ICalContentType, calendar)
+}
+
+// GetTraining
+// @Summary Download Training
+// @Description Download a scheduled training as an iCalendar file
+// @Tags Calendar
+// @Produce text/calendar
+// @Param access_token header string true "Access token"
+// @Param user_training_id path int true "User Training ID"
+// @Success 200 {string} string "iCalendar"
+// @Failure 400 {object} responses.ErrorResponse "Invalid user training ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Scheduled training belongs to another user"
+// @Failure 404 {object} responses.ErrorResponse "No scheduled training with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/calendar/training/{user_training_id} [get]
+func (h CalendarHandler) GetTraining(c *gin.Context) {
+	userTrainingID, err := strconv.Atoi(c.Param("user_training_id"))
+	if err != nil || userTrainingID <= 0 {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	calendar, err := h.service.GetTrainingICal(c.Request.Context(), userTrainingID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="training-%d.ics"`, userTrainingID))
+	c.Data(http.StatusOK, utils.ICalContentType, calendar)
+}
@@ -2,25 +2,48 @@ package handlers
 
 import (
 	"BACKEND/internal/converters"
+	"BACKEND/internal/delivery/chat"
 	"BACKEND/internal/delivery/middleware"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
 	"BACKEND/internal/services"
+	"BACKEND/internal/validators"
 	"BACKEND/pkg/responses"
+	"BACKEND/pkg/utils"
+	"errors"
 	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
 	"net/http"
 	"strconv"
 )
 
+// Ограничения на размер вложений чата по виду
+var maxAttachmentSize = map[string]int64{
+	domain.AttachmentImage: 10 << 20,
+	domain.AttachmentVoice: 10 << 20,
+	domain.AttachmentFile:  20 << 20,
+}
+
+const maxAttachmentRequestSize = 20<<20 + 1<<20
+
 type ChatHandler struct {
-	service   services.Chat
-	converter converters.ChatConverter
+	service    services.Chat
+	chatServer *chat.Server
+	converter  converters.ChatConverter
+	validate   *validator.Validate
 }
 
 func InitChatHandler(
 	service services.Chat,
+	chatServer *chat.Server,
+	validate *validator.Validate,
 ) *ChatHandler {
 	return &ChatHandler{
-		service:   service,
-		converter: converters.InitChatConverter(),
+		service:    service,
+		chatServer: chatServer,
+		converter:  converters.InitChatConverter(),
+		validate:   validate,
 	}
 }
 
@@ -33,9 +56,10 @@ func InitChatHandler(
 // @Param access_token header string true "Access token"
 // @Param search query string false "Search term"
 // @Success 200 {object} []dto.Chat "List of chats"
-// @Failure 400 {object} responses.MessageResponse "Bad JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/chat/user [get]
 func (h *ChatHandler) GetUserChats(c *gin.Context) {
 	search := c.Query("search")
@@ -43,7 +67,7 @@ func (h *ChatHandler) GetUserChats(c *gin.Context) {
 
 	chats, err := h.service.GetUserChats(c.Request.Context(), userID, search)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -59,9 +83,10 @@ func (h *ChatHandler) GetUserChats(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param search query string false "Search term"
 // @Success 200 {array} dto.Chat "List of chats"
-// @Failure 400 {object} responses.MessageResponse "Bad JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/chat/trainer [get]
 func (h *ChatHandler) GetTrainerChats(c *gin.Context) {
 	search := c.Query("search")
@@ -69,7 +94,7 @@ func (h *ChatHandler) GetTrainerChats(c *gin.Context) {
 
 	chats, err := h.service.GetTrainerChats(c.Request.Context(), trainerID, search)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -86,14 +111,15 @@ func (h *ChatHandler) GetTrainerChats(c *gin.Context) {
 // @Param trainer_id path int true "Trainer ID"
 // @Param cursor query int false "Cursor for pagination"
 // @Success 200 {object} dto.MessagePagination "List of messages with pagination"
-// @Failure 400 {object} responses.MessageResponse "Bad path or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad path or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/chat/user/{trainer_id} [get]
 func (h *ChatHandler) GetChatMessageUser(c *gin.Context) {
 	trainerID, err := strconv.Atoi(c.Param("trainer_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadPath})
+		c.Error(errs.ErrBadPath)
 		return
 	}
 
@@ -103,7 +129,7 @@ func (h *ChatHandler) GetChatMessageUser(c *gin.Context) {
 	}
 	cursor, err := strconv.Atoi(cursorStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -111,7 +137,7 @@ func (h *ChatHandler) GetChatMessageUser(c *gin.Context) {
 
 	messages, err := h.service.GetChatMessage(c.Request.Context(), userID, trainerID, cursor)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -128,14 +154,15 @@ func (h *ChatHandler) GetChatMessageUser(c *gin.Context) {
 // @Param user_id path int true "User ID"
 // @Param cursor query int false "Cursor for pagination"
 // @Success 200 {object} dto.MessagePagination "List of messages with pagination"
-// @Failure 400 {object} responses.MessageResponse "Bad path or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad path or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/chat/trainer/{user_id} [get]
 func (h *ChatHandler) GetChatMessageTrainer(c *gin.Context) {
 	userID, err := strconv.Atoi(c.Param("user_id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadPath})
+		c.Error(errs.ErrBadPath)
 		return
 	}
 
@@ -145,7 +172,7 @@ func (h *ChatHandler) GetChatMessageTrainer(c *gin.Context) {
 	}
 	cursor, err := strconv.Atoi(cursorStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -153,9 +180,431 @@ func (h *ChatHandler) GetChatMessageTrainer(c *gin.Context) {
 
 	messages, err := h.service.GetChatMessage(c.Request.Context(), userID, trainerID, cursor)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, messages)
+}
+
+// SearchMessages
+// @Summary Search Chat Messages
+// @Description Full-text search over messages of all own chats or of the chat with `to`, newest first. Russian and English word forms are matched, `"phrase"`, `or` and `-word` are supported. Matches in `highlight` are wrapped in `<mark></mark>`, the rest of the text is HTML-escaped. Pass result `cursor` to the chat messages endpoint to open the chat at the message
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param query query string true "Search query, up to 256 characters"
+// @Param to query int false "Counterpart ID: trainer for a user, user for a trainer"
+// @Param cursor query int false "Cursor for pagination"
+// @Success 200 {object} dto.MessageFoundPagination "Found messages with pagination"
+// @Failure 400 {object} responses.ErrorResponse "Bad query or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/search [get]
+func (h *ChatHandler) SearchMessages(c *gin.Context) {
+	var query dto.MessageSearchQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		c.Error(validators.ValidationError(err, &dto.MessageSearchQuery{}))
+		return
+	}
+
+	messages, err := h.service.SearchMessages(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType), query.Query, query.To, query.Cursor)
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.JSON(http.StatusOK, messages)
 }
+
+// GetTrainersPresence
+// @Summary Get Trainers Presence
+// @Description Get online status and last seen time of trainers for a user's chat list. Unknown IDs are skipped
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param ids query []int true "Trainer IDs, up to 100"
+// @Success 200 {array} dto.Presence "List of presences"
+// @Failure 400 {object} responses.ErrorResponse "Bad query or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/user/presence [get]
+func (h *ChatHandler) GetTrainersPresence(c *gin.Context) {
+	h.getPresence(c, utils.Trainer)
+}
+
+// GetUsersPresence
+// @Summary Get Users Presence
+// @Description Get online status and last seen time of users for a trainer's chat list. Unknown IDs are skipped
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param ids query []int true "User IDs, up to 100"
+// @Success 200 {array} dto.Presence "List of presences"
+// @Failure 400 {object} responses.ErrorResponse "Bad query or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/trainer/presence [get]
+func (h *ChatHandler) GetUsersPresence(c *gin.Context) {
+	h.getPresence(c, utils.User)
+}
+
+func (h *ChatHandler) getPresence(c *gin.Context, userType string) {
+	var query dto.PresenceQuery
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		c.Error(validators.ValidationError(err, &dto.PresenceQuery{}))
+		return
+	}
+
+	presences, err := h.service.GetPresence(c.Request.Context(), userType, query.IDs)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, presences)
+}
+
+// EditMessage
+// @Summary Edit Chat Message
+// @Description Replace text of own message, previous text is saved to edit history. The change is sent to both participants over WS as an `edit` event
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param message_id path int true "Message ID"
+// @Param message body dto.MessageUpdate true "New text"
+// @Success 200 {object} dto.Message "Edited message"
+// @Failure 400 {object} responses.ErrorResponse "Bad path, body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 404 {object} responses.ErrorResponse "No own message with such ID"
+// @Failure 409 {object} responses.ErrorResponse "Offer in the message is already accepted or declined"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/message/{message_id} [put]
+func (h *ChatHandler) EditMessage(c *gin.Context) {
+	messageID, err := strconv.Atoi(c.Param("message_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	var messageUpdate dto.MessageUpdate
+
+	if err = c.ShouldBindJSON(&messageUpdate); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err = h.validate.Struct(messageUpdate); err != nil {
+		c.Error(validators.ValidationError(err, &dto.MessageUpdate{}))
+		return
+	}
+
+	message, err := h.service.EditMessage(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType), messageID, messageUpdate.Message)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.chatServer.Notify(c.Request.Context(), chat.EditEvent, message)
+
+	c.JSON(http.StatusOK, message)
+}
+
+// DeleteMessage
+// @Summary Delete Chat Message
+// @Description Delete own message for everyone within MESSAGE_DELETE_TIME after sending. The change is sent to both participants over WS as a `delete` event
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param message_id path int true "Message ID"
+// @Success 200 {object} dto.Message "Deleted message"
+// @Failure 400 {object} responses.ErrorResponse "Bad path or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 404 {object} responses.ErrorResponse "No own message with such ID"
+// @Failure 409 {object} responses.ErrorResponse "Time to delete the message has expired or offer in it is already accepted or declined"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/message/{message_id} [delete]
+func (h *ChatHandler) DeleteMessage(c *gin.Context) {
+	messageID, err := strconv.Atoi(c.Param("message_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	message, err := h.service.DeleteMessage(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType), messageID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	h.chatServer.Notify(c.Request.Context(), chat.DeleteEvent, message)
+
+	c.JSON(http.StatusOK, message)
+}
+
+// GetMessageEdits
+// @Summary Get Chat Message Edits
+// @Description Get previous versions of a message from own chat, oldest first
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param message_id path int true "Message ID"
+// @Success 200 {array} dto.MessageEdit "Edit history"
+// @Failure 400 {object} responses.ErrorResponse "Bad path or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 404 {object} responses.ErrorResponse "No message with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/message/{message_id}/edits [get]
+func (h *ChatHandler) GetMessageEdits(c *gin.Context) {
+	messageID, err := strconv.Atoi(c.Param("message_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	edits, err := h.service.GetMessageEdits(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType), messageID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, edits)
+}
+
+// BlockTrainer
+// @Summary Block Trainer In Chat
+// @Description Add a trainer to own block list: neither side can send messages, offers can not be accepted, typing and presence are not shared
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param trainer_id path int true "Trainer ID"
+// @Success 200 "Trainer successfully blocked"
+// @Failure 400 {object} responses.ErrorResponse "Bad path or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 404 {object} responses.ErrorResponse "No trainer with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/user/{trainer_id}/block [post]
+func (h *ChatHandler) BlockTrainer(c *gin.Context) {
+	h.block(c, "trainer_id", true)
+}
+
+// UnblockTrainer
+// @Summary Unblock Trainer In Chat
+// @Description Remove a trainer from own block list
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param trainer_id path int true "Trainer ID"
+// @Success 200 "Trainer successfully unblocked"
+// @Failure 400 {object} responses.ErrorResponse "Bad path or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/user/{trainer_id}/block [delete]
+func (h *ChatHandler) UnblockTrainer(c *gin.Context) {
+	h.block(c, "trainer_id", false)
+}
+
+// BlockUser
+// @Summary Block User In Chat
+// @Description Add a user to own block list: neither side can send messages, offers can not be accepted, typing and presence are not shared
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param user_id path int true "User ID"
+// @Success 200 "User successfully blocked"
+// @Failure 400 {object} responses.ErrorResponse "Bad path or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 404 {object} responses.ErrorResponse "No user with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/trainer/{user_id}/block [post]
+func (h *ChatHandler) BlockUser(c *gin.Context) {
+	h.block(c, "user_id", true)
+}
+
+// UnblockUser
+// @Summary Unblock User In Chat
+// @Description Remove a user from own block list
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param user_id path int true "User ID"
+// @Success 200 "User successfully unblocked"
+// @Failure 400 {object} responses.ErrorResponse "Bad path or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/trainer/{user_id}/block [delete]
+func (h *ChatHandler) UnblockUser(c *gin.Context) {
+	h.block(c, "user_id", false)
+}
+
+func (h *ChatHandler) block(c *gin.Context, param string, block bool) {
+	counterpartID, err := strconv.Atoi(c.Param(param))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	userID := c.GetInt(middleware.UserID)
+	userType := c.GetString(middleware.UserType)
+
+	if block {
+		err = h.service.Block(c.Request.Context(), userID, userType, counterpartID)
+	} else {
+		err = h.service.Unblock(c.Request.Context(), userID, userType, counterpartID)
+	}
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// GetBlocked
+// @Summary Get Chat Block List
+// @Description Get accounts blocked by the user or trainer, latest first
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 200 {array} dto.BlockedAccount "Blocked accounts"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/blocks [get]
+func (h *ChatHandler) GetBlocked(c *gin.Context) {
+	blocked, err := h.service.GetBlocked(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, blocked)
+}
+
+// ReportMessage
+// @Summary Report Chat Message
+// @Description Report abuse in a message received from the chat counterpart. Reports are reviewed by content moderators
+// @Tags Chats
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param message_id path int true "Message ID"
+// @Param report body dto.MessageReportCreate true "Report reason"
+// @Success 201 {object} responses.CreatedIDResponse "Report successfully created"
+// @Failure 400 {object} responses.ErrorResponse "Bad path, body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 404 {object} responses.ErrorResponse "No received message with such ID"
+// @Failure 409 {object} responses.ErrorResponse "Message is already reported and waits for review"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/message/{message_id}/report [post]
+func (h *ChatHandler) ReportMessage(c *gin.Context) {
+	messageID, err := strconv.Atoi(c.Param("message_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	var report dto.MessageReportCreate
+
+	if err = c.ShouldBindJSON(&report); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err = h.validate.Struct(report); err != nil {
+		c.Error(validators.ValidationError(err, &dto.MessageReportCreate{}))
+		return
+	}
+
+	id, err := h.service.ReportMessage(c.Request.Context(), c.GetInt(middleware.UserID), c.GetString(middleware.UserType), messageID, report.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.CreatedIDResponse{ID: id})
+}
+
+// UploadAttachment
+// @Summary Upload Chat Attachment
+// @Description Upload a file to attach to a message: pass returned id in `attachment_ids` of a WS `message` event. Images get a thumbnail. Attachments that are not sent within a day are deleted
+// @Tags Chats
+// @Accept multipart/form-data
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param file formData file true "Image jpeg/jpg/png/gif or voice note ogg/oga/opus/mp3/m4a/webm under 10MB, file pdf/txt under 20MB"
+// @Success 201 {object} dto.Attachment "Uploaded attachment"
+// @Failure 400 {object} responses.ErrorResponse "Bad file or JWT provided, file is too large"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/chat/attachment [post]
+func (h *ChatHandler) UploadAttachment(c *gin.Context) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxAttachmentRequestSize)
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.Error(errs.ErrFileTooLarge)
+			return
+		}
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	// Проверка на допустимый тип `Content-Type` и расширение
+	kind, ok := validators.ValidateAttachmentTypeExtension(file)
+	if !ok {
+		c.Error(errs.ErrBadFile)
+		return
+	}
+
+	if file.Size > maxAttachmentSize[kind] {
+		c.Error(errs.ErrFileTooLarge)
+		return
+	}
+
+	attachment, err := h.service.UploadAttachment(c, file, kind, c.GetInt(middleware.UserID), c.GetString(middleware.UserType))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, attachment)
+}
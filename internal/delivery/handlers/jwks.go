@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"BACKEND/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// Время кеширования набора ключей клиентами, новый ключ должен быть опубликован дольше этого времени до начала подписи им
+const jwksMaxAge = "public, max-age=300"
+
+type JWKSHandler struct {
+	jwtUtil utils.JWT
+}
+
+func InitJWKSHandler(
+	jwtUtil utils.JWT,
+) *JWKSHandler {
+	return &JWKSHandler{
+		jwtUtil: jwtUtil,
+	}
+}
+
+// GetJWKS
+// @Summary Get JWKS
+// @Description Public keys for access token verification (RFC 7517). Tokens carry the key id in the `kid` header
+// @Tags Authorization
+// @Produce json
+// @Success 200 {object} utils.JWKS "Return public keys"
+// @Router /.well-known/jwks.json [get]
+func (j JWKSHandler) GetJWKS(c *gin.Context) {
+	c.Header("Cache-Control", jwksMaxAge)
+	c.JSON(http.StatusOK, j.jwtUtil.JWKS())
+}
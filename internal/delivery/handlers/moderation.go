@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"BACKEND/internal/converters"
+	"BACKEND/internal/delivery/middleware"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/services"
+	"BACKEND/internal/validators"
+	"BACKEND/pkg/utils"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"strconv"
+)
+
+type ModerationHandler struct {
+	service         services.Moderation
+	filterConverter converters.FilterConverter
+	validate        *validator.Validate
+}
+
+func InitModerationHandler(
+	service services.Moderation,
+	validate *validator.Validate,
+) *ModerationHandler {
+	return &ModerationHandler{
+		service:         service,
+		filterConverter: converters.InitFilterConverter(),
+		validate:        validate,
+	}
+}
+
+// GetReports
+// @Summary Get Message Reports
+// @Description Get reports on chat messages with the reported message, oldest first. Text of messages deleted after the report is shown
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param status query string false "Filter by status: pending, resolved or dismissed"
+// @Param cursor query int false "Cursor for pagination"
+// @Success 200 {object} dto.MessageReportPagination "List of reports with pagination"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/admin/message-report [get]
+func (m ModerationHandler) GetReports(c *gin.Context) {
+	var filters dto.FiltersMessageReports
+
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	if err := m.validate.Struct(filters); err != nil {
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	reports, err := m.service.GetReports(c.Request.Context(), m.filterConverter.FiltersMessageReportsDTOToDomain(filters))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, reports)
+}
+
+// ReviewReport
+// @Summary Review Message Report
+// @Description Close pending report as resolved or dismissed
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param report_id path int true "Report ID"
+// @Param review body dto.ReportReview true "Review result"
+// @Success 200 "Report reviewed successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid report ID, body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 404 {object} responses.ErrorResponse "No report with such ID"
+// @Failure 409 {object} responses.ErrorResponse "Report is already reviewed"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/admin/message-report/{report_id}/review [post]
+func (m ModerationHandler) ReviewReport(c *gin.Context) {
+	reportID, err := strconv.Atoi(c.Param("report_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	var review dto.ReportReview
+	if err = c.ShouldBindJSON(&review); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err = m.validate.Struct(review); err != nil {
+		c.Error(validators.ValidationError(err, &dto.ReportReview{}))
+		return
+	}
+
+	err = m.service.ReviewReport(c.Request.Context(), c.GetInt(middleware.UserID), reportID, review.Status)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// SuspendUser
+// @Summary Suspend User
+// @Description Suspend user until the given time or forever if it is not set. Suspended user can not log in, use the API or send messages
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param user_id path int true "User ID"
+// @Param suspension body dto.Suspension true "Suspension end and reason"
+// @Success 200 "User suspended successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid user ID, body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 404 {object} responses.ErrorResponse "No user with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/admin/suspension/user/{user_id} [post]
+func (m ModerationHandler) SuspendUser(c *gin.Context) {
+	m.suspend(c, utils.User, "user_id")
+}
+
+// UnsuspendUser
+// @Summary Unsuspend User
+// @Description Lift user suspension
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param user_id path int true "User ID"
+// @Success 200 "User unsuspended successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid user ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 404 {object} responses.ErrorResponse "No user with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/admin/suspension/user/{user_id} [delete]
+func (m ModerationHandler) UnsuspendUser(c *gin.Context) {
+	m.unsuspend(c, utils.User, "user_id")
+}
+
+// SuspendTrainer
+// @Summary Suspend Trainer
+// @Description Suspend trainer until the given time or forever if it is not set. Suspended trainer can not log in, use the API or send messages
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param trainer_id path int true "Trainer ID"
+// @Param suspension body dto.Suspension true "Suspension end and reason"
+// @Success 200 "Trainer suspended successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid trainer ID, body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 404 {object} responses.ErrorResponse "No trainer with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/admin/suspension/trainer/{trainer_id} [post]
+func (m ModerationHandler) SuspendTrainer(c *gin.Context) {
+	m.suspend(c, utils.Trainer, "trainer_id")
+}
+
+// UnsuspendTrainer
+// @Summary Unsuspend Trainer
+// @Description Lift trainer suspension
+// @Tags Moderation
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param trainer_id path int true "Trainer ID"
+// @Success 200 "Trainer unsuspended successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid trainer ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 404 {object} responses.ErrorResponse "No trainer with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/admin/suspension/trainer/{trainer_id} [delete]
+func (m ModerationHandler) UnsuspendTrainer(c *gin.Context) {
+	m.unsuspend(c, utils.Trainer, "trainer_id")
+}
+
+func (m ModerationHandler) suspend(c *gin.Context, accountType, param string) {
+	accountID, err := strconv.Atoi(c.Param(param))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	var suspension dto.Suspension
+	if err = c.ShouldBindJSON(&suspension); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err = m.validate.Struct(suspension); err != nil {
+		c.Error(validators.ValidationError(err, &dto.Suspension{}))
+		return
+	}
+
+	err = m.service.Suspend(c.Request.Context(), c.GetInt(middleware.UserID), accountType, accountID, suspension)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (m ModerationHandler) unsuspend(c *gin.Context, accountType, param string) {
+	accountID, err := strconv.Atoi(c.Param(param))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	err = m.service.Unsuspend(c.Request.Context(), c.GetInt(middleware.UserID), accountType, accountID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -7,7 +7,6 @@ import (
 	"BACKEND/internal/services"
 	"BACKEND/internal/validators"
 	"BACKEND/pkg/responses"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"net/http"
@@ -39,21 +38,22 @@ func InitRoleHandler(
 // @Param access_token header string true "Access token"
 // @Param role body dto.BaseBase true "Role data to create"
 // @Success 201 {object} responses.CreatedIDResponse "Role successfully created"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 409 {object} responses.ErrorResponse "Role with such name already exists"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/role [post]
 func (r RoleHandler) CreateRole(c *gin.Context) {
 	var role dto.BaseBase
 
 	if err := c.ShouldBindJSON(&role); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	if err := r.validate.Struct(role); err != nil {
-		customErr := validators.CustomErrorMessage(err, &dto.BaseBase{})
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: customErr})
+		c.Error(validators.ValidationError(err, &dto.BaseBase{}))
 		return
 	}
 
@@ -61,12 +61,7 @@ func (r RoleHandler) CreateRole(c *gin.Context) {
 
 	roleID, err := r.service.Create(ctx, r.converter.BaseBaseDTOToDomain(role))
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrAlreadyExist):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -80,16 +75,16 @@ func (r RoleHandler) CreateRole(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} []dto.Base "Return roles"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/role [get]
 func (r RoleHandler) GetRoles(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	roles, err := r.service.GetByName(ctx)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -105,15 +100,16 @@ func (r RoleHandler) GetRoles(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param role_ids body []int true "Role IDs to delete"
 // @Success 200 "Roles successfully deleted"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/role [delete]
 func (r RoleHandler) DeleteRoles(c *gin.Context) {
 	var roleIDs []int
 
 	if err := c.ShouldBindJSON(&roleIDs); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -121,11 +117,7 @@ func (r RoleHandler) DeleteRoles(c *gin.Context) {
 
 	err := r.service.Delete(ctx, roleIDs)
 	if err != nil {
-		if err.Error() == "Один из переданных объектов не существует" {
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		} else {
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -4,8 +4,6 @@ import (
 	"BACKEND/internal/converters"
 	"BACKEND/internal/errs"
 	"BACKEND/internal/services"
-	"BACKEND/pkg/responses"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
@@ -33,24 +31,20 @@ func InitServiceHandler(
 // @Produce json
 // @Param id path int true "Service ID"
 // @Success 200 {object} dto.Service "Service details"
-// @Failure 400 {object} responses.MessageResponse "Invalid service ID"
-// @Failure 404 {object} responses.MessageResponse "Service not found"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid service ID"
+// @Failure 404 {object} responses.ErrorResponse "Service not found"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/service/{id} [get]
 func (s *ServiceHandler) GetServiceByID(c *gin.Context) {
 	serviceID, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadPath})
+		c.Error(errs.ErrBadPath)
 		return
 	}
 
 	service, err := s.service.GetServiceByID(c.Request.Context(), serviceID)
 	if err != nil {
-		if errors.Is(err, errs.ErrNoService) {
-			c.JSON(http.StatusNotFound, err.Error())
-			return
-		}
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -7,7 +7,6 @@ import (
 	"BACKEND/internal/services"
 	"BACKEND/internal/validators"
 	"BACKEND/pkg/responses"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"net/http"
@@ -39,21 +38,22 @@ func InitSpecializationHandler(
 // @Param access_token header string true "Access token"
 // @Param specialization body dto.BaseBase true "Specializations data to create"
 // @Success 201 {object} responses.CreatedIDResponse "Specializations successfully created"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 409 {object} responses.ErrorResponse "Specialization with such name already exists"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/specialization [post]
 func (s SpecializationHandler) CreateSpecialization(c *gin.Context) {
 	var specializations dto.BaseBase
 
 	if err := c.ShouldBindJSON(&specializations); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	if err := s.validate.Struct(specializations); err != nil {
-		customErr := validators.CustomErrorMessage(err, &dto.BaseBase{})
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: customErr})
+		c.Error(validators.ValidationError(err, &dto.BaseBase{}))
 		return
 	}
 
@@ -61,12 +61,7 @@ func (s SpecializationHandler) CreateSpecialization(c *gin.Context) {
 
 	specializationsID, err := s.service.Create(ctx, s.converter.BaseBaseDTOToDomain(specializations))
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrAlreadyExist):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -80,16 +75,16 @@ func (s SpecializationHandler) CreateSpecialization(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Success 200 {object} []dto.Base "Return specializations"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/specialization [get]
 func (s SpecializationHandler) GetSpecializations(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	specializations, err := s.service.GetByName(ctx)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -105,15 +100,16 @@ func (s SpecializationHandler) GetSpecializations(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param specialization_ids body []int true "Specialization IDs to delete"
 // @Success 200 "Specializations successfully deleted"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/specialization [delete]
 func (s SpecializationHandler) DeleteSpecializations(c *gin.Context) {
 	var specializationIDs []int
 
 	if err := c.ShouldBindJSON(&specializationIDs); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -121,11 +117,7 @@ func (s SpecializationHandler) DeleteSpecializations(c *gin.Context) {
 
 	err := s.service.Delete(ctx, specializationIDs)
 	if err != nil {
-		if err.Error() == "Один из переданных объектов не существует" {
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		} else {
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
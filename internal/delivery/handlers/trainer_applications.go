@@ -0,0 +1,244 @@
+package handlers
+
+import (
+	"BACKEND/internal/converters"
+	"BACKEND/internal/delivery/middleware"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/services"
+	"BACKEND/internal/validators"
+	"BACKEND/pkg/responses"
+	"encoding/json"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+	"strconv"
+)
+
+const (
+	maxCertificates    = 5
+	maxCertificateSize = 5 << 20
+)
+
+type TrainerApplicationHandler struct {
+	service         services.TrainerApplications
+	filterConverter converters.FilterConverter
+	validate        *validator.Validate
+}
+
+func InitTrainerApplicationHandler(
+	service services.TrainerApplications,
+	validate *validator.Validate,
+) *TrainerApplicationHandler {
+	return &TrainerApplicationHandler{
+		service:         service,
+		filterConverter: converters.InitFilterConverter(),
+		validate:        validate,
+	}
+}
+
+// Submit
+// @Summary Submit Trainer Application
+// @Description Apply to become a trainer. The application is reviewed by an admin, on approval the applicant receives an email with a link to set the password
+// @Tags Trainer Applications
+// @Accept multipart/form-data
+// @Produce json
+// @Param application formData string true "JSON object dto.TrainerApplicationCreate"
+// @Param certificates formData file false "Up to 5 certificates with type pdf/jpeg/jpg/png under 5MB each"
+// @Success 201 {object} responses.CreatedIDResponse "Return created application's id"
+// @Failure 400 {object} responses.ErrorResponse "Bad application or certificates provided"
+// @Failure 409 {object} responses.ErrorResponse "Email already belongs to a trainer or has a pending application"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/trainer/application [post]
+func (t TrainerApplicationHandler) Submit(c *gin.Context) {
+	// Ограничение на весь запрос: все сертификаты и поле заявки
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxCertificates*maxCertificateSize+1<<20)
+
+	var application dto.TrainerApplicationCreate
+	if err := json.Unmarshal([]byte(c.PostForm("application")), &application); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err := t.validate.Struct(application); err != nil {
+		c.Error(validators.ValidationError(err, &dto.TrainerApplicationCreate{}))
+		return
+	}
+
+	form, err := c.MultipartForm()
+	if err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	certificates := form.File["certificates"]
+	if len(certificates) > maxCertificates {
+		c.Error(errs.ErrTooManyFiles)
+		return
+	}
+
+	for _, certificate := range certificates {
+		if certificate.Size > maxCertificateSize {
+			c.Error(errs.ErrFileTooLarge)
+			return
+		}
+
+		// Проверка на допустимый тип `Content-Type` и расширение
+		if !validators.ValidateDocumentTypeExtension(certificate) {
+			c.Error(errs.ErrBadFile)
+			return
+		}
+	}
+
+	id, err := t.service.Submit(c, application, certificates)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.CreatedIDResponse{ID: id})
+}
+
+// GetCovers
+// @Summary Get Trainer Applications
+// @Description Get trainer applications with pagination, optionally filtered by status
+// @Tags Trainer Applications
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param status query string false "Application status" Enums(pending, approved, rejected)
+// @Param cursor query int false "Cursor for pagination"
+// @Success 200 {object} dto.TrainerApplicationCoverPagination "List of applications with pagination"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query parameters or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not responsible for trainer onboarding"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/admin/trainer-application [get]
+func (t TrainerApplicationHandler) GetCovers(c *gin.Context) {
+	var filters dto.FiltersTrainerApplications
+
+	if err := c.ShouldBindQuery(&filters); err != nil {
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	if err := t.validate.Struct(filters); err != nil {
+		c.Error(errs.ErrBadQuery)
+		return
+	}
+
+	applications, err := t.service.GetCovers(c.Request.Context(), t.filterConverter.FiltersTrainerApplicationsDTOToDomain(filters))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, applications)
+}
+
+// GetByID
+// @Summary Get Trainer Application
+// @Description Get trainer application with roles, specializations and certificates
+// @Tags Trainer Applications
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param application_id path int true "Application ID"
+// @Success 200 {object} dto.TrainerApplication "Return application"
+// @Failure 400 {object} responses.ErrorResponse "Invalid application ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not responsible for trainer onboarding"
+// @Failure 404 {object} responses.ErrorResponse "No application with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/admin/trainer-application/{application_id} [get]
+func (t TrainerApplicationHandler) GetByID(c *gin.Context) {
+	applicationID, err := strconv.Atoi(c.Param("application_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	application, err := t.service.GetByID(c.Request.Context(), applicationID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, application)
+}
+
+// Approve
+// @Summary Approve Trainer Application
+// @Description Approve pending application: creates the trainer with roles and specializations and emails a link to set the password
+// @Tags Trainer Applications
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param application_id path int true "Application ID"
+// @Success 201 {object} responses.CreatedIDResponse "Return created trainer's id"
+// @Failure 400 {object} responses.ErrorResponse "Invalid application ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not responsible for trainer onboarding"
+// @Failure 404 {object} responses.ErrorResponse "No application with such ID"
+// @Failure 409 {object} responses.ErrorResponse "Application is already reviewed or trainer already exists"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/admin/trainer-application/{application_id}/approve [post]
+func (t TrainerApplicationHandler) Approve(c *gin.Context) {
+	applicationID, err := strconv.Atoi(c.Param("application_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	trainerID, err := t.service.Approve(c.Request.Context(), c.GetInt(middleware.UserID), applicationID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, responses.CreatedIDResponse{ID: trainerID})
+}
+
+// Reject
+// @Summary Reject Trainer Application
+// @Description Reject pending application, the reason is sent to the applicant
+// @Tags Trainer Applications
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param application_id path int true "Application ID"
+// @Param reason body dto.ApplicationReject true "Rejection reason"
+// @Success 200 "Application rejected successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid application ID, body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not responsible for trainer onboarding"
+// @Failure 404 {object} responses.ErrorResponse "No application with such ID"
+// @Failure 409 {object} responses.ErrorResponse "Application is already reviewed"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/admin/trainer-application/{application_id}/reject [post]
+func (t TrainerApplicationHandler) Reject(c *gin.Context) {
+	applicationID, err := strconv.Atoi(c.Param("application_id"))
+	if err != nil {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	var reject dto.ApplicationReject
+	if err := c.ShouldBindJSON(&reject); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if err := t.validate.Struct(reject); err != nil {
+		c.Error(validators.ValidationError(err, &dto.ApplicationReject{}))
+		return
+	}
+
+	err = t.service.Reject(c.Request.Context(), c.GetInt(middleware.UserID), applicationID, reject.Reason)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
@@ -8,7 +8,6 @@ import (
 	"BACKEND/internal/services"
 	"BACKEND/internal/validators"
 	"BACKEND/pkg/responses"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"net/http"
@@ -42,9 +41,9 @@ func InitTrainerHandler(
 // @Produce json
 // @Param access_token header string true "Access token"
 // @Success 200 {object} dto.Trainer "Return trainer"
-// @Failure 400 {object} responses.MessageResponse "Bad body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal Server Error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/me [get]
 func (t TrainerHandler) Me(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -53,12 +52,7 @@ func (t TrainerHandler) Me(c *gin.Context) {
 
 	trainer, err := t.service.GetByID(ctx, trainerID)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoUser):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -73,15 +67,16 @@ func (t TrainerHandler) Me(c *gin.Context) {
 // @Produce json
 // @Param trainer_id path int true "Trainer ID"
 // @Success 200 {object} dto.Trainer "Return trainer"
-// @Failure 400 {object} responses.MessageResponse "Bad body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal Server Error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 404 {object} responses.ErrorResponse "No trainer with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/{trainer_id} [get]
 func (t TrainerHandler) GetProfile(c *gin.Context) {
 	idStr := c.Param("trainer_id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -89,12 +84,7 @@ func (t TrainerHandler) GetProfile(c *gin.Context) {
 
 	trainer, err := t.service.GetByID(ctx, id)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoTrainer):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -112,20 +102,20 @@ func (t TrainerHandler) GetProfile(c *gin.Context) {
 // @Param role_ids query []int false "Role IDs"
 // @Param specialization_ids query []int false "Specialization IDs"
 // @Success 200 {object} dto.TrainerCoverPagination "List of trainer covers with pagination"
-// @Failure 400 {object} responses.MessageResponse "Invalid query parameters"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer [get]
 func (t TrainerHandler) GetCovers(c *gin.Context) {
 	var filters dto.FiltersTrainerCovers
 
 	if err := c.ShouldBindQuery(&filters); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
 	trainerCovers, err := t.service.GetCovers(c.Request.Context(), t.filterConverter.FilterTrainerDTOToDomain(filters))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -141,21 +131,20 @@ func (t TrainerHandler) GetCovers(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param user body dto.TrainerUpdate true "Trainer data to set"
 // @Success 200 "Trainer successfully updated"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/main [put]
 func (t TrainerHandler) UpdateMain(c *gin.Context) {
 	var trainerUpdate dto.TrainerUpdate
 
 	if err := c.ShouldBindJSON(&trainerUpdate); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	if err := t.validate.Struct(trainerUpdate); err != nil {
-		customErr := validators.CustomErrorMessage(err, &dto.TrainerUpdate{})
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: customErr})
+		c.Error(validators.ValidationError(err, &dto.TrainerUpdate{}))
 		return
 	}
 
@@ -165,12 +154,7 @@ func (t TrainerHandler) UpdateMain(c *gin.Context) {
 
 	err := t.service.UpdateMain(ctx, t.converter.TrainerUpdateDTOToDomain(trainerUpdate, trainerID))
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoTrainer):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -186,9 +170,9 @@ func (t TrainerHandler) UpdateMain(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param photo formData file false "New user photo with type jpeg/jpg/png/svg under 2MB"
 // @Success 200 "Trainer successfully updated"
-// @Failure 400 {object} responses.MessageResponse "Invalid photo or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid photo or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/photo [put]
 func (t TrainerHandler) UpdatePhoto(c *gin.Context) {
 	file, _ := c.FormFile("photo")
@@ -197,13 +181,13 @@ func (t TrainerHandler) UpdatePhoto(c *gin.Context) {
 		// Проверка на ограничение по размеру файла на 2МБ
 		err := c.Request.ParseMultipartForm(2 << 20)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: "Photo size is bigger than 2MB"})
+			c.Error(errs.ErrFileTooLarge)
 			return
 		}
 
 		// Проверка на допустимый тип `Content-Type` и расширение
 		if !validators.ValidateFileTypeExtension(file) {
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: "Photo bad type or extension"})
+			c.Error(errs.ErrBadFile)
 			return
 		}
 	}
@@ -212,12 +196,7 @@ func (t TrainerHandler) UpdatePhoto(c *gin.Context) {
 
 	err := t.service.UpdatePhotoUrl(c, file, trainerID)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoTrainer):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -233,15 +212,15 @@ func (t TrainerHandler) UpdatePhoto(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param roles body []int true "Role IDs to set"
 // @Success 200 "Roles successfully updated"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/roles [put]
 func (t TrainerHandler) UpdateRoles(c *gin.Context) {
 	var roleIDs []int
 
 	if err := c.ShouldBindJSON(&roleIDs); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -251,12 +230,7 @@ func (t TrainerHandler) UpdateRoles(c *gin.Context) {
 
 	err := t.service.UpdateRoles(ctx, trainerID, roleIDs)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoRole), errors.Is(err, errs.ErrAlreadyExist):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -272,15 +246,15 @@ func (t TrainerHandler) UpdateRoles(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param specializations body []int true "Specialization IDs to set"
 // @Success 200 "Specializations successfully updated"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/specializations [put]
 func (t TrainerHandler) UpdateSpecializations(c *gin.Context) {
 	var specializationIDs []int
 
 	if err := c.ShouldBindJSON(&specializationIDs); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -290,12 +264,7 @@ func (t TrainerHandler) UpdateSpecializations(c *gin.Context) {
 
 	err := t.service.UpdateSpecializations(ctx, trainerID, specializationIDs)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoSpecialization), errors.Is(err, errs.ErrAlreadyExist):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -311,15 +280,16 @@ func (t TrainerHandler) UpdateSpecializations(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param service body dto.ServiceCreate true "Service data to create"
 // @Success 201 {object} responses.CreatedIDResponse "Service successfully created"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/service [post]
 func (t TrainerHandler) CreateService(c *gin.Context) {
 	var service dto.ServiceCreate
 
 	if err := c.ShouldBindJSON(&service); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -329,12 +299,7 @@ func (t TrainerHandler) CreateService(c *gin.Context) {
 
 	serviceID, err := t.service.CreateService(ctx, t.converter.ServiceCreateDTOToDomain(service, trainerID))
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrAlreadyExist):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -350,28 +315,26 @@ func (t TrainerHandler) CreateService(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param service body dto.ServiceUpdate true "Service data to update"
 // @Success 200 "Service successfully updated"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 404 {object} responses.ErrorResponse "No service of the trainer with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/service [put]
 func (t TrainerHandler) UpdateService(c *gin.Context) {
 	var service dto.ServiceUpdate
 
 	if err := c.ShouldBindJSON(&service); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	ctx := c.Request.Context()
+	trainerID := c.GetInt(middleware.UserID)
 
-	err := t.service.UpdateService(ctx, t.converter.ServiceUpdateDTOToDomain(service))
+	err := t.service.UpdateService(ctx, trainerID, t.converter.ServiceUpdateDTOToDomain(service))
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoService):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -387,15 +350,15 @@ func (t TrainerHandler) UpdateService(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param service_id path int true "Service ID"
 // @Success 200 "Service successfully deleted"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/service/{service_id} [delete]
 func (t TrainerHandler) DeleteService(c *gin.Context) {
 	serviceIDStr := c.Param("service_id")
 	serviceID, err := strconv.Atoi(serviceIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -404,12 +367,7 @@ func (t TrainerHandler) DeleteService(c *gin.Context) {
 
 	err = t.service.DeleteService(ctx, trainerID, serviceID)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoService):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -425,15 +383,16 @@ func (t TrainerHandler) DeleteService(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param achievement body dto.AchievementCreate true "Achievement data to create"
 // @Success 201 {object} responses.CreatedIDResponse "Achievement successfully created"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/achievement [post]
 func (t TrainerHandler) CreateAchievement(c *gin.Context) {
 	var achievement dto.AchievementCreate
 
 	if err := c.ShouldBindJSON(&achievement); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -443,12 +402,7 @@ func (t TrainerHandler) CreateAchievement(c *gin.Context) {
 
 	achievementID, err := t.service.CreateAchievement(ctx, trainerID, achievement.Name)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrAlreadyExist):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -465,34 +419,32 @@ func (t TrainerHandler) CreateAchievement(c *gin.Context) {
 // @Param achievement_id path int true "Achievement ID"
 // @Param status body dto.AchievementStatusUpdate true "Achievement status to update"
 // @Success 200 "Achievement status successfully updated"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/achievement/{achievement_id}/status [put]
 func (t TrainerHandler) UpdateAchievementStatus(c *gin.Context) {
 	achievementIDStr := c.Param("achievement_id")
 	achievementID, err := strconv.Atoi(achievementIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
 	var statusUpdate dto.AchievementStatusUpdate
 	if err := c.ShouldBindJSON(&statusUpdate); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	ctx := c.Request.Context()
 
-	err = t.service.UpdateAchievementStatus(ctx, achievementID, statusUpdate.Status)
+	adminID := c.GetInt(middleware.UserID)
+
+	err = t.service.UpdateAchievementStatus(ctx, adminID, achievementID, statusUpdate.Status)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoAchievement):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -508,15 +460,15 @@ func (t TrainerHandler) UpdateAchievementStatus(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param achievement_id path int true "Achievement ID"
 // @Success 200 "Achievement successfully deleted"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/trainer/achievement/{achievement_id} [delete]
 func (t TrainerHandler) DeleteAchievement(c *gin.Context) {
 	achievementIDStr := c.Param("achievement_id")
 	achievementID, err := strconv.Atoi(achievementIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -526,12 +478,7 @@ func (t TrainerHandler) DeleteAchievement(c *gin.Context) {
 
 	err = t.service.DeleteAchievement(ctx, trainerID, achievementID)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoAchievement):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
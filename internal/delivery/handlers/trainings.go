@@ -7,23 +7,26 @@ import (
 	"BACKEND/internal/models/dto"
 	"BACKEND/internal/services"
 	"BACKEND/pkg/responses"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
+	"time"
 )
 
 type TrainingHandler struct {
 	service         services.Trainings
+	policy          services.Policies
 	converter       converters.TrainingConverter
 	filterConverter converters.FilterConverter
 }
 
 func InitTrainingsHandler(
 	service services.Trainings,
+	policy services.Policies,
 ) *TrainingHandler {
 	return &TrainingHandler{
 		service:         service,
+		policy:          policy,
 		converter:       converters.InitTrainingConverter(),
 		filterConverter: converters.InitFilterConverter(),
 	}
@@ -38,15 +41,16 @@ func InitTrainingsHandler(
 // @Param access_token header string true "Access token"
 // @Param exercises body []dto.ExerciseCreateBase true "Exercises data to create"
 // @Success 201 {object} responses.CreatedIDsResponse "Exercises successfully created"
-// @Failure 400 {object} responses.MessageResponse "Bad body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/exercise [post]
 func (t TrainingHandler) CreateExercises(c *gin.Context) {
 	var exercises []dto.ExerciseCreateBase
 
 	if err := c.ShouldBindJSON(&exercises); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -54,7 +58,7 @@ func (t TrainingHandler) CreateExercises(c *gin.Context) {
 
 	ids, err := t.service.CreateExercises(ctx, t.converter.ExercisesCreateBaseDTOToDomain(exercises))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -70,8 +74,8 @@ func (t TrainingHandler) CreateExercises(c *gin.Context) {
 // @Param cursor query int false "Cursor for pagination"
 // @Param search query string false "Search term"
 // @Success 200 {object} dto.ExercisePagination "Return exercises with pagination"
-// @Failure 400 {object} responses.MessageResponse "Invalid query parameters"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/exercise [get]
 func (t TrainingHandler) GetExercises(c *gin.Context) {
 	cursorStr := c.Query("cursor")
@@ -80,7 +84,7 @@ func (t TrainingHandler) GetExercises(c *gin.Context) {
 	}
 	cursor, err := strconv.Atoi(cursorStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -90,7 +94,7 @@ func (t TrainingHandler) GetExercises(c *gin.Context) {
 
 	pagination, err := t.service.GetExercises(ctx, search, cursor)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -106,15 +110,16 @@ func (t TrainingHandler) GetExercises(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param training body dto.TrainingCreateBase true "Training data to create"
 // @Success 201 {object} responses.CreatedIDsResponse "Training base successfully created"
-// @Failure 400 {object} responses.MessageResponse "Bad body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Admin is not a content moderator"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/base [post]
 func (t TrainingHandler) CreateTrainingBase(c *gin.Context) {
 	var training []dto.TrainingCreateBase
 
 	if err := c.ShouldBindJSON(&training); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -122,7 +127,7 @@ func (t TrainingHandler) CreateTrainingBase(c *gin.Context) {
 
 	ids, err := t.service.CreateTrainingBases(ctx, t.converter.TrainingCreateBasesDTOToDomain(training))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -138,15 +143,15 @@ func (t TrainingHandler) CreateTrainingBase(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param training body dto.TrainingCreate true "Training data to create"
 // @Success 201 {object} responses.CreatedIDResponse "Training successfully created"
-// @Failure 400 {object} responses.MessageResponse "Bad body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training [post]
 func (t TrainingHandler) CreateTraining(c *gin.Context) {
 	var training dto.TrainingCreate
 
 	if err := c.ShouldBindJSON(&training); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -156,7 +161,7 @@ func (t TrainingHandler) CreateTraining(c *gin.Context) {
 
 	id, err := t.service.CreateTraining(ctx, t.converter.TrainingCreateDTOToDomain(training, userID))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -172,15 +177,15 @@ func (t TrainingHandler) CreateTraining(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param training body dto.TrainingCreateTrainer true "Training data to create"
 // @Success 201 {object} responses.CreatedIDResponse "Training successfully created"
-// @Failure 400 {object} responses.MessageResponse "Bad body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/trainer [post]
 func (t TrainingHandler) CreateTrainingTrainer(c *gin.Context) {
 	var training dto.TrainingCreateTrainer
 
 	if err := c.ShouldBindJSON(&training); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -190,7 +195,7 @@ func (t TrainingHandler) CreateTrainingTrainer(c *gin.Context) {
 
 	id, err := t.service.CreateTrainingTrainer(ctx, t.converter.TrainingCreateTrainerDTOToDomain(training, trainerID))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -208,28 +213,30 @@ func (t TrainingHandler) CreateTrainingTrainer(c *gin.Context) {
 // @Param exercise_id path int true "Exercise ID"
 // @Param status body dto.ExerciseStatusUpdate true "Exercise status to update"
 // @Success 200 "Exercise status successfully updated"
-// @Failure 400 {object} responses.MessageResponse "Bad body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Scheduled training belongs to another user"
+// @Failure 404 {object} responses.ErrorResponse "No scheduled training with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/{training_id}/exercise/{exercise_id}/status [patch]
 func (t TrainingHandler) SetExerciseStatus(c *gin.Context) {
 	trainingIDStr := c.Param("training_id")
 	trainingID, err := strconv.Atoi(trainingIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
 	exerciseIDStr := c.Param("exercise_id")
 	exerciseID, err := strconv.Atoi(exerciseIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
 	var statusUpdate dto.ExerciseStatusUpdate
 	if err := c.ShouldBindJSON(&statusUpdate); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -237,12 +244,7 @@ func (t TrainingHandler) SetExerciseStatus(c *gin.Context) {
 
 	err = t.service.SetExerciseStatus(ctx, trainingID, exerciseID, statusUpdate.Status)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoExercise):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -258,8 +260,8 @@ func (t TrainingHandler) SetExerciseStatus(c *gin.Context) {
 // @Param search query string false "Search term"
 // @Param cursor query int false "Cursor for pagination"
 // @Success 200 {object} dto.TrainingCoverPagination "Return training covers"
-// @Failure 400 {object} responses.MessageResponse "Invalid query parameters"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training [get]
 func (t TrainingHandler) GetTrainings(c *gin.Context) {
 	search := c.Query("search")
@@ -269,7 +271,7 @@ func (t TrainingHandler) GetTrainings(c *gin.Context) {
 	}
 	cursor, err := strconv.Atoi(cursorStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -277,7 +279,7 @@ func (t TrainingHandler) GetTrainings(c *gin.Context) {
 
 	covers, err := t.service.GetTrainingCovers(ctx, search, cursor)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -294,9 +296,9 @@ func (t TrainingHandler) GetTrainings(c *gin.Context) {
 // @Param search query string false "Search term"
 // @Param cursor query int false "Cursor for pagination"
 // @Success 200 {object} dto.TrainingCoverPagination "Return training covers"
-// @Failure 400 {object} responses.MessageResponse "Bad query or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad query or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/user [get]
 func (t TrainingHandler) GetUserTrainings(c *gin.Context) {
 	search := c.Query("search")
@@ -306,7 +308,7 @@ func (t TrainingHandler) GetUserTrainings(c *gin.Context) {
 	}
 	cursor, err := strconv.Atoi(cursorStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -316,7 +318,7 @@ func (t TrainingHandler) GetUserTrainings(c *gin.Context) {
 
 	covers, err := t.service.GetTrainingCoversByUserID(ctx, search, userID, cursor)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -333,9 +335,9 @@ func (t TrainingHandler) GetUserTrainings(c *gin.Context) {
 // @Param search query string false "Search term"
 // @Param cursor query int false "Cursor for pagination"
 // @Success 200 {object} dto.TrainingCoverTrainerPagination "Return training covers"
-// @Failure 400 {object} responses.MessageResponse "Bad query or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad query or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/trainer [get]
 func (t TrainingHandler) GetTrainerTrainings(c *gin.Context) {
 	search := c.Query("search")
@@ -345,7 +347,7 @@ func (t TrainingHandler) GetTrainerTrainings(c *gin.Context) {
 	}
 	cursor, err := strconv.Atoi(cursorStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -355,7 +357,7 @@ func (t TrainingHandler) GetTrainerTrainings(c *gin.Context) {
 
 	covers, err := t.service.GetTrainingCoversByTrainerID(ctx, search, trainerID, cursor)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -370,15 +372,15 @@ func (t TrainingHandler) GetTrainerTrainings(c *gin.Context) {
 // @Produce json
 // @Param training_id path int true "Training ID"
 // @Success 200 {object} dto.Training "Return training"
-// @Failure 400 {object} responses.MessageResponse "Invalid training ID"
-// @Failure 404 {object} responses.MessageResponse "No training with such ID"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid training ID"
+// @Failure 404 {object} responses.ErrorResponse "No training with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/{training_id} [get]
 func (t TrainingHandler) GetTraining(c *gin.Context) {
 	trainingIDStr := c.Param("training_id")
 	trainingID, err := strconv.Atoi(trainingIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadPath})
+		c.Error(errs.ErrBadPath)
 		return
 	}
 
@@ -386,12 +388,7 @@ func (t TrainingHandler) GetTraining(c *gin.Context) {
 
 	training, err := t.service.GetTraining(ctx, trainingID)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoTraining):
-			c.JSON(http.StatusNotFound, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -406,15 +403,15 @@ func (t TrainingHandler) GetTraining(c *gin.Context) {
 // @Produce json
 // @Param training_id path int true "Training ID"
 // @Success 200 {object} dto.TrainingTrainer "Return training"
-// @Failure 400 {object} responses.MessageResponse "Invalid training ID"
-// @Failure 404 {object} responses.MessageResponse "No training with such ID"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid training ID"
+// @Failure 404 {object} responses.ErrorResponse "No training with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/{training_id}/trainer [get]
 func (t TrainingHandler) GetTrainingTrainer(c *gin.Context) {
 	trainingIDStr := c.Param("training_id")
 	trainingID, err := strconv.Atoi(trainingIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadPath})
+		c.Error(errs.ErrBadPath)
 		return
 	}
 
@@ -422,12 +419,7 @@ func (t TrainingHandler) GetTrainingTrainer(c *gin.Context) {
 
 	training, err := t.service.GetTrainingTrainer(ctx, trainingID)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoTraining):
-			c.JSON(http.StatusNotFound, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -436,15 +428,18 @@ func (t TrainingHandler) GetTrainingTrainer(c *gin.Context) {
 
 // GetScheduleTrainings
 // @Summary Get Trainings Date
-// @Description Get trainings by user training IDs
+// @Description Get trainings by user training IDs. Date and times are in the caller's profile time zone
 // @Tags Trainings
 // @Accept json
 // @Produce json
+// @Param access_token header string true "Access token"
 // @Param user_training_ids query []int true "User Training IDs"
 // @Success 200 {object} []dto.UserTraining "Return trainings with dates"
-// @Failure 400 {object} responses.MessageResponse "Invalid user training IDs"
-// @Failure 404 {object} responses.MessageResponse "No schedule training with such ID"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid user training IDs or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "One of scheduled trainings belongs to another user"
+// @Failure 404 {object} responses.ErrorResponse "No schedule training with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/date [get]
 func (t TrainingHandler) GetScheduleTrainings(c *gin.Context) {
 	userTrainingIDsStr := c.QueryArray("user_training_ids")
@@ -457,7 +452,7 @@ func (t TrainingHandler) GetScheduleTrainings(c *gin.Context) {
 	for _, idStr := range userTrainingIDsStr {
 		id, err := strconv.Atoi(idStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: "Invalid user training ID"})
+			c.Error(errs.ErrBadQuery)
 			return
 		}
 		userTrainingIDs = append(userTrainingIDs, id)
@@ -465,14 +460,14 @@ func (t TrainingHandler) GetScheduleTrainings(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	trainingDates, err := t.service.GetScheduleTrainings(ctx, userTrainingIDs)
+	if err := t.policy.CheckUserTrainings(ctx, middleware.Caller(c), userTrainingIDs); err != nil {
+		middleware.Abort(c, err)
+		return
+	}
+
+	trainingDates, err := t.service.GetScheduleTrainings(ctx, middleware.Caller(c), userTrainingIDs)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoTraining):
-			c.JSON(http.StatusNotFound, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -481,22 +476,23 @@ func (t TrainingHandler) GetScheduleTrainings(c *gin.Context) {
 
 // ScheduleTraining
 // @Summary Schedule Training
-// @Description Schedule training
+// @Description Schedule training. With rrule (RFC 5545 subset: FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY, COUNT, UNTIL) a recurring series starting at date is created instead and {"series_id"} is returned.
+// @Description Date and times are in the user's profile time zone, a series repeats in it
 // @Tags Trainings
 // @Accept json
 // @Produce json
 // @Param access_token header string true "Access token"
 // @Param training body dto.ScheduleTraining true "Scheduled training data to create"
-// @Success 201 {object} responses.CreatedIDResponse "Scheduled training successfully created"
-// @Failure 400 {object} responses.MessageResponse "Bad body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Success 201 {object} responses.CreatedIDIDsResponse "Scheduled training successfully created"
+// @Failure 400 {object} responses.ErrorResponse "Bad body, recurrence rule or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/schedule [post]
 func (t TrainingHandler) ScheduleTraining(c *gin.Context) {
 	var training dto.ScheduleTraining
 
 	if err := c.ShouldBindJSON(&training); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -504,9 +500,20 @@ func (t TrainingHandler) ScheduleTraining(c *gin.Context) {
 
 	userID := c.GetInt(middleware.UserID)
 
+	if training.RRule != nil {
+		seriesID, err := t.service.ScheduleSeries(ctx, t.converter.ScheduleTrainingDTOToDomain(training, userID))
+		if err != nil {
+			c.Error(err)
+			return
+		}
+
+		c.JSON(http.StatusCreated, responses.CreatedSeriesResponse{SeriesID: seriesID})
+		return
+	}
+
 	id, ids, err := t.service.ScheduleTraining(ctx, t.converter.ScheduleTrainingDTOToDomain(training, userID))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -516,57 +523,26 @@ func (t TrainingHandler) ScheduleTraining(c *gin.Context) {
 	})
 }
 
-// GetSchedule
-// @Summary Get Schedule
-// @Description Get schedule for the specified month
-// @Tags Trainings
-// @Accept json
-// @Produce json
-// @Param access_token header string true "Access token"
-// @Param month query int true "Month (1-12)"
-// @Success 200 {object} []dto.TrainingSchedule "Return schedule for the month"
-// @Failure 400 {object} responses.MessageResponse "Bad month or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
-// @Router /api/training/schedule [get]
-func (t TrainingHandler) GetSchedule(c *gin.Context) {
-	monthStr := c.Query("month")
-	month, err := strconv.Atoi(monthStr)
-	if err != nil || month < 1 || month > 12 {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
-		return
-	}
-
-	ctx := c.Request.Context()
-
-	userID := c.GetInt(middleware.UserID)
-
-	schedule, err := t.service.GetSchedule(ctx, month, userID)
-	if err != nil {
-		c.Status(http.StatusInternalServerError)
-		return
-	}
-
-	c.JSON(http.StatusOK, schedule)
-}
-
 // DeleteUserTraining
 // @Summary Delete User Training
 // @Description Delete a user training
 // @Tags Trainings
 // @Accept json
 // @Produce json
+// @Param access_token header string true "Access token"
 // @Param training_id path int true "Training ID"
 // @Success 200 "Training deleted successfully"
-// @Failure 400 {object} responses.MessageResponse "Invalid training ID"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid training ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Training belongs to another user"
+// @Failure 404 {object} responses.ErrorResponse "No training with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/user/{training_id} [delete]
 func (t TrainingHandler) DeleteUserTraining(c *gin.Context) {
 	trainingIDStr := c.Param("training_id")
 	trainingID, err := strconv.Atoi(trainingIDStr)
 	if err != nil || trainingID <= 0 {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadPath})
+		c.Error(errs.ErrBadPath)
 		return
 	}
 
@@ -574,7 +550,7 @@ func (t TrainingHandler) DeleteUserTraining(c *gin.Context) {
 
 	err = t.service.DeleteUserTraining(ctx, trainingID)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -587,17 +563,20 @@ func (t TrainingHandler) DeleteUserTraining(c *gin.Context) {
 // @Tags Trainings
 // @Accept json
 // @Produce json
+// @Param access_token header string true "Access token"
 // @Param user_training_id path int true "User Training ID"
 // @Success 200 "Scheduled training deleted successfully"
-// @Failure 400 {object} responses.MessageResponse "Invalid user training ID"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid user training ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Scheduled training belongs to another user"
+// @Failure 404 {object} responses.ErrorResponse "No scheduled training with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/schedule/{user_training_id} [delete]
 func (t TrainingHandler) DeleteScheduledTraining(c *gin.Context) {
 	userTrainingIDStr := c.Param("user_training_id")
 	userTrainingID, err := strconv.Atoi(userTrainingIDStr)
 	if err != nil || userTrainingID <= 0 {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadPath})
+		c.Error(errs.ErrBadPath)
 		return
 	}
 
@@ -605,13 +584,163 @@ func (t TrainingHandler) DeleteScheduledTraining(c *gin.Context) {
 
 	err = t.service.DeleteScheduledTraining(ctx, userTrainingID)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// UpdateSeries
+// @Summary Update Training Series
+// @Description Change the recurrence rule, start date and time of the whole series. Saved and cancelled occurrences are kept
+// @Tags Trainings
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param series_id path int true "Series ID"
+// @Param series body dto.TrainingSeriesUpdate true "New series data"
+// @Success 200 "Series updated successfully"
+// @Failure 400 {object} responses.ErrorResponse "Bad body, recurrence rule, series ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 404 {object} responses.ErrorResponse "No own series with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/training/schedule/series/{series_id} [put]
+func (t TrainingHandler) UpdateSeries(c *gin.Context) {
+	seriesID, err := strconv.Atoi(c.Param("series_id"))
+	if err != nil || seriesID <= 0 {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	var series dto.TrainingSeriesUpdate
+
+	if err = c.ShouldBindJSON(&series); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	err = t.service.UpdateSeries(c.Request.Context(), t.converter.TrainingSeriesUpdateDTOToDomain(series, seriesID, c.GetInt(middleware.UserID)))
+	if err != nil {
+		c.Error(err)
 		return
 	}
 
 	c.Status(http.StatusOK)
 }
 
+// DeleteSeries
+// @Summary Delete Training Series
+// @Description Delete the whole series. Occurrences that were saved separately stay as ordinary scheduled trainings
+// @Tags Trainings
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param series_id path int true "Series ID"
+// @Success 200 "Series deleted successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid series ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 404 {object} responses.ErrorResponse "No own series with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/training/schedule/series/{series_id} [delete]
+func (t TrainingHandler) DeleteSeries(c *gin.Context) {
+	seriesID, err := strconv.Atoi(c.Param("series_id"))
+	if err != nil || seriesID <= 0 {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	if err = t.service.DeleteSeries(c.Request.Context(), c.GetInt(middleware.UserID), seriesID); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// SaveOccurrence
+// @Summary Update Series Occurrence
+// @Description Move a single occurrence of the series, the rest of the series is not changed. The occurrence is saved as a scheduled training with exercises of the series, its id is returned
+// @Tags Trainings
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param series_id path int true "Series ID"
+// @Param date path string true "Original occurrence date (YYYY-MM-DD)"
+// @Param occurrence body dto.TrainingOccurrenceUpdate true "New occurrence date and time"
+// @Success 200 {object} responses.CreatedIDResponse "Occurrence saved successfully"
+// @Failure 400 {object} responses.ErrorResponse "Bad body, path or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 404 {object} responses.ErrorResponse "No own series with such ID or no occurrence on this date"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/training/schedule/series/{series_id}/occurrence/{date} [put]
+func (t TrainingHandler) SaveOccurrence(c *gin.Context) {
+	seriesID, date, ok := occurrenceParams(c)
+	if !ok {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	var occurrence dto.TrainingOccurrenceUpdate
+
+	if err := c.ShouldBindJSON(&occurrence); err != nil {
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	id, err := t.service.SaveOccurrence(c.Request.Context(), t.converter.TrainingOccurrenceUpdateDTOToDomain(occurrence, seriesID, c.GetInt(middleware.UserID), date))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, responses.CreatedIDResponse{ID: id})
+}
+
+// CancelOccurrence
+// @Summary Cancel Series Occurrence
+// @Description Cancel a single occurrence of the series, the rest of the series is not changed
+// @Tags Trainings
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param series_id path int true "Series ID"
+// @Param date path string true "Original occurrence date (YYYY-MM-DD)"
+// @Success 200 "Occurrence cancelled successfully"
+// @Failure 400 {object} responses.ErrorResponse "Invalid path or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 404 {object} responses.ErrorResponse "No own series with such ID or no occurrence on this date"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/training/schedule/series/{series_id}/occurrence/{date} [delete]
+func (t TrainingHandler) CancelOccurrence(c *gin.Context) {
+	seriesID, date, ok := occurrenceParams(c)
+	if !ok {
+		c.Error(errs.ErrBadPath)
+		return
+	}
+
+	if err := t.service.CancelOccurrence(c.Request.Context(), c.GetInt(middleware.UserID), seriesID, date); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func occurrenceParams(c *gin.Context) (int, time.Time, bool) {
+	seriesID, err := strconv.Atoi(c.Param("series_id"))
+	if err != nil || seriesID <= 0 {
+		return 0, time.Time{}, false
+	}
+
+	date, err := time.Parse(time.DateOnly, c.Param("date"))
+	if err != nil {
+		return 0, time.Time{}, false
+	}
+
+	return seriesID, date, true
+}
+
 // CreatePlanUser
 // @Summary Create Plan
 // @Description Create a new plan
@@ -621,15 +750,15 @@ func (t TrainingHandler) DeleteScheduledTraining(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param plan body dto.PlanCreate true "Plan data to create"
 // @Success 201 {object} responses.CreatedIDResponse "Plan successfully created"
-// @Failure 400 {object} responses.MessageResponse "Bad body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/plan/user [post]
 func (t TrainingHandler) CreatePlanUser(c *gin.Context) {
 	var plan dto.PlanCreate
 
 	if err := c.ShouldBindJSON(&plan); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -639,7 +768,7 @@ func (t TrainingHandler) CreatePlanUser(c *gin.Context) {
 
 	id, err := t.service.CreatePlan(ctx, t.converter.PlanCreateDTOToDomain(plan, userID))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -652,24 +781,27 @@ func (t TrainingHandler) CreatePlanUser(c *gin.Context) {
 // @Tags Trainings
 // @Accept json
 // @Produce json
+// @Param access_token header string true "Access token"
 // @Param user_id path int true "User ID"
 // @Param plan body dto.PlanCreate true "Plan data to create"
 // @Success 201 {object} responses.CreatedIDResponse "Plan successfully created"
-// @Failure 400 {object} responses.MessageResponse "Bad body provided"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "User is not a client of the trainer or email is not verified"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/plan/user/{user_id} [post]
 func (t TrainingHandler) CreatePlanTrainer(c *gin.Context) {
 	userIDStr := c.Param("user_id")
 	userID, err := strconv.Atoi(userIDStr)
 	if err != nil || userID <= 0 {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadPath})
+		c.Error(errs.ErrBadPath)
 		return
 	}
 
 	var plan dto.PlanCreate
 
 	if err := c.ShouldBindJSON(&plan); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
@@ -677,7 +809,7 @@ func (t TrainingHandler) CreatePlanTrainer(c *gin.Context) {
 
 	id, err := t.service.CreatePlan(ctx, t.converter.PlanCreateDTOToDomain(plan, userID))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -692,9 +824,9 @@ func (t TrainingHandler) CreatePlanTrainer(c *gin.Context) {
 // @Produce json
 // @Param access_token header string true "Access token"
 // @Success 200 {object} []dto.PlanCover "Return plan covers"
-// @Failure 400 {object} responses.MessageResponse "Bad query or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad query or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/plan/user [get]
 func (t TrainingHandler) GetPlanCoversByUserID(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -703,7 +835,7 @@ func (t TrainingHandler) GetPlanCoversByUserID(c *gin.Context) {
 
 	covers, err := t.service.GetPlanCoversByUserID(ctx, userID)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -716,17 +848,20 @@ func (t TrainingHandler) GetPlanCoversByUserID(c *gin.Context) {
 // @Tags Trainings
 // @Accept json
 // @Produce json
+// @Param access_token header string true "Access token"
 // @Param plan_id path int true "Plan ID"
 // @Success 200 {object} dto.Plan "Return plan"
-// @Failure 400 {object} responses.MessageResponse "Invalid plan ID"
-// @Failure 404 {object} responses.MessageResponse "No plan with such ID"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid plan ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Plan belongs to another user"
+// @Failure 404 {object} responses.ErrorResponse "No plan with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/plan/{plan_id} [get]
 func (t TrainingHandler) GetPlan(c *gin.Context) {
 	planIDStr := c.Param("plan_id")
 	planID, err := strconv.Atoi(planIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadPath})
+		c.Error(errs.ErrBadPath)
 		return
 	}
 
@@ -734,7 +869,7 @@ func (t TrainingHandler) GetPlan(c *gin.Context) {
 
 	plan, err := t.service.GetPlan(ctx, planID)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -747,17 +882,20 @@ func (t TrainingHandler) GetPlan(c *gin.Context) {
 // @Tags Trainings
 // @Accept json
 // @Produce json
+// @Param access_token header string true "Access token"
 // @Param plan_id path int true "Plan ID"
 // @Success 200 "Plan deleted successfully"
-// @Failure 400 {object} responses.MessageResponse "Invalid plan ID"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid plan ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Plan belongs to another user"
+// @Failure 404 {object} responses.ErrorResponse "No plan with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/plan/{plan_id} [delete]
 func (t TrainingHandler) DeletePlan(c *gin.Context) {
 	planIDStr := c.Param("plan_id")
 	planID, err := strconv.Atoi(planIDStr)
 	if err != nil || planID <= 0 {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadPath})
+		c.Error(errs.ErrBadPath)
 		return
 	}
 
@@ -765,7 +903,7 @@ func (t TrainingHandler) DeletePlan(c *gin.Context) {
 
 	err = t.service.DeletePlan(ctx, planID)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -784,15 +922,15 @@ func (t TrainingHandler) DeletePlan(c *gin.Context) {
 // @Param date_end query string true "End date"
 // @Param page query int false "Page number"
 // @Success 200 {object} dto.ProgressPagination "List of progress with pagination"
-// @Failure 400 {object} responses.MessageResponse "Bad query or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Bad query or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/training/progress [get]
 func (t TrainingHandler) GetProgress(c *gin.Context) {
 	var filters dto.FiltersProgress
 
 	if err := c.ShouldBindQuery(&filters); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -804,7 +942,7 @@ func (t TrainingHandler) GetProgress(c *gin.Context) {
 
 	progressPagination, err := t.service.GetProgress(c.Request.Context(), t.filterConverter.FiltersProgressDTOToDomain(filters, userID))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
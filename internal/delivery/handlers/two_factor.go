@@ -0,0 +1,157 @@
+package handlers
+
+import (
+	"BACKEND/internal/delivery/middleware"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/services"
+	"BACKEND/internal/validators"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"net/http"
+)
+
+type TwoFactorHandler struct {
+	service  services.TwoFactor
+	validate *validator.Validate
+}
+
+func InitTwoFactorHandler(
+	service services.TwoFactor,
+	validate *validator.Validate,
+) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		service:  service,
+		validate: validate,
+	}
+}
+
+// Setup
+// @Summary Setup Two-factor Authentication
+// @Description Generate a TOTP secret and otpauth:// provisioning URI for a QR code. Two-factor authentication is not enabled until confirmed with a code
+// @Tags Two-factor
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Success 200 {object} dto.TwoFactorSetup "Return secret and provisioning URI"
+// @Failure 400 {object} responses.ErrorResponse "Bad JWT provided"
+// @Failure 409 {object} responses.ErrorResponse "Two-factor authentication is already enabled"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/2fa/setup [post]
+func (t TwoFactorHandler) Setup(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	setup, err := t.service.Setup(ctx, c.GetInt(middleware.UserID), c.GetString(middleware.UserType))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// Enable
+// @Summary Enable Two-factor Authentication
+// @Description Confirm setup with a code from the authenticator app. Returns recovery codes, they are shown only once
+// @Tags Two-factor
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param code body dto.TwoFactorCode true "Code from the authenticator app"
+// @Success 200 {object} dto.RecoveryCodes "Return recovery codes"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided, invalid code or two-factor authentication is not set up"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/2fa/enable [post]
+func (t TwoFactorHandler) Enable(c *gin.Context) {
+	code, ok := t.bindCode(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	codes, err := t.service.Enable(ctx, c.GetInt(middleware.UserID), c.GetString(middleware.UserType), code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+// Disable
+// @Summary Disable Two-factor Authentication
+// @Description Disable two-factor authentication with a code from the authenticator app or a recovery code
+// @Tags Two-factor
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param code body dto.TwoFactorCode true "Code from the authenticator app or recovery code"
+// @Success 200 "Two-factor authentication disabled successfully"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided, invalid code or two-factor authentication is not enabled"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/2fa/disable [post]
+func (t TwoFactorHandler) Disable(c *gin.Context) {
+	code, ok := t.bindCode(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	err := t.service.Disable(ctx, c.GetInt(middleware.UserID), c.GetString(middleware.UserType), code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// RegenerateRecoveryCodes
+// @Summary Regenerate Recovery Codes
+// @Description Replace all recovery codes with new ones. Old codes stop working
+// @Tags Two-factor
+// @Accept json
+// @Produce json
+// @Param access_token header string true "Access token"
+// @Param code body dto.TwoFactorCode true "Code from the authenticator app or recovery code"
+// @Success 200 {object} dto.RecoveryCodes "Return new recovery codes"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided, invalid code or two-factor authentication is not enabled"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /api/auth/2fa/recovery [post]
+func (t TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	code, ok := t.bindCode(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	codes, err := t.service.RegenerateRecoveryCodes(ctx, c.GetInt(middleware.UserID), c.GetString(middleware.UserType), code)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, codes)
+}
+
+func (t TwoFactorHandler) bindCode(c *gin.Context) (string, bool) {
+	var code dto.TwoFactorCode
+	if err := c.ShouldBindJSON(&code); err != nil {
+		c.Error(errs.ErrBadBody)
+		return "", false
+	}
+
+	if err := t.validate.Struct(code); err != nil {
+		c.Error(validators.ValidationError(err, &dto.TwoFactorCode{}))
+		return "", false
+	}
+
+	return code.Code, true
+}
@@ -7,8 +7,6 @@ import (
 	"BACKEND/internal/models/dto"
 	"BACKEND/internal/services"
 	"BACKEND/internal/validators"
-	"BACKEND/pkg/responses"
-	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"net/http"
@@ -40,9 +38,9 @@ func InitUserHandler(
 // @Produce json
 // @Param access_token header string true "Access token"
 // @Success 200 {object} dto.User "Return user"
-// @Failure 400 {object} responses.MessageResponse "Bad body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal Server Error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/user/me [get]
 func (u UserHandler) Me(c *gin.Context) {
 	ctx := c.Request.Context()
@@ -51,12 +49,7 @@ func (u UserHandler) Me(c *gin.Context) {
 
 	user, err := u.service.GetByID(ctx, userID)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoUser):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -71,14 +64,15 @@ func (u UserHandler) Me(c *gin.Context) {
 // @Produce json
 // @Param user_id path int true "User ID"
 // @Success 200 {object} dto.User "Return user"
-// @Failure 400 {object} responses.MessageResponse "Bad body provided"
-// @Failure 500 "Internal Server Error"
+// @Failure 400 {object} responses.ErrorResponse "Bad body provided"
+// @Failure 404 {object} responses.ErrorResponse "No user with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/user/{user_id} [get]
 func (u UserHandler) GetProfile(c *gin.Context) {
 	idStr := c.Param("user_id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -86,12 +80,7 @@ func (u UserHandler) GetProfile(c *gin.Context) {
 
 	user, err := u.service.GetByID(ctx, id)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoUser):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -107,8 +96,8 @@ func (u UserHandler) GetProfile(c *gin.Context) {
 // @Param search query string false "Search term"
 // @Param cursor query int false "Cursor for pagination"
 // @Success 200 {object} dto.UserCoverPagination "List of user covers with pagination"
-// @Failure 400 {object} responses.MessageResponse "Invalid query parameters"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/user [get]
 func (u UserHandler) GetCovers(c *gin.Context) {
 	search := c.Query("search")
@@ -118,13 +107,13 @@ func (u UserHandler) GetCovers(c *gin.Context) {
 	}
 	cursor, err := strconv.Atoi(cursorStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
 	userCovers, err := u.service.GetCovers(c.Request.Context(), search, cursor)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -140,21 +129,20 @@ func (u UserHandler) GetCovers(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param user body dto.UserUpdate true "User data to set"
 // @Success 200 "User successfully updated"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/user/main [put]
 func (u UserHandler) UpdateMain(c *gin.Context) {
 	var newUserMain dto.UserUpdate
 
 	if err := c.ShouldBindJSON(&newUserMain); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	if err := u.validate.Struct(newUserMain); err != nil {
-		customErr := validators.CustomErrorMessage(err, &dto.UserUpdate{})
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: customErr})
+		c.Error(validators.ValidationError(err, &dto.UserUpdate{}))
 		return
 	}
 
@@ -164,12 +152,7 @@ func (u UserHandler) UpdateMain(c *gin.Context) {
 
 	err := u.service.UpdateMain(ctx, u.converter.UserUpdateDTOToDomain(newUserMain, userID))
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoUser):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
@@ -185,9 +168,9 @@ func (u UserHandler) UpdateMain(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param photo formData file false "New user photo with type jpeg/jpg/png/svg under 2MB"
 // @Success 200 "User successfully updated"
-// @Failure 400 {object} responses.MessageResponse "Invalid photo or jwt provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid photo or jwt provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/user/photo [put]
 func (u UserHandler) UpdatePhoto(c *gin.Context) {
 	file, _ := c.FormFile("photo")
@@ -196,13 +179,13 @@ func (u UserHandler) UpdatePhoto(c *gin.Context) {
 		// Проверка на ограничение по размеру файла на 2МБ
 		err := c.Request.ParseMultipartForm(2 << 20)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: "Photo size is bigger than 2MB"})
+			c.Error(errs.ErrFileTooLarge)
 			return
 		}
 
 		// Проверка на допустимый тип `Content-Type` и расширение
 		if !validators.ValidateFileTypeExtension(file) {
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: "Photo bad type or extension"})
+			c.Error(errs.ErrBadFile)
 			return
 		}
 	}
@@ -211,12 +194,7 @@ func (u UserHandler) UpdatePhoto(c *gin.Context) {
 
 	err := u.service.UpdatePhotoUrl(c, file, userID)
 	if err != nil {
-		switch {
-		case errors.Is(err, errs.ErrNoUser):
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: err.Error()})
-		default:
-			c.Status(http.StatusInternalServerError)
-		}
+		c.Error(err)
 		return
 	}
 
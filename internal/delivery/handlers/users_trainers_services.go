@@ -3,25 +3,37 @@ package handlers
 import (
 	"BACKEND/internal/converters"
 	"BACKEND/internal/delivery/middleware"
+	"BACKEND/internal/errs"
 	"BACKEND/internal/models/dto"
 	"BACKEND/internal/repository"
 	"BACKEND/internal/services"
 	"BACKEND/pkg/responses"
+	"BACKEND/pkg/utils"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
 )
 
+// statusAccess - кто может менять статус услуги соответствующего типа
+var statusAccess = map[int]string{
+	1: utils.Trainer,
+	2: utils.Trainer,
+	3: utils.User,
+}
+
 type UserTrainerServiceHandler struct {
 	service   services.UserTrainerServices
+	policy    services.Policies
 	converter converters.ServicesConverter
 }
 
 func InitUserTrainerServiceHandler(
 	service services.UserTrainerServices,
+	policy services.Policies,
 ) *UserTrainerServiceHandler {
 	return &UserTrainerServiceHandler{
 		service:   service,
+		policy:    policy,
 		converter: converters.InitServiceConverter(),
 	}
 }
@@ -35,25 +47,32 @@ func InitUserTrainerServiceHandler(
 // @Param access_token header string true "Access token"
 // @Param service body dto.UserTrainerServiceCreateTrainer true "Service data to create"
 // @Success 201 {object} responses.CreatedIDResponse "Service created successfully"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Service belongs to another trainer or email is not verified"
+// @Failure 404 {object} responses.ErrorResponse "No service with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/service [post]
 func (s UserTrainerServiceHandler) CreateService(c *gin.Context) {
 	var service dto.UserTrainerServiceCreateTrainer
 
 	if err := c.ShouldBindJSON(&service); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	ctx := c.Request.Context()
 
+	if err := s.policy.CheckTrainerService(ctx, middleware.Caller(c), service.ServiceID); err != nil {
+		middleware.Abort(c, err)
+		return
+	}
+
 	trainerID := c.GetInt(middleware.UserID)
 
 	id, err := s.service.Create(ctx, s.converter.UserTrainerServiceCreateTrainerDTOToDomain(service, trainerID))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -62,83 +81,64 @@ func (s UserTrainerServiceHandler) CreateService(c *gin.Context) {
 
 // ScheduleService
 // @Summary Schedule Service
-// @Description Schedule a new service between user and trainer
+// @Description Schedule a session of the contract. Time must be within trainer availability (see slots) and must not overlap other sessions of the trainer.
+// @Description Date and times are in the caller's profile time zone
 // @Tags Services
 // @Accept json
 // @Produce json
+// @Param access_token header string true "Access token"
 // @Param schedule body dto.ScheduleService true "Schedule data to create"
 // @Success 201 {object} responses.CreatedIDResponse "Schedule created successfully"
-// @Failure 400 {object} responses.MessageResponse "Invalid body provided"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Caller is not a participant of the service or email is not verified"
+// @Failure 404 {object} responses.ErrorResponse "No service with such ID"
+// @Failure 409 {object} responses.ErrorResponse "Trainer is not available at this time or time overlaps another session"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/service/schedule [post]
 func (s UserTrainerServiceHandler) ScheduleService(c *gin.Context) {
 	var schedule dto.ScheduleService
 
 	if err := c.ShouldBindJSON(&schedule); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	ctx := c.Request.Context()
 
-	id, err := s.service.Schedule(ctx, s.converter.ScheduleServiceDTOToDomain(schedule))
-	if err != nil {
-		c.Status(http.StatusInternalServerError)
-		return
-	}
-
-	c.JSON(http.StatusCreated, responses.CreatedIDResponse{ID: id})
-}
-
-// GetSchedule
-// @Summary Get Schedule
-// @Description Get schedule for the specified month
-// @Tags Services
-// @Accept json
-// @Produce json
-// @Param access_token header string true "Access token"
-// @Param month path int true "Month (1-12)"
-// @Success 200 {object} []dto.TrainingSchedule "Return schedule for the month"
-// @Failure 400 {object} responses.MessageResponse "Invalid month or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
-// @Router /api/service/schedule/{month} [get]
-func (s UserTrainerServiceHandler) GetSchedule(c *gin.Context) {
-	monthStr := c.Param("month")
-	month, err := strconv.Atoi(monthStr)
-	if err != nil || month < 1 || month > 12 {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadPath})
+	if err := s.policy.CheckUserTrainerService(ctx, middleware.Caller(c), schedule.ScheduleID); err != nil {
+		middleware.Abort(c, err)
 		return
 	}
 
-	ctx := c.Request.Context()
-
-	trainerID := c.GetInt(middleware.UserID)
-
-	schedule, err := s.service.GetSchedule(ctx, month, trainerID)
+	id, err := s.service.Schedule(ctx, middleware.Caller(c), s.converter.ScheduleServiceDTOToDomain(schedule))
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
-	c.JSON(http.StatusOK, schedule)
+	c.JSON(http.StatusCreated, responses.CreatedIDResponse{ID: id})
 }
 
 // GetSchedulesByIDs
 // @Summary Get Schedules by IDs
-// @Description Get schedules by an array of schedule IDs
+// @Description Get schedules by an array of schedule IDs. Date and times are in the caller's profile time zone
 // @Tags Services
 // @Accept json
 // @Produce json
+// @Param access_token header string true "Access token"
 // @Param schedule_ids query []int true "Array of schedule IDs"
 // @Success 200 {object} []dto.ScheduleServiceUser "Return schedules for the given IDs"
-// @Failure 400 {object} responses.MessageResponse "Invalid query parameters provided"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query parameters or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Caller is not a participant of one of the schedules"
+// @Failure 404 {object} responses.ErrorResponse "No schedule with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/service/schedule [get]
 func (s UserTrainerServiceHandler) GetSchedulesByIDs(c *gin.Context) {
 	scheduleIDsStr := c.QueryArray("schedule_ids")
 	if len(scheduleIDsStr) == 0 {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -146,7 +146,7 @@ func (s UserTrainerServiceHandler) GetSchedulesByIDs(c *gin.Context) {
 	for _, idStr := range scheduleIDsStr {
 		id, err := strconv.Atoi(idStr)
 		if err != nil {
-			c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+			c.Error(errs.ErrBadQuery)
 			return
 		}
 		scheduleIDs = append(scheduleIDs, id)
@@ -154,9 +154,14 @@ func (s UserTrainerServiceHandler) GetSchedulesByIDs(c *gin.Context) {
 
 	ctx := c.Request.Context()
 
-	schedules, err := s.service.GetSchedulesByIDs(ctx, scheduleIDs)
+	if err := s.policy.CheckSchedules(ctx, middleware.Caller(c), scheduleIDs); err != nil {
+		middleware.Abort(c, err)
+		return
+	}
+
+	schedules, err := s.service.GetSchedulesByIDs(ctx, middleware.Caller(c), scheduleIDs)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -169,16 +174,20 @@ func (s UserTrainerServiceHandler) GetSchedulesByIDs(c *gin.Context) {
 // @Tags Services
 // @Accept json
 // @Produce json
+// @Param access_token header string true "Access token"
 // @Param schedule_id path int true "Schedule ID"
 // @Success 200 "Schedule deleted successfully"
-// @Failure 400 {object} responses.MessageResponse "Invalid schedule ID provided"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid schedule ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Caller is not a participant of the schedule"
+// @Failure 404 {object} responses.ErrorResponse "No schedule with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/service/schedule/{schedule_id} [delete]
 func (s UserTrainerServiceHandler) DeleteScheduled(c *gin.Context) {
 	scheduleIDStr := c.Param("schedule_id")
 	scheduleID, err := strconv.Atoi(scheduleIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -186,7 +195,7 @@ func (s UserTrainerServiceHandler) DeleteScheduled(c *gin.Context) {
 
 	err = s.service.DeleteScheduled(ctx, scheduleID)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -202,9 +211,9 @@ func (s UserTrainerServiceHandler) DeleteScheduled(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param cursor query int false "Cursor for pagination"
 // @Success 200 {object} dto.ServiceUserPagination "List of user services with pagination"
-// @Failure 400 {object} responses.MessageResponse "Invalid query parameters"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/service/trainer [get]
 func (s UserTrainerServiceHandler) GetTrainerServices(c *gin.Context) {
 	cursorStr := c.Query("cursor")
@@ -213,7 +222,7 @@ func (s UserTrainerServiceHandler) GetTrainerServices(c *gin.Context) {
 	}
 	cursor, err := strconv.Atoi(cursorStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -223,7 +232,7 @@ func (s UserTrainerServiceHandler) GetTrainerServices(c *gin.Context) {
 
 	userServices, err := s.service.GetUserServices(ctx, trainerID, cursor)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -239,9 +248,9 @@ func (s UserTrainerServiceHandler) GetTrainerServices(c *gin.Context) {
 // @Param access_token header string true "Access token"
 // @Param cursor query int false "Cursor for pagination"
 // @Success 200 {object} dto.ServiceTrainerPagination "List of trainer services with pagination"
-// @Failure 400 {object} responses.MessageResponse "Invalid query parameters"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/service/user [get]
 func (s UserTrainerServiceHandler) GetUserServices(c *gin.Context) {
 	cursorStr := c.Query("cursor")
@@ -250,7 +259,7 @@ func (s UserTrainerServiceHandler) GetUserServices(c *gin.Context) {
 	}
 	cursor, err := strconv.Atoi(cursorStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -260,7 +269,7 @@ func (s UserTrainerServiceHandler) GetUserServices(c *gin.Context) {
 
 	trainerServices, err := s.service.GetTrainerServices(ctx, userID, cursor)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -273,32 +282,41 @@ func (s UserTrainerServiceHandler) GetUserServices(c *gin.Context) {
 // @Description type = 1 - payment status
 // @Description type = 2 - trainer confirm status
 // @Description type = 3 - user confirm status
+// @Description type 1 and 2 can be changed by trainer only, type 3 - by user only
 // @Tags Services
 // @Accept json
 // @Produce json
+// @Param access_token header string true "Access token"
 // @Param service_id path int true "Service ID"
 // @Param status body dto.UpdateStatusService true "Status data to update"
 // @Success 200 "Status updated successfully"
-// @Failure 400 {object} responses.MessageResponse "Invalid body or JWT provided"
-// @Failure 401 {object} responses.MessageResponse "JWT is expired or invalid"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid body or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Caller is not a participant of the service or can't change this status or email is not verified"
+// @Failure 404 {object} responses.ErrorResponse "No service with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/service/status/{service_id} [put]
 func (s UserTrainerServiceHandler) UpdateStatus(c *gin.Context) {
 	serviceIDStr := c.Param("service_id")
 	serviceID, err := strconv.Atoi(serviceIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
 	var status dto.UpdateStatusService
 	if err := c.ShouldBindJSON(&status); err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
 		return
 	}
 
 	if status.Type < 1 || status.Type > 3 {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadBody})
+		c.Error(errs.ErrBadBody)
+		return
+	}
+
+	if statusAccess[status.Type] != c.GetString(middleware.UserType) {
+		c.Error(errs.ErrForbidden)
 		return
 	}
 
@@ -306,7 +324,7 @@ func (s UserTrainerServiceHandler) UpdateStatus(c *gin.Context) {
 
 	err = s.service.UpdateStatus(ctx, repository.UsersTrainersServicesField[status.Type], serviceID, status.Status)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -319,16 +337,20 @@ func (s UserTrainerServiceHandler) UpdateStatus(c *gin.Context) {
 // @Tags Services
 // @Accept json
 // @Produce json
+// @Param access_token header string true "Access token"
 // @Param service_id path int true "Service ID"
 // @Success 200 "Service deleted successfully"
-// @Failure 400 {object} responses.MessageResponse "Invalid service ID provided"
-// @Failure 500 "Internal server error"
+// @Failure 400 {object} responses.ErrorResponse "Invalid service ID or JWT provided"
+// @Failure 401 {object} responses.ErrorResponse "JWT is expired or invalid"
+// @Failure 403 {object} responses.ErrorResponse "Caller is not a participant of the service"
+// @Failure 404 {object} responses.ErrorResponse "No service with such ID"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
 // @Router /api/service/{service_id} [delete]
 func (s UserTrainerServiceHandler) DeleteService(c *gin.Context) {
 	serviceIDStr := c.Param("service_id")
 	serviceID, err := strconv.Atoi(serviceIDStr)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, responses.MessageResponse{Message: responses.ResponseBadQuery})
+		c.Error(errs.ErrBadQuery)
 		return
 	}
 
@@ -336,7 +358,7 @@ func (s UserTrainerServiceHandler) DeleteService(c *gin.Context) {
 
 	err = s.service.Delete(ctx, serviceID)
 	if err != nil {
-		c.Status(http.StatusInternalServerError)
+		c.Error(err)
 		return
 	}
 
@@ -1,42 +1,103 @@
 package middleware
 
 import (
-	"BACKEND/pkg/responses"
+	"BACKEND/internal/errs"
+	"BACKEND/pkg/utils"
 	"fmt"
 	"github.com/gin-gonic/gin"
-	"net/http"
 )
 
 const (
-	UserID = "user_id"
+	UserID     = "user_id"
+	UserType   = "user_type"
+	SessionID  = "session_id"
+	IsVerified = "is_verified"
+	AdminRoles = "admin_roles"
 )
 
 const (
 	AccessToken = "access_token"
 )
 
-func (m Middleware) Authorization(userType string) gin.HandlerFunc {
+func (m Middleware) Authorization(userTypes ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		accessToken := c.GetHeader(AccessToken)
 		if accessToken == "" {
 			m.logger.Error().Msg("No jwt provided")
-			c.AbortWithStatusJSON(http.StatusBadRequest, responses.MessageResponse{Message: "No jwt provided"})
+			Abort(c, errs.NeedToAuth)
 			return
 		}
 
-		userData, isValid, err := m.jwtUtil.Authorize(accessToken, userType)
+		userData, isValid, err := m.jwtUtil.Authorize(accessToken, userTypes...)
 		if err != nil {
 			m.logger.Error().Msg(fmt.Sprintf("Troubles while getting user info from jwt: %v", err))
-			c.AbortWithStatusJSON(http.StatusBadRequest, responses.MessageResponse{Message: "Bad jwt provided"})
+			Abort(c, errs.ErrBadJWT)
 			return
 		}
 
 		if !isValid {
 			m.logger.Error().Msg("Access token is expired or invalid or user type mismatch")
-			c.AbortWithStatusJSON(http.StatusUnauthorized, responses.MessageResponse{Message: "Access token is expired or invalid"})
+			Abort(c, errs.ErrInvalidJWT)
+			return
+		}
+
+		session, isActive, err := m.session.Check(c.Request.Context(), userData.SessionID)
+		if err != nil {
+			m.logger.Error().Msg(fmt.Sprintf("Troubles while checking session: %v", err))
+			Abort(c, err)
+			return
+		}
+
+		if !isActive {
+			m.logger.Error().Msg(fmt.Sprintf("Session %s is revoked", userData.SessionID))
+			Abort(c, errs.ErrSessionRevoked)
+			return
+		}
+
+		if session.Suspended() {
+			m.logger.Error().Msg(fmt.Sprintf("%s %d is suspended", userData.UserType, userData.ID))
+			Abort(c, errs.ErrSuspended)
 			return
 		}
 
 		c.Set(UserID, userData.ID)
+		c.Set(UserType, userData.UserType)
+		c.Set(SessionID, userData.SessionID)
+		c.Set(IsVerified, session.IsVerified)
+		c.Set(AdminRoles, session.Roles)
+	}
+}
+
+// AdminAuthorization пропускает администраторов, у которых есть хотя бы одна из ролей
+func (m Middleware) AdminAuthorization(roles ...string) gin.HandlerFunc {
+	authorize := m.Authorization(utils.Admin)
+
+	return func(c *gin.Context) {
+		authorize(c)
+		if c.IsAborted() {
+			return
+		}
+
+		for _, adminRole := range c.GetStringSlice(AdminRoles) {
+			for _, role := range roles {
+				if adminRole == role {
+					return
+				}
+			}
+		}
+
+		m.logger.Error().Msg(fmt.Sprintf("Admin %d has none of roles %v", c.GetInt(UserID), roles))
+		Abort(c, errs.ErrForbidden)
+	}
+}
+
+// Verified ограничивает доступ аккаунтам с неподтвержденной почтой. Должен стоять после Authorization
+func (m Middleware) Verified() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !c.GetBool(IsVerified) {
+			m.logger.Error().Msg(fmt.Sprintf("%s %d is not verified", c.GetString(UserType), c.GetInt(UserID)))
+			Abort(c, errs.ErrNotVerified)
+			return
+		}
 	}
 }
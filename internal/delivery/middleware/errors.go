@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/pkg/responses"
+	"errors"
+	"github.com/gin-gonic/gin"
+)
+
+// Errors отвечает на ошибку, добавленную через c.Error, в едином формате. Статус и код берутся из каталога errs,
+// сообщение - на языке из Accept-Language. Должен стоять первым, чтобы обрабатывать ошибки остальных middleware
+func (m Middleware) Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		lang := errs.ParseLanguage(c.GetHeader("Accept-Language"))
+
+		c.Header("Content-Language", string(lang))
+		c.Header("Vary", "Accept-Language")
+		c.JSON(errs.Status(err), m.errorResponse(err, lang))
+	}
+}
+
+// Abort прерывает обработку запроса с ошибкой, ответ формирует Errors
+func Abort(c *gin.Context, err error) {
+	c.Error(err)
+	c.Abort()
+}
+
+func (m Middleware) errorResponse(err error, lang errs.Language) responses.ErrorResponse {
+	var validationErr *errs.ValidationError
+	if errors.As(err, &validationErr) {
+		details := make([]responses.FieldError, len(validationErr.Fields))
+		for i, field := range validationErr.Fields {
+			details[i] = responses.FieldError{
+				Field:   field.Field,
+				Code:    field.Tag,
+				Message: field.Message(lang),
+			}
+		}
+
+		return responses.ErrorResponse{
+			Code:    errs.ErrValidation.Code,
+			Message: errs.ErrValidation.Message(lang),
+			Details: details,
+		}
+	}
+
+	var catalogErr *errs.Error
+	if !errors.As(err, &catalogErr) {
+		// Текст внутренних ошибок не отдается клиенту
+		m.logger.Error().Msg(err.Error())
+		catalogErr = errs.ErrInternal
+	}
+
+	return responses.ErrorResponse{
+		Code:    catalogErr.Code,
+		Message: catalogErr.Message(lang),
+	}
+}
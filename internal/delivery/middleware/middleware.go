@@ -7,14 +7,18 @@ import (
 
 type Middleware struct {
 	jwtUtil utils.JWT
+	session utils.Session
 	logger  zerolog.Logger
 }
 
 func InitMiddleware(
+	jwtUtil utils.JWT,
+	session utils.Session,
 	logger zerolog.Logger,
 ) *Middleware {
 	return &Middleware{
-		jwtUtil: utils.InitJWTUtil(),
+		jwtUtil: jwtUtil,
+		session: session,
 		logger:  logger,
 	}
 }
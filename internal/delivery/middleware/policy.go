@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"context"
+	"github.com/gin-gonic/gin"
+	"strconv"
+)
+
+type PolicyCheck func(ctx context.Context, caller domain.Caller, objectID int) error
+
+// Policy проверяет права вызывающего на объект, id которого передан в параметре пути `param`.
+// Должен стоять после Authorization
+func (m Middleware) Policy(check PolicyCheck, param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		objectID, err := strconv.Atoi(c.Param(param))
+		if err != nil || objectID <= 0 {
+			Abort(c, errs.ErrBadPath)
+			return
+		}
+
+		err = check(c.Request.Context(), Caller(c), objectID)
+		if err != nil {
+			Abort(c, err)
+			return
+		}
+	}
+}
+
+// Caller возвращает данные пользователя, положенные в контекст Authorization
+func Caller(c *gin.Context) domain.Caller {
+	return domain.Caller{
+		ID:   c.GetInt(UserID),
+		Type: c.GetString(UserType),
+	}
+}
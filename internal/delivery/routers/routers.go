@@ -9,6 +9,7 @@ import (
 	"BACKEND/internal/validators"
 	"BACKEND/pkg/config"
 	"BACKEND/pkg/utils"
+	"context"
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
 	"github.com/jmoiron/sqlx"
@@ -17,7 +18,7 @@ import (
 	"time"
 )
 
-func InitRouting(engine *gin.Engine, db *sqlx.DB, middleWarrior *middleware.Middleware, jwtUtil utils.JWT, session utils.Session, logger zerolog.Logger) {
+func InitRouting(engine *gin.Engine, db *sqlx.DB, middleWarrior *middleware.Middleware, jwtUtil utils.JWT, session utils.Session, loginLimiter utils.LoginLimiter, broker utils.Broker, presence utils.Presence, mailer utils.Mailer, logger zerolog.Logger) *chat.Server {
 	dbResponseTime := time.Duration(viper.GetInt(config.DBResponseTime)) * time.Second
 	entitiesPerRequest := viper.GetInt(config.EntitiesPerRequest)
 
@@ -32,60 +33,203 @@ func InitRouting(engine *gin.Engine, db *sqlx.DB, middleWarrior *middleware.Midd
 	serviceRepo := repository.InitUserTrainerServicesRepo(db, entitiesPerRequest)
 	trainingRepo := repository.InitTrainingRepo(db, entitiesPerRequest)
 	chatRepo := repository.InitChatRepo(db, entitiesPerRequest)
+	policyRepo := repository.InitPolicyRepo(db)
+	adminRepo := repository.InitAdminRepo(db)
+	twoFactorRepo := repository.InitTwoFactorRepo(db)
+	applicationRepo := repository.InitTrainerApplicationRepo(db, entitiesPerRequest)
+	deletionRepo := repository.InitAccountDeletionRepo(db)
+	exportRepo := repository.InitDataExportRepo(db)
+	moderationRepo := repository.InitModerationRepo(db, entitiesPerRequest)
+	availabilityRepo := repository.InitAvailabilityRepo(db)
+	calendarRepo := repository.InitCalendarRepo(db)
 
 	// Инициализация сервисов
-	userService := services.InitUserService(userRepo, dbResponseTime, logger)
-	trainerService := services.InitTrainerService(trainerRepo, dbResponseTime, logger)
+	userService := services.InitUserService(userRepo, loginLimiter, dbResponseTime, logger)
+	trainerService := services.InitTrainerService(trainerRepo, loginLimiter, dbResponseTime, logger)
 	tokenService := services.InitTokenService(jwtUtil, session)
+	accountService := services.InitAccountService(userRepo, trainerRepo, deletionRepo, session, mailer, dbResponseTime, logger)
 	specializationService := services.InitBaseService(specializationRepo, dbResponseTime, logger)
 	roleService := services.InitBaseService(roleRepo, dbResponseTime, logger)
 	serviceService := services.InitUsersTrainersServicesService(serviceRepo, dbResponseTime, logger)
 	trainingService := services.InitTrainingService(trainingRepo, dbResponseTime, logger)
-	chatService := services.InitChatService(chatRepo, dbResponseTime, logger)
+	chatService := services.InitChatService(chatRepo, serviceService, presence, time.Duration(viper.GetInt(config.MessageDeleteTime))*time.Minute,
+		time.Duration(viper.GetInt(config.OfferTime))*time.Hour, dbResponseTime, logger)
+	policyService := services.InitPolicyService(policyRepo, dbResponseTime, logger)
+	adminService := services.InitAdminService(adminRepo, loginLimiter, dbResponseTime, logger)
+	twoFactorService := services.InitTwoFactorService(twoFactorRepo, session, dbResponseTime, logger)
+	applicationService := services.InitTrainerApplicationService(applicationRepo, session, mailer, dbResponseTime, logger)
+	moderationService := services.InitModerationService(moderationRepo, session, dbResponseTime, logger)
+	availabilityService := services.InitAvailabilityService(availabilityRepo, dbResponseTime, logger)
+	calendarService := services.InitCalendarService(calendarRepo, dbResponseTime, logger)
+	personalDataService := services.InitPersonalDataService(exportRepo, userService, trainerService, trainingService, serviceService, chatService, calendarService,
+		mailer, dbResponseTime, logger)
 
 	// Инициализация хендлеров
-	authHandler := handlers.InitAuthHandler(userService, trainerService, tokenService, validate)
+	authHandler := handlers.InitAuthHandler(userService, trainerService, adminService, tokenService, accountService, twoFactorService, validate)
 	userHandler := handlers.InitUserHandler(userService, validate)
 	trainerHandler := handlers.InitTrainerHandler(trainerService, validate)
 	specializationHandler := handlers.InitSpecializationHandler(specializationService, validate)
 	roleHandler := handlers.InitRoleHandler(roleService, validate)
-	userTrainerServiceHandler := handlers.InitUserTrainerServiceHandler(serviceService)
-	trainingHandler := handlers.InitTrainingsHandler(trainingService)
-	chatHandler := handlers.InitChatHandler(chatService)
+	userTrainerServiceHandler := handlers.InitUserTrainerServiceHandler(serviceService, policyService)
+	trainingHandler := handlers.InitTrainingsHandler(trainingService, policyService)
+	chatServer := chat.NewServer(chatService, broker, chat.LoadConfig(), jwtUtil, session, logger)
+	chatHandler := handlers.InitChatHandler(chatService, chatServer, validate)
 	serviceHandler := handlers.InitServiceHandler(roleService)
+	adminHandler := handlers.InitAdminHandler(adminService)
+	twoFactorHandler := handlers.InitTwoFactorHandler(twoFactorService, validate)
+	jwksHandler := handlers.InitJWKSHandler(jwtUtil)
+	applicationHandler := handlers.InitTrainerApplicationHandler(applicationService, validate)
+	accountHandler := handlers.InitAccountHandler(accountService, personalDataService, validate)
+	moderationHandler := handlers.InitModerationHandler(moderationService, validate)
+	availabilityHandler := handlers.InitAvailabilityHandler(availabilityService, validate)
+	calendarHandler := handlers.InitCalendarHandler(calendarService, validate)
 
 	// Инициализация middleware
 	userMiddleware := middleWarrior.Authorization(utils.User)
 	trainerMiddleware := middleWarrior.Authorization(utils.Trainer)
 	adminMiddleware := middleWarrior.Authorization(utils.Admin)
+	moderatorMiddleware := middleWarrior.AdminAuthorization(utils.ContentModerator)
+	onboardingMiddleware := middleWarrior.AdminAuthorization(utils.TrainerOnboarding)
+	userTrainerMiddleware := middleWarrior.Authorization(utils.User, utils.Trainer)
+	anyMiddleware := middleWarrior.Authorization(utils.User, utils.Trainer, utils.Admin)
+	trainerAdminMiddleware := middleWarrior.Authorization(utils.Trainer, utils.Admin)
+	verifiedMiddleware := middleWarrior.Verified()
+
+	// Ошибки всех маршрутов отдаются в едином формате
+	engine.Use(middleWarrior.Errors())
 
 	// Группа маршрутов
 	baseGroup := engine.Group("/api")
-	initAuthRouter(baseGroup, authHandler, adminMiddleware)
+	initAuthRouter(baseGroup, authHandler, onboardingMiddleware, userTrainerMiddleware, anyMiddleware)
+	initAccountRouter(baseGroup, accountHandler, userTrainerMiddleware)
+	initAdminRouter(baseGroup, adminHandler, adminMiddleware)
+	initTwoFactorRouter(baseGroup, twoFactorHandler, trainerAdminMiddleware)
+	initTrainerApplicationRouter(baseGroup, applicationHandler, onboardingMiddleware)
+	initModerationRouter(baseGroup, moderationHandler, moderatorMiddleware)
+	initAvailabilityRouter(baseGroup, availabilityHandler, trainerMiddleware, userTrainerMiddleware)
+	initCalendarRouter(baseGroup, calendarHandler, middleWarrior, policyService, userMiddleware, userTrainerMiddleware)
 	initUserRouter(baseGroup, userHandler, userMiddleware)
-	initTrainerRouter(baseGroup, trainerHandler, trainerMiddleware, adminMiddleware)
-	initRolesRouter(baseGroup, roleHandler, adminMiddleware)
-	initSpecializationsRouter(baseGroup, specializationHandler, adminMiddleware)
-	initUserTrainerServicesRouter(baseGroup, userTrainerServiceHandler, userMiddleware, trainerMiddleware)
-	initTrainingsRouter(baseGroup, trainingHandler, userMiddleware, trainerMiddleware, adminMiddleware)
-	initChatRouter(baseGroup, chatHandler, userMiddleware, trainerMiddleware)
+	initTrainerRouter(baseGroup, trainerHandler, trainerMiddleware, moderatorMiddleware, verifiedMiddleware)
+	initRolesRouter(baseGroup, roleHandler, moderatorMiddleware)
+	initSpecializationsRouter(baseGroup, specializationHandler, moderatorMiddleware)
+	initUserTrainerServicesRouter(baseGroup, userTrainerServiceHandler, middleWarrior, policyService, userMiddleware, trainerMiddleware, userTrainerMiddleware, verifiedMiddleware)
+	initTrainingsRouter(baseGroup, trainingHandler, middleWarrior, policyService, userMiddleware, trainerMiddleware, moderatorMiddleware, userTrainerMiddleware, verifiedMiddleware)
+	initChatRouter(baseGroup, chatHandler, userMiddleware, trainerMiddleware, userTrainerMiddleware, verifiedMiddleware)
 	initServiceRouter(baseGroup, serviceHandler)
 
+	engine.GET("/.well-known/jwks.json", jwksHandler.GetJWKS)
+
 	wsGroup := engine.Group("/ws")
-	chatServer := chat.NewServer(chatService, jwtUtil, logger)
 	go chatServer.Listen()
 	wsGroup.GET("", chatServer.ChatHandler)
+
+	go purgeAccounts(accountService, personalDataService, chatService)
+
+	return chatServer
 }
 
-func initAuthRouter(group *gin.RouterGroup, authHandler *handlers.AuthHandler, adminMiddleware gin.HandlerFunc) {
+// purgeAccounts удаляет аккаунты с наступившим сроком удаления и устаревшие выгрузки данных
+func purgeAccounts(accountService services.Accounts, personalDataService services.PersonalData, chatService services.Chat) {
+	purgeTime := time.Duration(viper.GetInt(config.AccountPurgeTime)) * time.Minute
+	if purgeTime <= 0 {
+		return
+	}
+
+	for range time.Tick(purgeTime) {
+		ctx := context.Background()
+		accountService.PurgeDeleted(ctx)
+		personalDataService.DeleteExpired(ctx)
+		chatService.DeleteUnattached(ctx)
+	}
+}
+
+func initAuthRouter(group *gin.RouterGroup, authHandler *handlers.AuthHandler, onboardingMiddleware, userTrainerMiddleware, anyMiddleware gin.HandlerFunc) {
 	authGroup := group.Group("/auth")
 
 	authGroup.POST("register/user", authHandler.RegisterUser)
 	authGroup.POST("login/user", authHandler.AuthorizeUser)
-	authGroup.POST("register/trainer", adminMiddleware, authHandler.RegisterTrainer)
+	authGroup.POST("register/trainer", onboardingMiddleware, authHandler.RegisterTrainer)
 	authGroup.POST("login/trainer", authHandler.AuthorizeTrainer)
 	authGroup.POST("login/admin", authHandler.AuthorizeAdmin)
+	authGroup.POST("login/2fa", authHandler.AuthorizeTwoFactor)
 	authGroup.GET("refresh", authHandler.Refresh)
+	authGroup.POST("logout", anyMiddleware, authHandler.Logout)
+	authGroup.POST("logout/all", anyMiddleware, authHandler.LogoutAll)
+	authGroup.GET("session", anyMiddleware, authHandler.GetSessions)
+	authGroup.DELETE("session/:session_id", anyMiddleware, authHandler.DeleteSession)
+	authGroup.POST("verify/send", userTrainerMiddleware, authHandler.SendVerification)
+	authGroup.POST("verify", authHandler.Verify)
+	authGroup.POST("password/forgot", authHandler.ForgotPassword)
+	authGroup.POST("password/reset", authHandler.ResetPassword)
+}
+
+func initTwoFactorRouter(group *gin.RouterGroup, twoFactorHandler *handlers.TwoFactorHandler, trainerAdminMiddleware gin.HandlerFunc) {
+	twoFactorGroup := group.Group("/auth/2fa")
+
+	twoFactorGroup.POST("setup", trainerAdminMiddleware, twoFactorHandler.Setup)
+	twoFactorGroup.POST("enable", trainerAdminMiddleware, twoFactorHandler.Enable)
+	twoFactorGroup.POST("disable", trainerAdminMiddleware, twoFactorHandler.Disable)
+	twoFactorGroup.POST("recovery", trainerAdminMiddleware, twoFactorHandler.RegenerateRecoveryCodes)
+}
+
+func initTrainerApplicationRouter(group *gin.RouterGroup, applicationHandler *handlers.TrainerApplicationHandler, onboardingMiddleware gin.HandlerFunc) {
+	group.POST("/trainer/application", applicationHandler.Submit)
+
+	applicationGroup := group.Group("/admin/trainer-application")
+
+	applicationGroup.GET("", onboardingMiddleware, applicationHandler.GetCovers)
+	applicationGroup.GET(":application_id", onboardingMiddleware, applicationHandler.GetByID)
+	applicationGroup.POST(":application_id/approve", onboardingMiddleware, applicationHandler.Approve)
+	applicationGroup.POST(":application_id/reject", onboardingMiddleware, applicationHandler.Reject)
+}
+
+func initModerationRouter(group *gin.RouterGroup, moderationHandler *handlers.ModerationHandler, moderatorMiddleware gin.HandlerFunc) {
+	adminGroup := group.Group("/admin")
+
+	adminGroup.GET("message-report", moderatorMiddleware, moderationHandler.GetReports)
+	adminGroup.POST("message-report/:report_id/review", moderatorMiddleware, moderationHandler.ReviewReport)
+	adminGroup.POST("suspension/user/:user_id", moderatorMiddleware, moderationHandler.SuspendUser)
+	adminGroup.DELETE("suspension/user/:user_id", moderatorMiddleware, moderationHandler.UnsuspendUser)
+	adminGroup.POST("suspension/trainer/:trainer_id", moderatorMiddleware, moderationHandler.SuspendTrainer)
+	adminGroup.DELETE("suspension/trainer/:trainer_id", moderatorMiddleware, moderationHandler.UnsuspendTrainer)
+}
+
+func initAvailabilityRouter(group *gin.RouterGroup, availabilityHandler *handlers.AvailabilityHandler, trainerMiddleware, userTrainerMiddleware gin.HandlerFunc) {
+	trainerGroup := group.Group("/trainer")
+
+	trainerGroup.GET("availability", trainerMiddleware, availabilityHandler.GetAvailability)
+	trainerGroup.PUT("availability", trainerMiddleware, availabilityHandler.UpdateAvailability)
+	trainerGroup.POST("availability/exception", trainerMiddleware, availabilityHandler.CreateException)
+	trainerGroup.DELETE("availability/exception/:exception_id", trainerMiddleware, availabilityHandler.DeleteException)
+	trainerGroup.POST("availability/import", trainerMiddleware, availabilityHandler.ImportBusy)
+	trainerGroup.DELETE("availability/import", trainerMiddleware, availabilityHandler.ClearBusy)
+	trainerGroup.GET(":trainer_id/slots", userTrainerMiddleware, availabilityHandler.GetSlots)
+}
+
+func initCalendarRouter(group *gin.RouterGroup, calendarHandler *handlers.CalendarHandler, middleWarrior *middleware.Middleware, policy services.Policies, userMiddleware, userTrainerMiddleware gin.HandlerFunc) {
+	calendarGroup := group.Group("/calendar")
+
+	calendarGroup.GET("", userTrainerMiddleware, calendarHandler.GetEvents)
+	calendarGroup.POST("feed", userTrainerMiddleware, calendarHandler.CreateFeed)
+	calendarGroup.DELETE("feed", userTrainerMiddleware, calendarHandler.DeleteFeed)
+	calendarGroup.GET("feed/:token", calendarHandler.GetFeed)
+	calendarGroup.GET("training/:user_training_id", userMiddleware, middleWarrior.Policy(policy.CheckUserTraining, "user_training_id"), calendarHandler.GetTraining)
+}
+
+func initAccountRouter(group *gin.RouterGroup, accountHandler *handlers.AccountHandler, userTrainerMiddleware gin.HandlerFunc) {
+	accountGroup := group.Group("/account")
+
+	accountGroup.POST("deletion", userTrainerMiddleware, accountHandler.RequestDeletion)
+	accountGroup.DELETE("deletion", userTrainerMiddleware, accountHandler.CancelDeletion)
+	accountGroup.POST("export", userTrainerMiddleware, accountHandler.RequestExport)
+	accountGroup.GET("export/:export_id", userTrainerMiddleware, accountHandler.GetExport)
+	accountGroup.GET("export/:export_id/download", userTrainerMiddleware, accountHandler.DownloadExport)
+}
+
+func initAdminRouter(group *gin.RouterGroup, adminHandler *handlers.AdminHandler, adminMiddleware gin.HandlerFunc) {
+	adminGroup := group.Group("/admin")
+
+	adminGroup.GET("me", adminMiddleware, adminHandler.Me)
 }
 
 func initUserRouter(group *gin.RouterGroup, userHandler *handlers.UserHandler, userMiddleware gin.HandlerFunc) {
@@ -98,7 +242,7 @@ func initUserRouter(group *gin.RouterGroup, userHandler *handlers.UserHandler, u
 	userGroup.PUT("photo", userMiddleware, userHandler.UpdatePhoto)
 }
 
-func initTrainerRouter(group *gin.RouterGroup, trainerHandler *handlers.TrainerHandler, trainerMiddleware gin.HandlerFunc, adminMiddleware gin.HandlerFunc) {
+func initTrainerRouter(group *gin.RouterGroup, trainerHandler *handlers.TrainerHandler, trainerMiddleware, moderatorMiddleware, verifiedMiddleware gin.HandlerFunc) {
 	userGroup := group.Group("/trainer")
 
 	userGroup.GET("me", trainerMiddleware, trainerHandler.Me)
@@ -108,42 +252,41 @@ func initTrainerRouter(group *gin.RouterGroup, trainerHandler *handlers.TrainerH
 	userGroup.PUT("photo", trainerMiddleware, trainerHandler.UpdatePhoto)
 	userGroup.PUT("roles", trainerMiddleware, trainerHandler.UpdateRoles)
 	userGroup.PUT("specializations", trainerMiddleware, trainerHandler.UpdateSpecializations)
-	userGroup.POST("service", trainerMiddleware, trainerHandler.CreateService)
-	userGroup.PUT("service", trainerMiddleware, trainerHandler.UpdateService)
+	userGroup.POST("service", trainerMiddleware, verifiedMiddleware, trainerHandler.CreateService)
+	userGroup.PUT("service", trainerMiddleware, verifiedMiddleware, trainerHandler.UpdateService)
 	userGroup.DELETE("service/:service_id", trainerMiddleware, trainerHandler.DeleteService)
-	userGroup.POST("achievement", trainerMiddleware, trainerHandler.CreateAchievement)
-	userGroup.PUT("achievement/:achievement_id/status", adminMiddleware, trainerHandler.UpdateAchievementStatus)
+	userGroup.POST("achievement", trainerMiddleware, verifiedMiddleware, trainerHandler.CreateAchievement)
+	userGroup.PUT("achievement/:achievement_id/status", moderatorMiddleware, trainerHandler.UpdateAchievementStatus)
 	userGroup.DELETE("achievement/:achievement_id", trainerMiddleware, trainerHandler.DeleteAchievement)
 }
 
-func initRolesRouter(group *gin.RouterGroup, roleHandler *handlers.RoleHandler, adminMiddleware gin.HandlerFunc) {
+func initRolesRouter(group *gin.RouterGroup, roleHandler *handlers.RoleHandler, moderatorMiddleware gin.HandlerFunc) {
 	roleGroup := group.Group("/role")
 
-	roleGroup.POST("", adminMiddleware, roleHandler.CreateRole)
+	roleGroup.POST("", moderatorMiddleware, roleHandler.CreateRole)
 	roleGroup.GET("", roleHandler.GetRoles)
-	roleGroup.DELETE("", adminMiddleware, roleHandler.DeleteRoles)
+	roleGroup.DELETE("", moderatorMiddleware, roleHandler.DeleteRoles)
 }
 
-func initSpecializationsRouter(group *gin.RouterGroup, specializationHandler *handlers.SpecializationHandler, adminMiddleware gin.HandlerFunc) {
+func initSpecializationsRouter(group *gin.RouterGroup, specializationHandler *handlers.SpecializationHandler, moderatorMiddleware gin.HandlerFunc) {
 	specializationGroup := group.Group("/specialization")
 
-	specializationGroup.POST("", adminMiddleware, specializationHandler.CreateSpecialization)
+	specializationGroup.POST("", moderatorMiddleware, specializationHandler.CreateSpecialization)
 	specializationGroup.GET("", specializationHandler.GetSpecializations)
-	specializationGroup.DELETE("", adminMiddleware, specializationHandler.DeleteSpecializations)
+	specializationGroup.DELETE("", moderatorMiddleware, specializationHandler.DeleteSpecializations)
 }
 
-func initUserTrainerServicesRouter(group *gin.RouterGroup, serviceHandler *handlers.UserTrainerServiceHandler, userMiddleware gin.HandlerFunc, trainerMiddleware gin.HandlerFunc) {
+func initUserTrainerServicesRouter(group *gin.RouterGroup, serviceHandler *handlers.UserTrainerServiceHandler, middleWarrior *middleware.Middleware, policy services.Policies, userMiddleware, trainerMiddleware, userTrainerMiddleware, verifiedMiddleware gin.HandlerFunc) {
 	serviceGroup := group.Group("/service")
 
-	serviceGroup.POST("", trainerMiddleware, serviceHandler.CreateService)
-	serviceGroup.POST("schedule", serviceHandler.ScheduleService)
-	serviceGroup.GET("schedule/:month", trainerMiddleware, serviceHandler.GetSchedule)
-	serviceGroup.GET("schedule", serviceHandler.GetSchedulesByIDs)
-	serviceGroup.DELETE("schedule/:schedule_id", serviceHandler.DeleteScheduled)
+	serviceGroup.POST("", trainerMiddleware, verifiedMiddleware, serviceHandler.CreateService)
+	serviceGroup.POST("schedule", userTrainerMiddleware, verifiedMiddleware, serviceHandler.ScheduleService)
+	serviceGroup.GET("schedule", userTrainerMiddleware, serviceHandler.GetSchedulesByIDs)
+	serviceGroup.DELETE("schedule/:schedule_id", userTrainerMiddleware, middleWarrior.Policy(policy.CheckSchedule, "schedule_id"), serviceHandler.DeleteScheduled)
 	serviceGroup.GET("trainer", trainerMiddleware, serviceHandler.GetTrainerServices)
 	serviceGroup.GET("user", userMiddleware, serviceHandler.GetUserServices)
-	serviceGroup.PUT("status/:service_id", serviceHandler.UpdateStatus)
-	serviceGroup.DELETE(":service_id", serviceHandler.DeleteService)
+	serviceGroup.PUT("status/:service_id", userTrainerMiddleware, verifiedMiddleware, middleWarrior.Policy(policy.CheckUserTrainerService, "service_id"), serviceHandler.UpdateStatus)
+	serviceGroup.DELETE(":service_id", userTrainerMiddleware, middleWarrior.Policy(policy.CheckUserTrainerService, "service_id"), serviceHandler.DeleteService)
 }
 
 func initServiceRouter(group *gin.RouterGroup, serviceHandler *handlers.ServiceHandler) {
@@ -152,40 +295,56 @@ func initServiceRouter(group *gin.RouterGroup, serviceHandler *handlers.ServiceH
 	serviceGroup.GET(":id", serviceHandler.GetServiceByID)
 }
 
-func initTrainingsRouter(group *gin.RouterGroup, trainingHandler *handlers.TrainingHandler, userMiddleware, trainerMiddleware, adminMiddleware gin.HandlerFunc) {
+func initTrainingsRouter(group *gin.RouterGroup, trainingHandler *handlers.TrainingHandler, middleWarrior *middleware.Middleware, policy services.Policies, userMiddleware, trainerMiddleware, moderatorMiddleware, userTrainerMiddleware, verifiedMiddleware gin.HandlerFunc) {
 	trainingGroup := group.Group("/training")
 
-	trainingGroup.POST("exercise", adminMiddleware, trainingHandler.CreateExercises)
+	trainingGroup.POST("exercise", moderatorMiddleware, trainingHandler.CreateExercises)
 	trainingGroup.GET("exercise", trainingHandler.GetExercises)
-	trainingGroup.POST("base", adminMiddleware, trainingHandler.CreateTrainingBase)
+	trainingGroup.POST("base", moderatorMiddleware, trainingHandler.CreateTrainingBase)
 	trainingGroup.POST("", userMiddleware, trainingHandler.CreateTraining)
 	trainingGroup.POST("trainer", trainerMiddleware, trainingHandler.CreateTrainingTrainer)
-	trainingGroup.PATCH(":training_id/exercise/:exercise_id/status", userMiddleware, trainingHandler.SetExerciseStatus)
+	trainingGroup.PATCH(":training_id/exercise/:exercise_id/status", userMiddleware, middleWarrior.Policy(policy.CheckUserTraining, "training_id"), trainingHandler.SetExerciseStatus)
 	trainingGroup.GET("", trainingHandler.GetTrainings)
 	trainingGroup.GET("user", userMiddleware, trainingHandler.GetUserTrainings)
 	trainingGroup.GET("trainer", trainerMiddleware, trainingHandler.GetTrainerTrainings)
 	trainingGroup.GET(":training_id", trainingHandler.GetTraining)
 	trainingGroup.GET(":training_id/trainer", trainingHandler.GetTrainingTrainer)
-	trainingGroup.GET("date", trainingHandler.GetScheduleTrainings)
+	trainingGroup.GET("date", userTrainerMiddleware, trainingHandler.GetScheduleTrainings)
 	trainingGroup.POST("schedule", userMiddleware, trainingHandler.ScheduleTraining)
-	trainingGroup.GET("schedule", userMiddleware, trainingHandler.GetSchedule)
-	trainingGroup.DELETE("user/:training_id", trainingHandler.DeleteUserTraining)
-	trainingGroup.DELETE("schedule/:user_training_id", trainingHandler.DeleteScheduledTraining)
+	trainingGroup.DELETE("user/:training_id", userTrainerMiddleware, middleWarrior.Policy(policy.CheckTraining, "training_id"), trainingHandler.DeleteUserTraining)
+	trainingGroup.DELETE("schedule/:user_training_id", userMiddleware, middleWarrior.Policy(policy.CheckUserTraining, "user_training_id"), trainingHandler.DeleteScheduledTraining)
+	trainingGroup.PUT("schedule/series/:series_id", userMiddleware, trainingHandler.UpdateSeries)
+	trainingGroup.DELETE("schedule/series/:series_id", userMiddleware, trainingHandler.DeleteSeries)
+	trainingGroup.PUT("schedule/series/:series_id/occurrence/:date", userMiddleware, trainingHandler.SaveOccurrence)
+	trainingGroup.DELETE("schedule/series/:series_id/occurrence/:date", userMiddleware, trainingHandler.CancelOccurrence)
 
 	trainingGroup.POST("plan/user", userMiddleware, trainingHandler.CreatePlanUser)
-	trainingGroup.POST("plan/user/:user_id", trainingHandler.CreatePlanTrainer)
+	trainingGroup.POST("plan/user/:user_id", trainerMiddleware, verifiedMiddleware, middleWarrior.Policy(policy.CheckClient, "user_id"), trainingHandler.CreatePlanTrainer)
 	trainingGroup.GET("plan/user", userMiddleware, trainingHandler.GetPlanCoversByUserID)
-	trainingGroup.GET("plan/:plan_id", trainingHandler.GetPlan)
-	trainingGroup.DELETE("plan/:plan_id", trainingHandler.DeletePlan)
+	trainingGroup.GET("plan/:plan_id", userTrainerMiddleware, middleWarrior.Policy(policy.CheckPlan, "plan_id"), trainingHandler.GetPlan)
+	trainingGroup.DELETE("plan/:plan_id", userTrainerMiddleware, middleWarrior.Policy(policy.CheckPlan, "plan_id"), trainingHandler.DeletePlan)
 
 	trainingGroup.GET("progress", userMiddleware, trainingHandler.GetProgress)
 }
 
-func initChatRouter(group *gin.RouterGroup, chatHandler *handlers.ChatHandler, userMiddleware gin.HandlerFunc, trainerMiddleware gin.HandlerFunc) {
+func initChatRouter(group *gin.RouterGroup, chatHandler *handlers.ChatHandler, userMiddleware, trainerMiddleware, userTrainerMiddleware, verifiedMiddleware gin.HandlerFunc) {
 	chatGroup := group.Group("/chat")
 
-	chatGroup.GET("user", userMiddleware, chatHandler.GetUserChats)
-	chatGroup.GET("trainer", trainerMiddleware, chatHandler.GetTrainerChats)
-	chatGroup.GET("user/:trainer_id", userMiddleware, chatHandler.GetChatMessageUser)
-	chatGroup.GET("trainer/:user_id", trainerMiddleware, chatHandler.GetChatMessageTrainer)
+	chatGroup.GET("user", userMiddleware, verifiedMiddleware, chatHandler.GetUserChats)
+	chatGroup.GET("trainer", trainerMiddleware, verifiedMiddleware, chatHandler.GetTrainerChats)
+	chatGroup.GET("user/presence", userMiddleware, verifiedMiddleware, chatHandler.GetTrainersPresence)
+	chatGroup.GET("trainer/presence", trainerMiddleware, verifiedMiddleware, chatHandler.GetUsersPresence)
+	chatGroup.GET("user/:trainer_id", userMiddleware, verifiedMiddleware, chatHandler.GetChatMessageUser)
+	chatGroup.GET("trainer/:user_id", trainerMiddleware, verifiedMiddleware, chatHandler.GetChatMessageTrainer)
+	chatGroup.GET("search", userTrainerMiddleware, verifiedMiddleware, chatHandler.SearchMessages)
+	chatGroup.GET("blocks", userTrainerMiddleware, verifiedMiddleware, chatHandler.GetBlocked)
+	chatGroup.POST("user/:trainer_id/block", userMiddleware, verifiedMiddleware, chatHandler.BlockTrainer)
+	chatGroup.DELETE("user/:trainer_id/block", userMiddleware, verifiedMiddleware, chatHandler.UnblockTrainer)
+	chatGroup.POST("trainer/:user_id/block", trainerMiddleware, verifiedMiddleware, chatHandler.BlockUser)
+	chatGroup.DELETE("trainer/:user_id/block", trainerMiddleware, verifiedMiddleware, chatHandler.UnblockUser)
+	chatGroup.POST("message/:message_id/report", userTrainerMiddleware, verifiedMiddleware, chatHandler.ReportMessage)
+	chatGroup.POST("attachment", userTrainerMiddleware, verifiedMiddleware, chatHandler.UploadAttachment)
+	chatGroup.PUT("message/:message_id", userTrainerMiddleware, verifiedMiddleware, chatHandler.EditMessage)
+	chatGroup.DELETE("message/:message_id", userTrainerMiddleware, verifiedMiddleware, chatHandler.DeleteMessage)
+	chatGroup.GET("message/:message_id/edits", userTrainerMiddleware, verifiedMiddleware, chatHandler.GetMessageEdits)
 }
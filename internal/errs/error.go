@@ -0,0 +1,52 @@
+package errs
+
+import (
+	"errors"
+	"net/http"
+)
+
+// Error - ошибка из каталога: стабильный код для клиентов, HTTP статус и сообщения на поддерживаемых языках
+type Error struct {
+	Code     string
+	Status   int
+	messages map[Language]string
+}
+
+func New(code string, status int, ru, en string) *Error {
+	return &Error{
+		Code:   code,
+		Status: status,
+		messages: map[Language]string{
+			RU: ru,
+			EN: en,
+		},
+	}
+}
+
+// Error возвращает сообщение на языке по умолчанию, оно же попадает в логи
+func (e *Error) Error() string {
+	return e.messages[DefaultLanguage]
+}
+
+func (e *Error) Message(lang Language) string {
+	if message, ok := e.messages[lang]; ok {
+		return message
+	}
+
+	return e.messages[DefaultLanguage]
+}
+
+// Status возвращает HTTP статус ошибки, для ошибок не из каталога - 500
+func Status(err error) int {
+	var catalogErr *Error
+	if errors.As(err, &catalogErr) {
+		return catalogErr.Status
+	}
+
+	var validationErr *ValidationError
+	if errors.As(err, &validationErr) {
+		return ErrValidation.Status
+	}
+
+	return http.StatusInternalServerError
+}
@@ -1,19 +1,74 @@
 package errs
 
-import "errors"
+import "net/http"
 
+// Каталог ошибок API. Код ошибки стабилен и не меняется вместе с текстом сообщения
 var (
-	NeedToAuth = errors.New("Необходима авторизация")
-
-	ErrNoRole           = errors.New("Роли с данным id не существует")
-	ErrNoSpecialization = errors.New("Специализации с данным id не существует")
-	ErrNoAchievement    = errors.New("Достижения с данным id не существует")
-	ErrNoService        = errors.New("Достижения с данным id не существует")
-	ErrNoUser           = errors.New("Пользователя с данным id не существует")
-	ErrNoTrainer        = errors.New("Тренера с данным id не существует")
-	ErrNoTraining       = errors.New("Тренировки с данным id не существует")
-	ErrNoExercise       = errors.New("Упражнения с данным id не существует")
-	InvalidEmail        = errors.New("Пользователя с такой почтой не существует")
-	InvalidPassword     = errors.New("Пароль не верен")
-	ErrAlreadyExist     = errors.New("Сущность уже существует")
+	ErrInternal   = New("internal", http.StatusInternalServerError, "Внутренняя ошибка сервера", "Internal server error")
+	ErrBadBody    = New("bad_body", http.StatusBadRequest, "Некорректное тело запроса", "Bad body provided")
+	ErrBadQuery   = New("bad_query", http.StatusBadRequest, "Некорректные параметры запроса", "Bad query provided")
+	ErrBadPath    = New("bad_path", http.StatusBadRequest, "Некорректный параметр пути", "Bad path parameter provided")
+	ErrValidation = New("validation", http.StatusBadRequest, "Данные запроса не прошли проверку", "Request validation failed")
+
+	ErrBadFile        = New("bad_file", http.StatusBadRequest, "Недопустимый тип или расширение файла", "File has unsupported type or extension")
+	ErrFileTooLarge   = New("file_too_large", http.StatusBadRequest, "Файл превышает допустимый размер", "File exceeds the allowed size")
+	ErrTooManyFiles   = New("too_many_files", http.StatusBadRequest, "Превышено допустимое количество файлов", "Too many files provided")
+	ErrBadJWT         = New("bad_jwt", http.StatusBadRequest, "Некорректный токен доступа", "Bad jwt provided")
+	ErrInvalidJWT     = New("invalid_jwt", http.StatusUnauthorized, "Токен доступа истек или недействителен", "Access token is expired or invalid")
+	ErrSessionRevoked = New("session_revoked", http.StatusUnauthorized, "Сессия завершена", "Session is revoked")
+
+	NeedToAuth   = New("need_to_auth", http.StatusUnauthorized, "Необходима авторизация", "Authorization required")
+	ErrForbidden = New("forbidden", http.StatusForbidden, "Недостаточно прав для выполнения действия", "Not enough permissions")
+
+	ErrSuspended       = New("account_suspended", http.StatusForbidden, "Аккаунт заблокирован администратором", "Account is suspended by an administrator")
+	ErrBlocked         = New("chat_blocked", http.StatusForbidden, "Переписка с этим собеседником недоступна", "Chat with this account is not available")
+	ErrNotVerified     = New("not_verified", http.StatusForbidden, "Необходимо подтвердить почту", "Email must be verified")
+	ErrAlreadyVerified = New("already_verified", http.StatusConflict, "Почта уже подтверждена", "Email is already verified")
+	ErrInvalidToken    = New("invalid_token", http.StatusBadRequest, "Токен недействителен или истек", "Token is invalid or expired")
+
+	ErrInvalidCredentials = New("invalid_credentials", http.StatusUnauthorized, "Неверная почта или пароль", "Invalid email or password")
+	ErrTooManyEvents      = New("too_many_events", http.StatusTooManyRequests, "Слишком много событий, попробуйте позже", "Too many events, try again later")
+	ErrTooManyAttempts    = New("too_many_attempts", http.StatusTooManyRequests, "Слишком много попыток входа, попробуйте позже", "Too many login attempts, try again later")
+
+	ErrNoTwoFactor      = New("two_factor_not_set_up", http.StatusBadRequest, "Двухфакторная аутентификация не настроена", "Two-factor authentication is not set up")
+	ErrTwoFactorEnabled = New("two_factor_enabled", http.StatusConflict, "Двухфакторная аутентификация уже включена", "Two-factor authentication is already enabled")
+	ErrInvalidCode      = New("invalid_code", http.StatusBadRequest, "Неверный код подтверждения", "Invalid confirmation code")
+
+	ErrNoRole              = New("role_not_found", http.StatusBadRequest, "Роли с данным id не существует", "Role with this id does not exist")
+	ErrNoSpecialization    = New("specialization_not_found", http.StatusBadRequest, "Специализации с данным id не существует", "Specialization with this id does not exist")
+	ErrNoObjects           = New("objects_not_found", http.StatusBadRequest, "Один из переданных объектов не существует", "One of the provided objects does not exist")
+	ErrNoAttachment        = New("attachment_not_found", http.StatusBadRequest, "Вложения с данным id не существует или оно уже отправлено", "Attachment with this id does not exist or is already sent")
+	ErrNoExercise          = New("exercise_not_found", http.StatusBadRequest, "Упражнения с данным id не существует", "Exercise with this id does not exist")
+	ErrNoAchievement       = New("achievement_not_found", http.StatusNotFound, "Достижения с данным id не существует", "Achievement with this id does not exist")
+	ErrNoService           = New("service_not_found", http.StatusNotFound, "Услуги с данным id не существует", "Service with this id does not exist")
+	ErrNoUser              = New("user_not_found", http.StatusNotFound, "Пользователя с данным id не существует", "User with this id does not exist")
+	ErrNoTrainer           = New("trainer_not_found", http.StatusNotFound, "Тренера с данным id не существует", "Trainer with this id does not exist")
+	ErrNoTraining          = New("training_not_found", http.StatusNotFound, "Тренировки с данным id не существует", "Training with this id does not exist")
+	ErrNoPlan              = New("plan_not_found", http.StatusNotFound, "Плана с данным id не существует", "Plan with this id does not exist")
+	ErrNoSchedule          = New("schedule_not_found", http.StatusNotFound, "Записи в расписании с данным id не существует", "Schedule entry with this id does not exist")
+	ErrNoSession           = New("session_not_found", http.StatusNotFound, "Сессии с данным id не существует", "Session with this id does not exist")
+	ErrNoAdmin             = New("admin_not_found", http.StatusNotFound, "Администратора с данным id не существует", "Admin with this id does not exist")
+	ErrNoAdminRole         = New("admin_role_not_found", http.StatusBadRequest, "Такой роли администратора не существует", "Admin role does not exist")
+	ErrNoApplication       = New("application_not_found", http.StatusNotFound, "Заявки с данным id не существует", "Application with this id does not exist")
+	ErrNoMessage           = New("message_not_found", http.StatusNotFound, "Сообщения с данным id не существует", "Message with this id does not exist")
+	ErrApplicationReviewed = New("application_reviewed", http.StatusConflict, "Заявка уже рассмотрена", "Application is already reviewed")
+	ErrNoDeletion          = New("deletion_not_requested", http.StatusConflict, "Удаление аккаунта не запрошено", "Account deletion is not requested")
+	ErrNoExport            = New("export_not_found", http.StatusNotFound, "Выгрузки данных с данным id не существует", "Data export with this id does not exist")
+	ErrExportNotReady      = New("export_not_ready", http.StatusConflict, "Выгрузка данных еще не готова", "Data export is not ready yet")
+	ErrNoReport            = New("report_not_found", http.StatusNotFound, "Жалобы с данным id не существует", "Report with this id does not exist")
+	ErrReportReviewed      = New("report_reviewed", http.StatusConflict, "Жалоба уже рассмотрена", "Report is already reviewed")
+	ErrNoOffer             = New("offer_not_found", http.StatusNotFound, "Предложения услуги с данным id не существует", "Service offer with this id does not exist")
+	ErrOfferClosed         = New("offer_closed", http.StatusConflict, "Предложение уже принято, отклонено или истекло", "Offer is already accepted, declined or expired")
+	ErrNoException         = New("availability_exception_not_found", http.StatusNotFound, "Исключения из расписания с данным id не существует", "Availability exception with this id does not exist")
+	ErrScheduleConflict    = New("schedule_conflict", http.StatusConflict, "Время пересекается с другим занятием тренера", "Time overlaps another session of the trainer")
+	ErrNoSeries            = New("training_series_not_found", http.StatusNotFound, "Серии тренировок с данным id не существует", "Training series with this id does not exist")
+	ErrNoOccurrence        = New("occurrence_not_found", http.StatusNotFound, "В этот день у серии нет вхождения", "Training series has no occurrence on this date")
+	ErrBadRRule            = New("bad_rrule", http.StatusBadRequest, "Некорректное или неподдерживаемое правило повторения", "Recurrence rule is invalid or unsupported")
+	ErrNoFeed              = New("calendar_feed_not_found", http.StatusNotFound, "Подписка на календарь не найдена", "Calendar feed does not exist")
+	ErrBadCalendar         = New("bad_calendar", http.StatusBadRequest, "Файл не является корректным календарем iCalendar", "File is not a valid iCalendar")
+	ErrSlotUnavailable     = New("slot_unavailable", http.StatusConflict, "Тренер не работает в это время", "Trainer is not available at this time")
+	ErrDeleteTimeExpired   = New("delete_time_expired", http.StatusConflict, "Время на удаление сообщения истекло", "Time to delete the message has expired")
+	InvalidEmail           = New("invalid_email", http.StatusUnauthorized, "Пользователя с такой почтой не существует", "User with this email does not exist")
+	InvalidPassword        = New("invalid_password", http.StatusBadRequest, "Пароль не верен", "Wrong password")
+	ErrAlreadyExist        = New("already_exists", http.StatusConflict, "Сущность уже существует", "Entity already exists")
 )
@@ -0,0 +1,61 @@
+package errs
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Language string
+
+const (
+	RU Language = "ru"
+	EN Language = "en"
+
+	DefaultLanguage = RU
+)
+
+var supportedLanguages = map[Language]bool{
+	RU: true,
+	EN: true,
+}
+
+// ParseLanguage выбирает язык ответа по заголовку Accept-Language с учетом весов q
+func ParseLanguage(acceptLanguage string) Language {
+	type weighted struct {
+		lang   Language
+		weight float64
+	}
+
+	var langs []weighted
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+
+		// Регион не учитывается: en-US и en-GB - английский
+		base, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+		if !supportedLanguages[Language(base)] {
+			continue
+		}
+
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil || parsed <= 0 {
+				continue
+			}
+			weight = parsed
+		}
+
+		langs = append(langs, weighted{lang: Language(base), weight: weight})
+	}
+
+	if len(langs) == 0 {
+		return DefaultLanguage
+	}
+
+	sort.SliceStable(langs, func(i, j int) bool {
+		return langs[i].weight > langs[j].weight
+	})
+
+	return langs[0].lang
+}
@@ -0,0 +1,77 @@
+package errs
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Сообщения о невалидном поле по тегу валидатора. %[1]s - поле, %[2]s - параметр тега
+var fieldMessages = map[Language]map[string]string{
+	RU: {
+		"required":   "Поле `%[1]s` является обязательным.",
+		"min":        "Поле `%[1]s` должно быть не менее %[2]s.",
+		"min_string": "Поле `%[1]s` должно быть не менее %[2]s символов.",
+		"max":        "Поле `%[1]s` должно быть не более %[2]s.",
+		"max_string": "Поле `%[1]s` должно быть не более %[2]s символов.",
+		"oneof":      "Поле `%[1]s` должно быть одним из значений: %[2]s.",
+		"email":      "Поле `%[1]s` должно быть корректным адресом электронной почты.",
+		"url":        "Поле `%[1]s` должно быть корректным URL.",
+		"timezone":   "Поле `%[1]s` должно быть часовым поясом IANA, например Europe/Moscow.",
+		"password":   "Поле `%[1]s` должно содержать от 8 до 64 символов и включать заглавные и строчные буквы, цифры и специальные символы.",
+		"":           "Поле `%[1]s` является некорректным.",
+	},
+	EN: {
+		"required":   "Field `%[1]s` is required.",
+		"min":        "Field `%[1]s` must be at least %[2]s.",
+		"min_string": "Field `%[1]s` must be at least %[2]s characters long.",
+		"max":        "Field `%[1]s` must be at most %[2]s.",
+		"max_string": "Field `%[1]s` must be at most %[2]s characters long.",
+		"oneof":      "Field `%[1]s` must be one of: %[2]s.",
+		"email":      "Field `%[1]s` must be a valid email address.",
+		"url":        "Field `%[1]s` must be a valid URL.",
+		"timezone":   "Field `%[1]s` must be an IANA time zone, e.g. Europe/Moscow.",
+		"password":   "Field `%[1]s` must be 8 to 64 characters long and contain uppercase and lowercase letters, digits and special characters.",
+		"":           "Field `%[1]s` is invalid.",
+	},
+}
+
+// FieldError - невалидное поле запроса
+type FieldError struct {
+	Field    string
+	Tag      string
+	Param    string
+	IsString bool
+}
+
+func (f FieldError) Message(lang Language) string {
+	messages, ok := fieldMessages[lang]
+	if !ok {
+		messages = fieldMessages[DefaultLanguage]
+	}
+
+	key := f.Tag
+	if f.IsString && (key == "min" || key == "max") {
+		key += "_string"
+	}
+
+	message, ok := messages[key]
+	if !ok {
+		message = messages[""]
+	}
+
+	return fmt.Sprintf(message, f.Field, f.Param)
+}
+
+// ValidationError - ошибка валидации тела или параметров запроса с перечнем невалидных полей
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (v *ValidationError) Error() string {
+	messages := make([]string, len(v.Fields))
+	for i, field := range v.Fields {
+		messages[i] = field.Message(DefaultLanguage)
+	}
+
+	return strings.Join(messages, " ")
+}
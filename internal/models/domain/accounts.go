@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+// Статусы выгрузок персональных данных
+const (
+	ExportPending = "pending"
+	ExportReady   = "ready"
+	ExportFailed  = "failed"
+)
+
+// Тип письма о запланированном удалении аккаунта
+const AccountDeletion = "account_deletion"
+
+type DataExport struct {
+	ID        int
+	Status    string
+	FilePath  null.String
+	CreatedAt time.Time
+	ReadyAt   null.Time
+}
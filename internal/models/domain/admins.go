@@ -0,0 +1,18 @@
+package domain
+
+type AdminCreate struct {
+	Email    string
+	Password string
+	Roles    []string
+}
+
+type Admin struct {
+	ID    int
+	Email string
+	Roles []string
+}
+
+type AdminSecure struct {
+	Secure
+	Roles []string
+}
@@ -0,0 +1,9 @@
+package domain
+
+type Secure struct {
+	ID         int
+	Password   string
+	IsVerified bool
+	// Действует блокировка администратором
+	IsSuspended bool
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+// AvailabilityInterval рабочее время тренера в день недели Weekday (ISO: 1 - понедельник, 7 - воскресенье).
+// У TimeStart и TimeEnd значение имеет только время, оно местное в часовом поясе тренера
+type AvailabilityInterval struct {
+	Weekday   int
+	TimeStart time.Time
+	TimeEnd   time.Time
+}
+
+// AvailabilityException дни с DateStart по DateEnd включительно в часовом поясе тренера, в которые он не работает
+type AvailabilityException struct {
+	ID        int
+	TrainerID int
+	DateStart time.Time
+	DateEnd   time.Time
+	Reason    null.String
+}
+
+// Availability рабочее время тренера. Busy - занятое время из импортированного календаря
+type Availability struct {
+	Weekly     []AvailabilityInterval
+	Exceptions []AvailabilityException
+	Busy       []Slot
+}
+
+// Slot свободный для записи промежуток времени
+type Slot struct {
+	TimeStart time.Time
+	TimeEnd   time.Time
+}
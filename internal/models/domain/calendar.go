@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+// Виды событий календаря
+const (
+	EventTraining = "training"
+	EventSession  = "session"
+)
+
+// CalendarEvent событие календаря: своя тренировка пользователя или занятие с тренером по договору.
+// ID - id записи users_trainings или users_trainers_services_schedule в зависимости от Type.
+// У вхождения серии тренировок заданы SeriesID и исходный день OccurrenceDate, а ID равен 0, пока вхождение не сохранено
+type CalendarEvent struct {
+	ID             int
+	Type           string
+	Title          string
+	TimeStart      time.Time
+	TimeEnd        time.Time
+	TrainingID     null.Int
+	ContractID     null.Int
+	SeriesID       null.Int
+	OccurrenceDate null.Time
+	Counterpart    CalendarCounterpart
+}
+
+// CalendarCounterpart собеседник по занятию: тренер для пользователя, пользователь для тренера
+type CalendarCounterpart struct {
+	ID        null.Int
+	FirstName null.String
+	LastName  null.String
+	PhotoUrl  null.String
+}
@@ -5,28 +5,69 @@ import (
 	"time"
 )
 
+// Виды вложений чата
+const (
+	AttachmentImage = "image"
+	AttachmentVoice = "voice"
+	AttachmentFile  = "file"
+)
+
+// Статусы предложения услуги. OfferExpired не хранится, а вычисляется по offer_expires_at
+const (
+	OfferPending  = "pending"
+	OfferAccepted = "accepted"
+	OfferDeclined = "declined"
+	OfferExpired  = "expired"
+)
+
 type MessageGet struct {
+	// Генерируется клиентом, повторная отправка с тем же client_id не создает новое сообщение
+	ClientID  *string `json:"client_id"`
 	To        int     `json:"to"`
 	Message   *string `json:"message"`
 	ServiceID *int    `json:"service_id"`
+	ReplyToID *int    `json:"reply_to_id"`
+	// id загруженных заранее вложений
+	AttachmentIDs []int `json:"attachment_ids"`
+	// Срок предложения услуги, по умолчанию OFFER_TIME часов. Учитывается только в сообщениях тренера с service_id
+	OfferExpiresAt *time.Time `json:"offer_expires_at"`
 }
 
 type Message struct {
-	ID        int       `json:"id"`
-	UserID    int       `json:"user_id"`
-	TrainerID int       `json:"trainer_id"`
-	Message   *string   `json:"message"`
-	ServiceID *int      `json:"service_id"`
-	IsToUser  bool      `json:"is_to_user"`
-	Time      time.Time `json:"time"`
+	ID          int          `json:"id"`
+	ClientID    *string      `json:"client_id"`
+	UserID      int          `json:"user_id"`
+	TrainerID   int          `json:"trainer_id"`
+	Message     *string      `json:"message"`
+	ServiceID   *int         `json:"service_id"`
+	IsToUser    bool         `json:"is_to_user"`
+	Time        time.Time    `json:"time"`
+	DeliveredAt *time.Time   `json:"delivered_at"`
+	ReadAt      *time.Time   `json:"read_at"`
+	ReplyToID   *int         `json:"reply_to_id"`
+	EditedAt    *time.Time   `json:"edited_at"`
+	DeletedAt   *time.Time   `json:"deleted_at"`
+	Attachments []Attachment `json:"attachments"`
+	// Только у предложений услуги
+	OfferStatus    *string    `json:"offer_status"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at"`
+	ContractID     *int       `json:"contract_id"`
+	// Сообщение о заключенном договоре, создается сервером
+	IsSystem bool `json:"is_system"`
 }
 
 type MessageCreate struct {
+	ClientID  null.String
 	UserID    int
 	TrainerID int
 	Message   null.String
 	ServiceID null.Int
+	ReplyToID null.Int
 	IsToUser  bool
+	// Привязываются к сообщению, должны быть загружены автором и еще не привязаны
+	AttachmentIDs []int
+	// Заполнен у предложений услуги
+	OfferExpiresAt null.Time
 }
 
 type MessagePagination struct {
@@ -41,4 +82,117 @@ type Chat struct {
 	LastName        string
 	LastMessage     string
 	TimeLastMessage time.Time
+	UnreadCount     int
+}
+
+// MessageFound сообщение, найденное поиском по истории чатов. ChatID - id собеседника
+type MessageFound struct {
+	ID        int
+	UserID    int
+	TrainerID int
+	IsToUser  bool
+	Time      time.Time
+	ChatID    int
+	PhotoUrl  null.String
+	FirstName string
+	LastName  string
+	// Фрагменты текста, экранированного для HTML, совпадения выделены <mark></mark>
+	Highlight string
+}
+
+type MessageFoundPagination struct {
+	Messages []MessageFound
+	Cursor   int
+}
+
+// BlockedAccount собеседник в черном списке аккаунта
+type BlockedAccount struct {
+	ID        int
+	PhotoUrl  null.String
+	FirstName string
+	LastName  string
+	CreatedAt time.Time
+}
+
+// ReceiptGet отметка о доставке или прочтении сообщения от клиента
+type ReceiptGet struct {
+	ID int `json:"id"`
+}
+
+// Receipt сообщения чата до MessageID включительно, адресованные одной стороне, доставлены или прочитаны в Time
+type Receipt struct {
+	UserID    int       `json:"user_id"`
+	TrainerID int       `json:"trainer_id"`
+	IsToUser  bool      `json:"is_to_user"`
+	MessageID int       `json:"message_id"`
+	Time      time.Time `json:"time"`
+}
+
+// TypingGet от клиента - набирает ли он сообщение собеседнику To
+type TypingGet struct {
+	To       int  `json:"to"`
+	IsTyping bool `json:"is_typing"`
+}
+
+// Typing собеседник набирает сообщение. IsToUser - набирает тренер
+type Typing struct {
+	UserID    int  `json:"user_id"`
+	TrainerID int  `json:"trainer_id"`
+	IsToUser  bool `json:"is_to_user"`
+	IsTyping  bool `json:"is_typing"`
+}
+
+// PresenceGet от клиента - открыт ли у него чат с собеседником To
+type PresenceGet struct {
+	To       int  `json:"to"`
+	IsOnline bool `json:"is_online"`
+}
+
+// PresenceChange собеседник открыл или закрыл чат. IsToUser - событие от тренера
+type PresenceChange struct {
+	UserID    int  `json:"user_id"`
+	TrainerID int  `json:"trainer_id"`
+	IsToUser  bool `json:"is_to_user"`
+	IsOnline  bool `json:"is_online"`
+}
+
+type Presence struct {
+	ID         int
+	IsOnline   bool
+	LastSeenAt null.Time
+}
+
+// MessageEditGet от клиента - новый текст сообщения ID
+type MessageEditGet struct {
+	ID      int    `json:"id"`
+	Message string `json:"message"`
+}
+
+// MessageDeleteGet от клиента - удалить сообщение ID у всех
+// OfferAnswerGet ответ пользователя на предложение услуги ID
+type OfferAnswerGet struct {
+	ID     int  `json:"id"`
+	Accept bool `json:"accept"`
+}
+
+type MessageDeleteGet struct {
+	ID int `json:"id"`
+}
+
+// MessageEdit предыдущая версия текста сообщения
+type MessageEdit struct {
+	Message  null.String
+	EditedAt time.Time
+}
+
+type Attachment struct {
+	ID           int         `json:"id"`
+	MessageID    null.Int    `json:"message_id"`
+	Kind         string      `json:"kind"`
+	FileUrl      string      `json:"file_url"`
+	ThumbnailUrl null.String `json:"thumbnail_url"`
+	Name         string      `json:"name"`
+	ContentType  string      `json:"content_type"`
+	Size         int64       `json:"size"`
+	CreatedAt    time.Time   `json:"created_at"`
 }
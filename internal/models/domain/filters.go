@@ -9,6 +9,33 @@ type FiltersTrainerCovers struct {
 	SpecializationIDs []int
 }
 
+type FiltersTrainerApplications struct {
+	Status string
+	Cursor int
+}
+
+type FiltersMessageReports struct {
+	Status string
+	Cursor int
+}
+
+// FiltersSlots дни с DateStart по DateEnd считаются в часовом поясе Viewer
+type FiltersSlots struct {
+	TrainerID int
+	Viewer    Caller
+	DateStart time.Time
+	DateEnd   time.Time
+	Duration  time.Duration
+}
+
+// FiltersCalendar дни с DateStart по DateEnd считаются в часовом поясе аккаунта
+type FiltersCalendar struct {
+	AccountID   int
+	AccountType string
+	DateStart   time.Time
+	DateEnd     time.Time
+}
+
 type FiltersProgress struct {
 	UserID    int
 	Search    string
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+// Статусы жалоб на сообщения
+const (
+	ReportPending   = "pending"
+	ReportResolved  = "resolved"
+	ReportDismissed = "dismissed"
+)
+
+// MessageReport жалоба на сообщение. Автор жалобы - UserID или TrainerID
+type MessageReport struct {
+	ID         int
+	UserID     null.Int
+	TrainerID  null.Int
+	Reason     string
+	Status     string
+	ReviewedBy null.Int
+	CreatedAt  time.Time
+	ReviewedAt null.Time
+	Message    Message
+}
+
+type MessageReportPagination struct {
+	Reports []MessageReport
+	Cursor  int
+}
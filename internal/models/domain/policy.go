@@ -0,0 +1,13 @@
+package domain
+
+import "gopkg.in/guregu/null.v3"
+
+type Caller struct {
+	ID   int
+	Type string
+}
+
+type Owner struct {
+	UserID    null.Int
+	TrainerID null.Int
+}
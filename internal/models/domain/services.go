@@ -41,9 +41,10 @@ type ServiceTrainerPagination struct {
 	Cursor   int
 }
 
+// ScheduleService занятие по договору. При записи время приходит местным временем вызывающего без зоны,
+// из базы - моментами времени
 type ScheduleService struct {
 	ScheduleID int
-	Date       time.Time
 	TimeStart  time.Time
 	TimeEnd    time.Time
 }
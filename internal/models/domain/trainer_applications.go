@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+// Статусы заявок тренеров
+const (
+	ApplicationPending  = "pending"
+	ApplicationApproved = "approved"
+	ApplicationRejected = "rejected"
+)
+
+type Certificate struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+type TrainerApplicationCreate struct {
+	TrainerBase
+	Email             string
+	RoleIDs           []int
+	SpecializationIDs []int
+	Certificates      []Certificate
+}
+
+type TrainerApplicationCover struct {
+	TrainerBase
+	ID        int
+	Email     string
+	Status    string
+	CreatedAt time.Time
+}
+
+type TrainerApplicationCoverPagination struct {
+	Applications []TrainerApplicationCover
+	Cursor       int
+}
+
+type TrainerApplication struct {
+	TrainerApplicationCover
+	Roles           []Base
+	Specializations []Base
+	Certificates    []Certificate
+	Reason          null.String
+	ReviewedBy      null.Int
+	ReviewedAt      null.Time
+	TrainerID       null.Int
+}
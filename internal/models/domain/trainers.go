@@ -19,10 +19,12 @@ type TrainerCreate struct {
 	Password string
 }
 
+// TrainerUpdate Timezone - часовой пояс IANA, без него остается прежний
 type TrainerUpdate struct {
 	TrainerBase
-	ID    int
-	Email string
+	ID       int
+	Email    string
+	Timezone null.String
 }
 
 type TrainerCover struct {
@@ -43,6 +45,7 @@ type Trainer struct {
 	Services     []Service
 	Achievements []BaseStatus
 	Email        string
+	Timezone     string
 }
 
 type ServiceBase struct {
@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
 
 type ExerciseCreateBase struct {
 	Name             string   `json:"name"`
@@ -98,7 +101,6 @@ type UserTraining struct {
 	Training
 	Exercises  []Exercise
 	TrainingID int
-	Date       time.Time
 	TimeStart  time.Time
 	TimeEnd    time.Time
 }
@@ -109,18 +111,44 @@ type ExerciseDetail struct {
 	Reps       int
 	Weight     int
 }
+
+// ScheduleTraining тренировка в расписании. TimeStart и TimeEnd приходят местным временем пользователя без зоны,
+// одна тренировка сохраняется моментами времени в его часовом поясе. С RRule вместо одной записи создается серия
 type ScheduleTraining struct {
 	TrainingID int
 	UserID     int
-	Date       time.Time
 	TimeStart  time.Time
 	TimeEnd    time.Time
 	Exercises  []ExerciseDetail
+	RRule      null.String
 }
 
-type TrainingSchedule struct {
-	Date        time.Time
-	TrainingIDs []int
+// TrainingSeries повторяющаяся тренировка. Дни и время серии - местные в часовом поясе Timezone.
+// DateEnd - день последнего вхождения, у бесконечной серии не задан.
+// Cancelled - отмененные вхождения, Overridden - исходные дни вхождений, сохраненных отдельными записями
+type TrainingSeries struct {
+	ID         int
+	UserID     int
+	TrainingID int
+	Title      string
+	DateStart  time.Time
+	DateEnd    null.Time
+	TimeStart  time.Time
+	TimeEnd    time.Time
+	RRule      string
+	Timezone   string
+	Cancelled  []time.Time
+	Overridden []time.Time
+}
+
+// TrainingOccurrence изменение одного вхождения серии, которое было запланировано на OccurrenceDate.
+// Новое время приходит местным временем пользователя без зоны, как и у ScheduleTraining
+type TrainingOccurrence struct {
+	SeriesID       int
+	UserID         int
+	OccurrenceDate time.Time
+	TimeStart      time.Time
+	TimeEnd        time.Time
 }
 
 type PlanCreate struct {
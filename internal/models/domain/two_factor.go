@@ -0,0 +1,8 @@
+package domain
+
+type TwoFactor struct {
+	ID           int
+	Secret       string
+	IsEnabled    bool
+	LastUsedStep int64
+}
@@ -15,10 +15,12 @@ type UserCreate struct {
 	Password string
 }
 
+// UserUpdate Timezone - часовой пояс IANA, без него остается прежний
 type UserUpdate struct {
 	UserBase
-	ID    int
-	Email string
+	ID       int
+	Email    string
+	Timezone null.String
 }
 
 type UserCover struct {
@@ -34,5 +36,6 @@ type UserCoverPagination struct {
 
 type User struct {
 	UserCover
-	Email string
+	Email    string
+	Timezone string
 }
@@ -0,0 +1,18 @@
+package dto
+
+import "time"
+
+type DeletionRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+type DeletionSchedule struct {
+	DeleteAt time.Time `json:"delete_at"`
+}
+
+type DataExport struct {
+	ID        int        `json:"id"`
+	Status    string     `json:"status"`
+	CreatedAt time.Time  `json:"created_at"`
+	ReadyAt   *time.Time `json:"ready_at"`
+}
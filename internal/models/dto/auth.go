@@ -1,6 +1,60 @@
 package dto
 
+import "time"
+
 type Auth struct {
 	Email    string `json:"email" validate:"required,email"`
 	Password string `json:"password" validate:"required,min=8,max=64,password"`
 }
+
+type Session struct {
+	ID         string    `json:"id"`
+	Device     string    `json:"device"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	IsCurrent  bool      `json:"is_current"`
+}
+
+type Token struct {
+	Token string `json:"token" validate:"required"`
+}
+
+type PasswordForgot struct {
+	Email    string `json:"email" validate:"required,email"`
+	UserType string `json:"user_type" validate:"required,oneof=user trainer"`
+}
+
+type PasswordReset struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password" validate:"required,min=8,max=64,password"`
+}
+
+type Admin struct {
+	ID    int      `json:"id"`
+	Email string   `json:"email"`
+	Roles []string `json:"roles"`
+}
+
+type TwoFactorSetup struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type TwoFactorCode struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type TwoFactorLogin struct {
+	ChallengeToken string `json:"challenge_token" validate:"required"`
+	Code           string `json:"code" validate:"required"`
+}
+
+type TwoFactorChallenge struct {
+	ChallengeToken string `json:"challenge_token"`
+	ExpiresIn      int    `json:"expires_in"`
+}
+
+type RecoveryCodes struct {
+	Codes []string `json:"recovery_codes"`
+}
@@ -0,0 +1,41 @@
+package dto
+
+import "time"
+
+type AvailabilityInterval struct {
+	Weekday   int       `json:"weekday" validate:"min=1,max=7"`
+	TimeStart time.Time `json:"time_start" validate:"required"`
+	TimeEnd   time.Time `json:"time_end" validate:"required"`
+}
+
+type AvailabilityUpdate struct {
+	Weekly []AvailabilityInterval `json:"weekly" validate:"max=50,dive"`
+}
+
+type AvailabilityExceptionCreate struct {
+	DateStart time.Time `json:"date_start" validate:"required"`
+	DateEnd   time.Time `json:"date_end" validate:"required"`
+	Reason    *string   `json:"reason" validate:"omitempty,max=255"`
+}
+
+type AvailabilityException struct {
+	ID        int       `json:"id"`
+	DateStart time.Time `json:"date_start"`
+	DateEnd   time.Time `json:"date_end"`
+	Reason    *string   `json:"reason"`
+}
+
+type Availability struct {
+	Weekly     []AvailabilityInterval  `json:"weekly"`
+	Exceptions []AvailabilityException `json:"exceptions"`
+	Busy       []Slot                  `json:"busy"`
+}
+
+type BusyImport struct {
+	Imported int `json:"imported"`
+}
+
+type Slot struct {
+	TimeStart time.Time `json:"time_start"`
+	TimeEnd   time.Time `json:"time_end"`
+}
@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+type CalendarEvent struct {
+	ID             int                  `json:"id"`
+	Type           string               `json:"type"`
+	Title          string               `json:"title"`
+	Date           time.Time            `json:"date"`
+	TimeStart      time.Time            `json:"time_start"`
+	TimeEnd        time.Time            `json:"time_end"`
+	TrainingID     *int                 `json:"training_id"`
+	ContractID     *int                 `json:"contract_id"`
+	SeriesID       *int                 `json:"series_id"`
+	OccurrenceDate *time.Time           `json:"occurrence_date"`
+	Counterpart    *CalendarCounterpart `json:"counterpart"`
+}
+
+type CalendarCounterpart struct {
+	ID        int     `json:"id"`
+	FirstName string  `json:"first_name"`
+	LastName  string  `json:"last_name"`
+	PhotoUrl  *string `json:"photo_url"`
+}
+
+type CalendarFeed struct {
+	URL string `json:"url"`
+}
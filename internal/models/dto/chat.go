@@ -6,13 +6,25 @@ import (
 )
 
 type Message struct {
-	ID        int       `json:"id"`
-	UserID    int       `json:"user_id"`
-	TrainerID int       `json:"trainer_id"`
-	Message   *string   `json:"message"`
-	Service   *int      `json:"service_id"`
-	IsToUser  bool      `json:"is_to_user"`
-	Time      time.Time `json:"time"`
+	ID          int          `json:"id"`
+	ClientID    *string      `json:"client_id"`
+	UserID      int          `json:"user_id"`
+	TrainerID   int          `json:"trainer_id"`
+	Message     *string      `json:"message"`
+	Service     *int         `json:"service_id"`
+	IsToUser    bool         `json:"is_to_user"`
+	Time        time.Time    `json:"time"`
+	DeliveredAt *time.Time   `json:"delivered_at"`
+	ReadAt      *time.Time   `json:"read_at"`
+	ReplyToID   *int         `json:"reply_to_id"`
+	EditedAt    *time.Time   `json:"edited_at"`
+	DeletedAt   *time.Time   `json:"deleted_at"`
+	Attachments []Attachment `json:"attachments"`
+	// pending, accepted, declined или expired - только у предложений услуги
+	OfferStatus    *string    `json:"offer_status"`
+	OfferExpiresAt *time.Time `json:"offer_expires_at"`
+	ContractID     *int       `json:"contract_id"`
+	IsSystem       bool       `json:"is_system"`
 }
 
 type MessageCreate struct {
@@ -35,4 +47,74 @@ type Chat struct {
 	LastName        string    `json:"last_name"`
 	LastMessage     string    `json:"last_message"`
 	TimeLastMessage time.Time `json:"time_last_message"`
+	UnreadCount     int       `json:"unread_count"`
+}
+
+type MessageSearchQuery struct {
+	Query  string `form:"query" validate:"required,max=256"`
+	To     int    `form:"to" validate:"min=0"`
+	Cursor int    `form:"cursor" validate:"min=0"`
+}
+
+type MessageFound struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	TrainerID int       `json:"trainer_id"`
+	IsToUser  bool      `json:"is_to_user"`
+	Time      time.Time `json:"time"`
+	// Собеседник: тренер для пользователя, пользователь для тренера
+	ChatID    int     `json:"chat_id"`
+	PhotoUrl  *string `json:"photo_url"`
+	FirstName string  `json:"first_name"`
+	LastName  string  `json:"last_name"`
+	Highlight string  `json:"highlight"`
+	// Курсор GetChatMessage, с которого начинается страница с этим сообщением
+	Cursor int `json:"cursor"`
+}
+
+type MessageFoundPagination struct {
+	Messages []MessageFound `json:"objects"`
+	Cursor   int            `json:"cursor"`
+}
+
+type BlockedAccount struct {
+	ID        int       `json:"id"`
+	PhotoUrl  *string   `json:"photo_url"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type MessageReportCreate struct {
+	Reason string `json:"reason" validate:"required,max=1000"`
+}
+
+type PresenceQuery struct {
+	IDs []int `form:"ids" validate:"required,min=1,max=100"`
+}
+
+type Presence struct {
+	ID         int        `json:"id"`
+	IsOnline   bool       `json:"is_online"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+}
+
+type MessageUpdate struct {
+	Message string `json:"message" validate:"required,max=4096"`
+}
+
+type MessageEdit struct {
+	Message  *string   `json:"message"`
+	EditedAt time.Time `json:"edited_at"`
+}
+
+type Attachment struct {
+	ID           int       `json:"id"`
+	Kind         string    `json:"kind"`
+	FileUrl      string    `json:"file_url"`
+	ThumbnailUrl *string   `json:"thumbnail_url"`
+	Name         string    `json:"name"`
+	ContentType  string    `json:"content_type"`
+	Size         int64     `json:"size"`
+	CreatedAt    time.Time `json:"created_at"`
 }
@@ -9,6 +9,27 @@ type FiltersTrainerCovers struct {
 	SpecializationIDs []int  `form:"specialization_ids"`
 }
 
+type FiltersTrainerApplications struct {
+	Status string `form:"status" validate:"omitempty,oneof=pending approved rejected"`
+	Cursor int    `form:"cursor"`
+}
+
+type FiltersMessageReports struct {
+	Status string `form:"status" validate:"omitempty,oneof=pending resolved dismissed"`
+	Cursor int    `form:"cursor"`
+}
+
+type FiltersSlots struct {
+	DateStart time.Time `form:"date_start" time_format:"2006-01-02" validate:"required"`
+	DateEnd   time.Time `form:"date_end" time_format:"2006-01-02" validate:"required"`
+	Duration  int       `form:"duration" validate:"omitempty,min=15,max=480"`
+}
+
+type FiltersCalendar struct {
+	DateStart time.Time `form:"date_start" time_format:"2006-01-02" validate:"required"`
+	DateEnd   time.Time `form:"date_end" time_format:"2006-01-02" validate:"required"`
+}
+
 type FiltersProgress struct {
 	Search    string    `form:"search"`
 	DateStart time.Time `form:"date_start"`
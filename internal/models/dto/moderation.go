@@ -0,0 +1,29 @@
+package dto
+
+import "time"
+
+type MessageReport struct {
+	ID         int        `json:"id"`
+	UserID     *int       `json:"user_id"`
+	TrainerID  *int       `json:"trainer_id"`
+	Reason     string     `json:"reason"`
+	Status     string     `json:"status"`
+	ReviewedBy *int       `json:"reviewed_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReviewedAt *time.Time `json:"reviewed_at"`
+	Message    Message    `json:"message"`
+}
+
+type MessageReportPagination struct {
+	Reports []MessageReport `json:"objects"`
+	Cursor  int             `json:"cursor"`
+}
+
+type ReportReview struct {
+	Status string `json:"status" validate:"required,oneof=resolved dismissed"`
+}
+
+type Suspension struct {
+	Until  *time.Time `json:"until"`
+	Reason string     `json:"reason" validate:"required,min=2,max=500"`
+}
@@ -0,0 +1,44 @@
+package dto
+
+import "time"
+
+type TrainerApplicationCreate struct {
+	TrainerBase
+	Email             string `json:"email" validate:"required,email"`
+	RoleIDs           []int  `json:"role_ids" validate:"max=10"`
+	SpecializationIDs []int  `json:"specialization_ids" validate:"max=10"`
+}
+
+type Certificate struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Url  string `json:"url"`
+}
+
+type TrainerApplicationCover struct {
+	TrainerBase
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TrainerApplicationCoverPagination struct {
+	Applications []TrainerApplicationCover `json:"objects"`
+	Cursor       int                       `json:"cursor"`
+}
+
+type TrainerApplication struct {
+	TrainerApplicationCover
+	Roles           []Base        `json:"roles"`
+	Specializations []Base        `json:"specializations"`
+	Certificates    []Certificate `json:"certificates"`
+	Reason          *string       `json:"reason"`
+	ReviewedBy      *int          `json:"reviewed_by"`
+	ReviewedAt      *time.Time    `json:"reviewed_at"`
+	TrainerID       *int          `json:"trainer_id"`
+}
+
+type ApplicationReject struct {
+	Reason string `json:"reason" validate:"required,min=2,max=500"`
+}
@@ -16,7 +16,8 @@ type TrainerCreate struct {
 
 type TrainerUpdate struct {
 	TrainerBase
-	Email string `json:"email" validate:"required,email"`
+	Email    string  `json:"email" validate:"required,email"`
+	Timezone *string `json:"timezone" validate:"omitempty,timezone"`
 }
 
 type TrainerCover struct {
@@ -37,6 +38,7 @@ type Trainer struct {
 	Services     []Service    `json:"services"`
 	Achievements []BaseStatus `json:"achievements"`
 	Email        string       `json:"email"`
+	Timezone     string       `json:"timezone"`
 }
 
 type ServiceBase struct {
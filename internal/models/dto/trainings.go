@@ -113,11 +113,20 @@ type ScheduleTraining struct {
 	TimeStart  time.Time        `json:"time_start"`
 	TimeEnd    time.Time        `json:"time_end"`
 	Exercises  []ExerciseDetail `json:"exercises"`
+	RRule      *string          `json:"rrule"`
 }
 
-type TrainingSchedule struct {
-	Date        time.Time `json:"date"`
-	TrainingIDs []int     `json:"user_training_ids"`
+type TrainingSeriesUpdate struct {
+	Date      time.Time `json:"date"`
+	TimeStart time.Time `json:"time_start"`
+	TimeEnd   time.Time `json:"time_end"`
+	RRule     string    `json:"rrule"`
+}
+
+type TrainingOccurrenceUpdate struct {
+	Date      time.Time `json:"date"`
+	TimeStart time.Time `json:"time_start"`
+	TimeEnd   time.Time `json:"time_end"`
 }
 
 type ExerciseStatusUpdate struct {
@@ -14,7 +14,8 @@ type UserCreate struct {
 
 type UserUpdate struct {
 	UserBase
-	Email string `json:"email" validate:"required,email"`
+	Email    string  `json:"email" validate:"required,email"`
+	Timezone *string `json:"timezone" validate:"omitempty,timezone"`
 }
 
 type UserCover struct {
@@ -30,5 +31,6 @@ type UserCoverPagination struct {
 
 type User struct {
 	UserCover
-	Email string `json:"email"`
+	Email    string `json:"email"`
+	Timezone string `json:"timezone"`
 }
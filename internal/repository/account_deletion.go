@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/pkg/customerr"
+	"BACKEND/pkg/utils"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+// Таблицы аккаунтов и колонки ссылок на них по типу аккаунта
+var (
+	accountTable = map[string]string{
+		utils.User:    "users",
+		utils.Trainer: "trainers",
+	}
+	accountColumn = map[string]string{
+		utils.User:    "user_id",
+		utils.Trainer: "trainer_id",
+	}
+	// Значение is_to_user у сообщений, которые написал аккаунт
+	accountAuthoredMessages = map[string]bool{
+		utils.User:    false,
+		utils.Trainer: true,
+	}
+)
+
+type accountDeletionRepo struct {
+	db *sqlx.DB
+}
+
+func InitAccountDeletionRepo(
+	db *sqlx.DB,
+) AccountDeletion {
+	return &accountDeletionRepo{
+		db: db,
+	}
+}
+
+func (a accountDeletionRepo) Schedule(ctx context.Context, accountType string, accountID int, deleteAt time.Time) error {
+	table, ok := accountTable[accountType]
+	if !ok {
+		return errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET delete_at = $1 WHERE id = $2`, table)
+
+	count, err := a.update(ctx, query, deleteAt, accountID)
+	if err != nil {
+		return err
+	}
+
+	if count != 1 {
+		if accountType == utils.Trainer {
+			return errs.ErrNoTrainer
+		}
+		return errs.ErrNoUser
+	}
+
+	return nil
+}
+
+func (a accountDeletionRepo) Cancel(ctx context.Context, accountType string, accountID int) error {
+	table, ok := accountTable[accountType]
+	if !ok {
+		return errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET delete_at = NULL WHERE id = $1 AND delete_at IS NOT NULL`, table)
+
+	count, err := a.update(ctx, query, accountID)
+	if err != nil {
+		return err
+	}
+
+	if count != 1 {
+		return errs.ErrNoDeletion
+	}
+
+	return nil
+}
+
+func (a accountDeletionRepo) GetDue(ctx context.Context, accountType string) ([]int, error) {
+	table, ok := accountTable[accountType]
+	if !ok {
+		return nil, errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`SELECT id FROM %s WHERE delete_at <= NOW() ORDER BY delete_at`, table)
+
+	var accountIDs []int
+	if err := a.db.SelectContext(ctx, &accountIDs, query); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+
+	return accountIDs, nil
+}
+
+// Delete обезличивает переписку и удаляет аккаунт, остальные данные удаляются каскадно.
+// Возвращает пути файлов аккаунта, которые нужно удалить с диска
+func (a accountDeletionRepo) Delete(ctx context.Context, accountType string, accountID int) ([]string, error) {
+	table, ok := accountTable[accountType]
+	if !ok {
+		return nil, errs.ErrForbidden
+	}
+
+	tx, err := a.db.Beginx()
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	// Текст сообщений удаленного аккаунта стирается, у собеседника остаются только его собственные сообщения
+	anonymiseQuery := fmt.Sprintf(`UPDATE messages SET message = NULL, service_id = NULL WHERE %s = $1 AND is_to_user = $2`,
+		accountColumn[accountType])
+
+	if _, err = tx.ExecContext(ctx, anonymiseQuery, accountID, accountAuthoredMessages[accountType]); err != nil {
+		tx.Rollback()
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	// Прежние версии текста стираются вместе с текстом, иначе собеседник прочитает их в истории правок
+	editsQuery := fmt.Sprintf(`DELETE FROM message_edits e USING messages m
+		WHERE e.message_id = m.id AND m.%s = $1 AND m.is_to_user = $2`, accountColumn[accountType])
+
+	if _, err = tx.ExecContext(ctx, editsQuery, accountID, accountAuthoredMessages[accountType]); err != nil {
+		tx.Rollback()
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	var files []string
+
+	// Вложения аккаунта удаляются вместе с текстом его сообщений
+	attachmentsQuery := fmt.Sprintf(`DELETE FROM attachments WHERE %s = $1
+		RETURNING file_url, thumbnail_url`, accountColumn[accountType])
+
+	var attachments []struct {
+		FileUrl      string      `db:"file_url"`
+		ThumbnailUrl null.String `db:"thumbnail_url"`
+	}
+	if err = tx.SelectContext(ctx, &attachments, attachmentsQuery, accountID); err != nil {
+		tx.Rollback()
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+
+	for _, attachment := range attachments {
+		files = append(files, attachment.FileUrl)
+		if attachment.ThumbnailUrl.Valid {
+			files = append(files, attachment.ThumbnailUrl.String)
+		}
+	}
+
+	// Заявка тренера хранит его почту и сертификаты, поэтому удаляется вместе с аккаунтом
+	if accountType == utils.Trainer {
+		certificatesQuery := `SELECT c.url FROM trainer_applications_certificates c
+			JOIN trainer_applications ta ON c.application_id = ta.id WHERE ta.trainer_id = $1`
+
+		if err = tx.SelectContext(ctx, &files, certificatesQuery, accountID); err != nil {
+			tx.Rollback()
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+		}
+
+		if _, err = tx.ExecContext(ctx, `DELETE FROM trainer_applications WHERE trainer_id = $1`, accountID); err != nil {
+			tx.Rollback()
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+		}
+	}
+
+	// Условие на delete_at не дает удалить аккаунт, если удаление успели отменить
+	deleteQuery := fmt.Sprintf(`DELETE FROM %s WHERE id = $1 AND delete_at <= NOW() RETURNING photo_url`, table)
+
+	var photoUrl null.String
+	if err = tx.QueryRowContext(ctx, deleteQuery, accountID).Scan(&photoUrl); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errs.ErrNoDeletion
+		}
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
+	if photoUrl.Valid {
+		files = append(files, photoUrl.String)
+	}
+
+	return files, nil
+}
+
+func (a accountDeletionRepo) update(ctx context.Context, query string, args ...any) (int64, error) {
+	res, err := a.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	return count, nil
+}
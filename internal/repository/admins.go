@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/pkg/customerr"
+	"BACKEND/pkg/utils"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type adminRepo struct {
+	db *sqlx.DB
+}
+
+func InitAdminRepo(
+	db *sqlx.DB,
+) Admins {
+	return &adminRepo{
+		db: db,
+	}
+}
+
+// Save создает администратора или, если почта уже занята, обновляет его пароль и роли
+func (a adminRepo) Save(ctx context.Context, admin domain.AdminCreate) (int, error) {
+	var id int
+
+	hashedPassword := utils.HashPassword(admin.Password)
+
+	query := `INSERT INTO admins (email, password, roles) VALUES ($1, $2, $3)
+		ON CONFLICT (email) DO UPDATE SET password = excluded.password, roles = excluded.roles
+		RETURNING id`
+
+	err := a.db.QueryRowContext(ctx, query, admin.Email, hashedPassword, pq.Array(admin.Roles)).Scan(&id)
+	if err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return id, nil
+}
+
+func (a adminRepo) GetByID(ctx context.Context, adminID int) (domain.Admin, error) {
+	admin := domain.Admin{ID: adminID}
+
+	query := `SELECT email, roles FROM admins WHERE id = $1`
+
+	err := a.db.QueryRowContext(ctx, query, adminID).Scan(&admin.Email, pq.Array(&admin.Roles))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Admin{}, errs.ErrNoAdmin
+		}
+		return domain.Admin{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return admin, nil
+}
+
+func (a adminRepo) GetSecure(ctx context.Context, email string) (domain.AdminSecure, error) {
+	secure := domain.AdminSecure{Secure: domain.Secure{IsVerified: true}}
+
+	query := `SELECT id, password, roles FROM admins WHERE email = $1`
+
+	err := a.db.QueryRowContext(ctx, query, email).Scan(&secure.ID, &secure.Password, pq.Array(&secure.Roles))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.AdminSecure{}, errs.InvalidEmail
+		}
+		return domain.AdminSecure{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return secure, nil
+}
@@ -0,0 +1,288 @@
+package repository
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/pkg/customerr"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+type availabilityRepo struct {
+	db *sqlx.DB
+}
+
+func InitAvailabilityRepo(db *sqlx.DB) Availability {
+	return &availabilityRepo{
+		db: db,
+	}
+}
+
+// GetAvailability возвращает недельный шаблон тренера, исключения и занятое время, пересекающие дни с dateStart по dateEnd.
+// Без dateEnd возвращаются все, которые заканчиваются не раньше dateStart. Дни считаются в часовом поясе тренера
+func (a availabilityRepo) GetAvailability(ctx context.Context, trainerID int, dateStart time.Time, dateEnd null.Time) (domain.Availability, error) {
+	var availability domain.Availability
+
+	weeklyQuery := `SELECT weekday, time_start, time_end FROM trainer_availability WHERE trainer_id = $1 ORDER BY weekday, time_start`
+
+	rows, err := a.db.QueryContext(ctx, weeklyQuery, trainerID)
+	if err != nil {
+		return domain.Availability{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var interval domain.AvailabilityInterval
+
+		if err = rows.Scan(&interval.Weekday, &interval.TimeStart, &interval.TimeEnd); err != nil {
+			return domain.Availability{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		availability.Weekly = append(availability.Weekly, interval)
+	}
+
+	if err = rows.Err(); err != nil {
+		return domain.Availability{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	exceptionsQuery := `
+		SELECT id, trainer_id, date_start, date_end, reason
+		FROM trainer_availability_exceptions
+		WHERE trainer_id = $1 AND date_end >= $2::date AND ($3::date IS NULL OR date_start <= $3::date)
+		ORDER BY date_start`
+
+	exceptionRows, err := a.db.QueryContext(ctx, exceptionsQuery, trainerID, dateStart, dateEnd)
+	if err != nil {
+		return domain.Availability{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer exceptionRows.Close()
+
+	for exceptionRows.Next() {
+		var exception domain.AvailabilityException
+
+		err = exceptionRows.Scan(&exception.ID, &exception.TrainerID, &exception.DateStart, &exception.DateEnd, &exception.Reason)
+		if err != nil {
+			return domain.Availability{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		availability.Exceptions = append(availability.Exceptions, exception)
+	}
+
+	if err = exceptionRows.Err(); err != nil {
+		return domain.Availability{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	busyQuery := `
+		SELECT b.time_start, b.time_end
+		FROM trainer_busy b
+			JOIN trainers t ON t.id = b.trainer_id
+		WHERE b.trainer_id = $1 AND b.time_end > $2::date::timestamp AT TIME ZONE t.timezone
+		  AND ($3::date IS NULL OR b.time_start < ($3::date + 1)::timestamp AT TIME ZONE t.timezone)
+		ORDER BY b.time_start`
+
+	busyRows, err := a.db.QueryContext(ctx, busyQuery, trainerID, dateStart, dateEnd)
+	if err != nil {
+		return domain.Availability{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer busyRows.Close()
+
+	for busyRows.Next() {
+		var busy domain.Slot
+
+		if err = busyRows.Scan(&busy.TimeStart, &busy.TimeEnd); err != nil {
+			return domain.Availability{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		availability.Busy = append(availability.Busy, busy)
+	}
+
+	if err = busyRows.Err(); err != nil {
+		return domain.Availability{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	return availability, nil
+}
+
+// ReplaceBusy заменяет импортированное занятое время тренера, которое заканчивается после from.
+// Прошедшие промежутки не трогаются
+func (a availabilityRepo) ReplaceBusy(ctx context.Context, trainerID int, from time.Time, busy []domain.Slot) error {
+	tx, err := a.db.Beginx()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	// Как и при замене шаблона, блокировка тренера не дает записи проверить время, пока оно заменяется
+	var lockedID int
+
+	lockQuery := `SELECT id FROM trainers WHERE id = $1 FOR UPDATE`
+
+	if err = tx.QueryRowContext(ctx, lockQuery, trainerID).Scan(&lockedID); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.ErrNoTrainer
+		}
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	deleteQuery := `DELETE FROM trainer_busy WHERE trainer_id = $1 AND time_end > $2`
+
+	if _, err = tx.ExecContext(ctx, deleteQuery, trainerID, from); err != nil {
+		tx.Rollback()
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	if len(busy) > 0 {
+		// Массивы времени передаются строками с зоной
+		starts := make([]string, len(busy))
+		ends := make([]string, len(busy))
+		for i, slot := range busy {
+			starts[i], ends[i] = slot.TimeStart.Format(time.RFC3339), slot.TimeEnd.Format(time.RFC3339)
+		}
+
+		insertQuery := `INSERT INTO trainer_busy (trainer_id, time_start, time_end)
+			SELECT $1, UNNEST($2::timestamptz[]), UNNEST($3::timestamptz[])`
+
+		if _, err = tx.ExecContext(ctx, insertQuery, trainerID, pq.Array(starts), pq.Array(ends)); err != nil {
+			tx.Rollback()
+			return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
+	return nil
+}
+
+// UpdateAvailability заменяет недельный шаблон тренера целиком
+func (a availabilityRepo) UpdateAvailability(ctx context.Context, trainerID int, weekly []domain.AvailabilityInterval) error {
+	tx, err := a.db.Beginx()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	// Блокировка тренера не дает записи проверить шаблон, пока он заменяется
+	var lockedID int
+
+	lockQuery := `SELECT id FROM trainers WHERE id = $1 FOR UPDATE`
+
+	if err = tx.QueryRowContext(ctx, lockQuery, trainerID).Scan(&lockedID); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return errs.ErrNoTrainer
+		}
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	deleteQuery := `DELETE FROM trainer_availability WHERE trainer_id = $1`
+
+	if _, err = tx.ExecContext(ctx, deleteQuery, trainerID); err != nil {
+		tx.Rollback()
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	if len(weekly) > 0 {
+		// Время передается строкой: от time.Time нужны только часы, минуты и секунды
+		weekdays := make([]int, len(weekly))
+		starts := make([]string, len(weekly))
+		ends := make([]string, len(weekly))
+		for i, interval := range weekly {
+			weekdays[i] = interval.Weekday
+			starts[i] = interval.TimeStart.Format(time.TimeOnly)
+			ends[i] = interval.TimeEnd.Format(time.TimeOnly)
+		}
+
+		insertQuery := `INSERT INTO trainer_availability (trainer_id, weekday, time_start, time_end)
+			SELECT $1, UNNEST($2::smallint[]), UNNEST($3::time[]), UNNEST($4::time[])`
+
+		if _, err = tx.ExecContext(ctx, insertQuery, trainerID, pq.Array(weekdays), pq.Array(starts), pq.Array(ends)); err != nil {
+			tx.Rollback()
+			return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
+	return nil
+}
+
+func (a availabilityRepo) CreateException(ctx context.Context, exception domain.AvailabilityException) (int, error) {
+	var createdID int
+
+	createQuery := `INSERT INTO trainer_availability_exceptions (trainer_id, date_start, date_end, reason) VALUES ($1, $2, $3, $4) RETURNING id`
+
+	err := a.db.QueryRowContext(ctx, createQuery, exception.TrainerID, exception.DateStart, exception.DateEnd, exception.Reason).Scan(&createdID)
+	if err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return createdID, nil
+}
+
+func (a availabilityRepo) DeleteException(ctx context.Context, trainerID, exceptionID int) error {
+	query := `DELETE FROM trainer_availability_exceptions WHERE id = $1 AND trainer_id = $2`
+
+	res, err := a.db.ExecContext(ctx, query, exceptionID, trainerID)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		return errs.ErrNoException
+	}
+
+	return nil
+}
+
+// GetBusy возвращает занятия тренера и импортированное занятое время, пересекающие промежуток с start по end, в порядке начала
+func (a availabilityRepo) GetBusy(ctx context.Context, trainerID int, start, end time.Time) ([]domain.Slot, error) {
+	query := `
+		SELECT time_start, time_end
+		FROM users_trainers_services_schedule
+		WHERE trainer_id = $1 AND period && TSTZRANGE($2::timestamptz, $3::timestamptz)
+		UNION ALL
+		SELECT time_start, time_end
+		FROM trainer_busy
+		WHERE trainer_id = $1 AND time_start < $3::timestamptz AND time_end > $2::timestamptz
+		ORDER BY 1`
+
+	rows, err := a.db.QueryContext(ctx, query, trainerID, start, end)
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	var busy []domain.Slot
+	for rows.Next() {
+		var slot domain.Slot
+
+		if err = rows.Scan(&slot.TimeStart, &slot.TimeEnd); err != nil {
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		busy = append(busy, slot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	return busy, nil
+}
+
+func (a availabilityRepo) GetTimezone(ctx context.Context, accountType string, accountID int) (string, error) {
+	return getTimezone(ctx, a.db, accountType, accountID)
+}
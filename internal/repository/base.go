@@ -119,7 +119,7 @@ func (c baseRepo) Delete(ctx context.Context, baseIDs []int) error {
 				customerr.ErrorPair{Message: customerr.RollbackErr, Err: rbErr},
 			)
 		}
-		return fmt.Errorf("Один из переданных объектов не существует")
+		return errs.ErrNoObjects
 	}
 
 	if err = tx.Commit(); err != nil {
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/pkg/customerr"
+	"BACKEND/pkg/utils"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+type calendarRepo struct {
+	db *sqlx.DB
+}
+
+func InitCalendarRepo(db *sqlx.DB) Calendar {
+	return &calendarRepo{
+		db: db,
+	}
+}
+
+// GetEvents возвращает события аккаунта, которые начинаются с start до end, в порядке начала.
+// У пользователя это его тренировки и занятия с тренерами, у тренера - занятия с клиентами.
+// Несохраненные вхождения серий тренировок сюда не входят, они разворачиваются из GetSeries
+func (c calendarRepo) GetEvents(ctx context.Context, accountID int, accountType string, start, end time.Time) ([]domain.CalendarEvent, error) {
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return nil, errs.ErrForbidden
+	}
+	counterpartType := chatCounterpart[accountType]
+
+	query := fmt.Sprintf(`
+		SELECT tuts.id, '%s', COALESCE(s.name, ''), tuts.time_start, tuts.time_end, NULL::int, uts.id,
+		       NULL::int, NULL::date, a.id, a.first_name, a.last_name, a.photo_url
+		FROM users_trainers_services_schedule tuts
+			JOIN users_trainers_services uts ON uts.id = tuts.users_trainers_services_id
+			LEFT JOIN services s ON s.id = uts.service_id
+			JOIN %s a ON a.id = uts.%s
+		WHERE uts.%s = $1 AND tuts.time_start >= $2 AND tuts.time_start < $3`,
+		domain.EventSession, accountTable[counterpartType], accountColumn[counterpartType], accountCol)
+
+	if accountType == utils.User {
+		query += fmt.Sprintf(`
+		UNION ALL
+		SELECT ut.id, '%s', t.name, ut.time_start, ut.time_end, ut.training_id, NULL::int,
+		       ut.series_id, ut.occurrence_date, NULL::int, NULL, NULL, NULL
+		FROM users_trainings ut
+			JOIN trainings t ON t.id = ut.training_id
+		WHERE ut.user_id = $1 AND ut.time_start >= $2 AND ut.time_start < $3`, domain.EventTraining)
+	}
+
+	query += `
+		ORDER BY 4, 1`
+
+	rows, err := c.db.QueryContext(ctx, query, accountID, start, end)
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	events := make([]domain.CalendarEvent, 0)
+	for rows.Next() {
+		var event domain.CalendarEvent
+
+		err = rows.Scan(&event.ID, &event.Type, &event.Title, &event.TimeStart, &event.TimeEnd, &event.TrainingID,
+			&event.ContractID, &event.SeriesID, &event.OccurrenceDate, &event.Counterpart.ID, &event.Counterpart.FirstName, &event.Counterpart.LastName, &event.Counterpart.PhotoUrl)
+		if err != nil {
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	return events, nil
+}
+
+// GetSeries возвращает серии тренировок пользователя, у которых могут быть вхождения с dateStart по dateEnd.
+// Дни сравниваются с местными днями серий без учета часовых поясов
+func (c calendarRepo) GetSeries(ctx context.Context, userID int, dateStart, dateEnd time.Time) ([]domain.TrainingSeries, error) {
+	query := `
+		SELECT ` + seriesColumns + `
+		FROM users_trainings_series s
+			JOIN trainings t ON t.id = s.training_id
+		WHERE s.user_id = $1 AND s.date_start <= $3::date AND (s.date_end IS NULL OR s.date_end >= $2::date)
+		ORDER BY s.id`
+
+	rows, err := c.db.QueryContext(ctx, query, userID, dateStart.Format(time.DateOnly), dateEnd.Format(time.DateOnly))
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	series := make([]domain.TrainingSeries, 0)
+	for rows.Next() {
+		item, err := scanSeries(rows)
+		if err != nil {
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		series = append(series, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	return series, nil
+}
+
+// GetTraining возвращает тренировку пользователя из расписания как событие календаря
+func (c calendarRepo) GetTraining(ctx context.Context, userTrainingID int) (domain.CalendarEvent, error) {
+	event := domain.CalendarEvent{Type: domain.EventTraining}
+
+	query := `
+		SELECT ut.id, t.name, ut.time_start, ut.time_end, ut.training_id, ut.series_id, ut.occurrence_date
+		FROM users_trainings ut
+			JOIN trainings t ON t.id = ut.training_id
+		WHERE ut.id = $1`
+
+	err := c.db.QueryRowContext(ctx, query, userTrainingID).Scan(&event.ID, &event.Title, &event.TimeStart, &event.TimeEnd,
+		&event.TrainingID, &event.SeriesID, &event.OccurrenceDate)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.CalendarEvent{}, errs.ErrNoSchedule
+		}
+		return domain.CalendarEvent{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return event, nil
+}
+
+// SetFeed сохраняет хеш токена подписки аккаунта, заменяя прежний. Старая ссылка перестает работать
+func (c calendarRepo) SetFeed(ctx context.Context, accountID int, accountType, tokenHash string) error {
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO calendar_feeds (%[1]s, token_hash)
+		VALUES ($1, $2)
+		ON CONFLICT (%[1]s) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = NOW()`, accountCol)
+
+	if _, err := c.db.ExecContext(ctx, query, accountID, tokenHash); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	return nil
+}
+
+func (c calendarRepo) DeleteFeed(ctx context.Context, accountID int, accountType string) error {
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`DELETE FROM calendar_feeds WHERE %s = $1`, accountCol)
+
+	res, err := c.db.ExecContext(ctx, query, accountID)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+	if count == 0 {
+		return errs.ErrNoFeed
+	}
+
+	return nil
+}
+
+// GetFeedOwner возвращает id и тип аккаунта, которому принадлежит подписка с хешем токена tokenHash
+func (c calendarRepo) GetFeedOwner(ctx context.Context, tokenHash string) (int, string, error) {
+	var userID, trainerID null.Int
+
+	query := `SELECT user_id, trainer_id FROM calendar_feeds WHERE token_hash = $1`
+
+	if err := c.db.QueryRowContext(ctx, query, tokenHash).Scan(&userID, &trainerID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, "", errs.ErrNoFeed
+		}
+		return 0, "", customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	if userID.Valid {
+		return int(userID.Int64), utils.User, nil
+	}
+
+	return int(trainerID.Int64), utils.Trainer, nil
+}
+
+func (c calendarRepo) GetTimezone(ctx context.Context, accountType string, accountID int) (string, error) {
+	return getTimezone(ctx, c.db, accountType, accountID)
+}
@@ -1,14 +1,54 @@
 package repository
 
 import (
+	"BACKEND/internal/errs"
 	"BACKEND/internal/models/domain"
 	"BACKEND/pkg/customerr"
+	"BACKEND/pkg/utils"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"gopkg.in/guregu/null.v3"
 	"time"
 )
 
+// Колонки сообщения в порядке scanMessage. Статус предложения с истекшим сроком - expired
+const messageColumns = `m.id, m.user_id, m.trainer_id, m.message, m.is_to_user, m.time, m.service_id, m.client_id, m.delivered_at, m.read_at,
+	m.reply_to_id, m.edited_at, m.deleted_at,
+	CASE WHEN m.offer_status = 'pending' AND m.offer_expires_at <= NOW() THEN 'expired' ELSE m.offer_status END, m.offer_expires_at, m.contract_id, m.is_system`
+
+// Колонки вложения в порядке scanAttachment
+const attachmentColumns = `a.id, a.message_id, a.kind, a.file_url, a.thumbnail_url, a.name, a.content_type, a.size, a.created_at`
+
+// Тип собеседника аккаунта в чате
+var chatCounterpart = map[string]string{
+	utils.User:    utils.Trainer,
+	utils.Trainer: utils.User,
+}
+
+// Ошибка несуществующего аккаунта по типу
+var accountNotFound = map[string]error{
+	utils.User:    errs.ErrNoUser,
+	utils.Trainer: errs.ErrNoTrainer,
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanMessage(row scanner, msg *domain.Message) error {
+	return row.Scan(&msg.ID, &msg.UserID, &msg.TrainerID, &msg.Message, &msg.IsToUser, &msg.Time, &msg.ServiceID, &msg.ClientID, &msg.DeliveredAt, &msg.ReadAt,
+		&msg.ReplyToID, &msg.EditedAt, &msg.DeletedAt, &msg.OfferStatus, &msg.OfferExpiresAt, &msg.ContractID, &msg.IsSystem)
+}
+
+func scanAttachment(row scanner, attachment *domain.Attachment) error {
+	return row.Scan(&attachment.ID, &attachment.MessageID, &attachment.Kind, &attachment.FileUrl, &attachment.ThumbnailUrl, &attachment.Name,
+		&attachment.ContentType, &attachment.Size, &attachment.CreatedAt)
+}
+
 type chatRepo struct {
 	db                 *sqlx.DB
 	entitiesPerRequest int
@@ -24,23 +64,147 @@ func InitChatRepo(
 	}
 }
 
-func (c chatRepo) CreateMessage(ctx context.Context, message domain.MessageCreate) (int, time.Time, error) {
-	var createdID int
-	var t time.Time
+// CreateMessage возвращает false, если сообщение с таким client_id уже было создано, и существующее сообщение
+func (c chatRepo) CreateMessage(ctx context.Context, message domain.MessageCreate) (domain.Message, bool, error) {
+	var created domain.Message
 
-	createQuery := `INSERT INTO messages (user_id, trainer_id, message, service_id, is_to_user) VALUES ($1, $2, $3, $4, $5) RETURNING id, time`
+	// Ничего не сохраняется, если один из собеседников заблокировал другого или автор заблокирован администратором
+	var isBlocked, isSuspended bool
 
-	err := c.db.QueryRowContext(ctx, createQuery, message.UserID, message.TrainerID, message.Message, message.ServiceID, message.IsToUser).Scan(&createdID, &t)
+	author := utils.User
+	authorID := message.UserID
+	if message.IsToUser {
+		author = utils.Trainer
+		authorID = message.TrainerID
+	}
+
+	checkQuery := fmt.Sprintf(`
+		SELECT EXISTS(SELECT 1 FROM blocks WHERE user_id = $1 AND trainer_id = $2),
+		       EXISTS(SELECT 1 FROM %s WHERE id = $3 AND suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > NOW()))
+	`, accountTable[author])
+
+	err := c.db.QueryRowContext(ctx, checkQuery, message.UserID, message.TrainerID, authorID).Scan(&isBlocked, &isSuspended)
 	if err != nil {
-		return 0, time.Time{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		return domain.Message{}, false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	if isSuspended {
+		return domain.Message{}, false, errs.ErrSuspended
+	}
+
+	if isBlocked {
+		return domain.Message{}, false, errs.ErrBlocked
 	}
 
-	return createdID, t.UTC(), nil
+	// Ответить можно только на сообщение из этого же чата
+	if message.ReplyToID.Valid {
+		var exists bool
+
+		existsQuery := `SELECT EXISTS(SELECT 1 FROM messages WHERE id = $1 AND user_id = $2 AND trainer_id = $3)`
+
+		err := c.db.QueryRowContext(ctx, existsQuery, message.ReplyToID, message.UserID, message.TrainerID).Scan(&exists)
+		if err != nil {
+			return domain.Message{}, false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		if !exists {
+			return domain.Message{}, false, errs.ErrNoMessage
+		}
+	}
+
+	// Предложить можно только свою услугу
+	if message.OfferExpiresAt.Valid {
+		var exists bool
+
+		existsQuery := `SELECT EXISTS(SELECT 1 FROM services WHERE id = $1 AND trainer_id = $2)`
+
+		err := c.db.QueryRowContext(ctx, existsQuery, message.ServiceID, message.TrainerID).Scan(&exists)
+		if err != nil {
+			return domain.Message{}, false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		if !exists {
+			return domain.Message{}, false, errs.ErrNoService
+		}
+	}
+
+	var offerStatus null.String
+	if message.OfferExpiresAt.Valid {
+		offerStatus = null.StringFrom(domain.OfferPending)
+	}
+
+	tx, err := c.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Message{}, false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	createQuery := `INSERT INTO messages AS m (client_id, user_id, trainer_id, message, service_id, reply_to_id, is_to_user, offer_status, offer_expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (user_id, trainer_id, is_to_user, client_id) WHERE client_id IS NOT NULL DO NOTHING
+		RETURNING ` + messageColumns
+
+	err = scanMessage(tx.QueryRowContext(ctx, createQuery, message.ClientID, message.UserID, message.TrainerID, message.Message, message.ServiceID, message.ReplyToID,
+		message.IsToUser, offerStatus, message.OfferExpiresAt), &created)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+
+		getQuery := `SELECT ` + messageColumns + ` FROM messages m WHERE m.user_id = $1 AND m.trainer_id = $2 AND m.is_to_user = $3 AND m.client_id = $4`
+
+		err = scanMessage(c.db.QueryRowContext(ctx, getQuery, message.UserID, message.TrainerID, message.IsToUser, message.ClientID), &created)
+		if err != nil {
+			return domain.Message{}, false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		messages := []domain.Message{created}
+		if err = c.loadAttachments(ctx, c.db, messages); err != nil {
+			return domain.Message{}, false, err
+		}
+
+		return messages[0], false, nil
+	}
+	if err != nil {
+		tx.Rollback()
+		return domain.Message{}, false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	if len(message.AttachmentIDs) != 0 {
+		// Автор сообщения - владелец вложений
+		attachQuery := fmt.Sprintf(`UPDATE attachments SET message_id = $1 WHERE id = ANY($2) AND %s = $3 AND message_id IS NULL`, accountColumn[author])
+
+		res, err := tx.ExecContext(ctx, attachQuery, created.ID, pq.Array(message.AttachmentIDs), authorID)
+		if err != nil {
+			tx.Rollback()
+			return domain.Message{}, false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+		}
+
+		count, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return domain.Message{}, false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+		}
+
+		if int(count) != len(message.AttachmentIDs) {
+			tx.Rollback()
+			return domain.Message{}, false, errs.ErrNoAttachment
+		}
+	}
+
+	messages := []domain.Message{created}
+	if err = c.loadAttachments(ctx, tx, messages); err != nil {
+		tx.Rollback()
+		return domain.Message{}, false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domain.Message{}, false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
+	return messages[0], true, nil
 }
 
 func (c chatRepo) GetChatMessage(ctx context.Context, userID, trainerID, cursor int) (domain.MessagePagination, error) {
 	query := `
-		SELECT m.id, m.user_id, m.trainer_id, m.message, m.is_to_user, m.time, m.service_id
+		SELECT ` + messageColumns + `
 		FROM messages m
 		WHERE m.user_id = $1 AND m.trainer_id = $2 AND (m.id <= $3 OR $3 = 0)
 		ORDER BY m.time DESC
@@ -53,29 +217,14 @@ func (c chatRepo) GetChatMessage(ctx context.Context, userID, trainerID, cursor
 	}
 	defer rows.Close()
 
-	type BasePriceNull struct {
-		ID    null.Int
-		Name  null.String
-		Price null.Int
-	}
-
 	var messages []domain.Message
 	for rows.Next() {
 		var msg domain.Message
-		var serviceID null.Int
 
-		err := rows.Scan(&msg.ID, &msg.UserID, &msg.TrainerID, &msg.Message, &msg.IsToUser, &msg.Time, &serviceID)
-		if err != nil {
+		if err = scanMessage(rows, &msg); err != nil {
 			return domain.MessagePagination{}, err
 		}
 
-		if serviceID.Valid {
-			a := int(serviceID.Int64)
-			msg.ServiceID = &a
-		} else {
-			msg.ServiceID = nil
-		}
-
 		messages = append(messages, msg)
 	}
 
@@ -89,6 +238,10 @@ func (c chatRepo) GetChatMessage(ctx context.Context, userID, trainerID, cursor
 		messages = messages[:c.entitiesPerRequest]
 	}
 
+	if err = c.loadAttachments(ctx, c.db, messages); err != nil {
+		return domain.MessagePagination{}, err
+	}
+
 	return domain.MessagePagination{
 		Messages: messages,
 		Cursor:   nextCursor,
@@ -97,7 +250,9 @@ func (c chatRepo) GetChatMessage(ctx context.Context, userID, trainerID, cursor
 
 func (c chatRepo) GetUserChats(ctx context.Context, userID int, search string) ([]domain.Chat, error) {
 	query := `
-	SELECT t.id, t.photo_url, t.first_name, t.last_name, m.message, m.time
+	SELECT t.id, t.photo_url, t.first_name, t.last_name, COALESCE(CASE WHEN m.deleted_at IS NULL THEN m.message END, ''), m.time,
+	       (SELECT COUNT(*) FROM messages unread
+	        WHERE unread.user_id = m.user_id AND unread.trainer_id = m.trainer_id AND unread.is_to_user = true AND unread.read_at IS NULL AND unread.deleted_at IS NULL)
 	FROM messages m
 	JOIN trainers t ON m.trainer_id = t.id
 	JOIN (
@@ -125,6 +280,7 @@ func (c chatRepo) GetUserChats(ctx context.Context, userID int, search string) (
 			&chat.LastName,
 			&chat.LastMessage,
 			&chat.TimeLastMessage,
+			&chat.UnreadCount,
 		)
 		if err != nil {
 			return nil, err
@@ -141,7 +297,9 @@ func (c chatRepo) GetUserChats(ctx context.Context, userID int, search string) (
 
 func (c chatRepo) GetTrainerChats(ctx context.Context, trainerID int, search string) ([]domain.Chat, error) {
 	query := `
-	SELECT u.id, u.photo_url, u.first_name, u.last_name, m.message, m.time
+	SELECT u.id, u.photo_url, u.first_name, u.last_name, COALESCE(CASE WHEN m.deleted_at IS NULL THEN m.message END, ''), m.time,
+	       (SELECT COUNT(*) FROM messages unread
+	        WHERE unread.user_id = m.user_id AND unread.trainer_id = m.trainer_id AND unread.is_to_user = false AND unread.read_at IS NULL AND unread.deleted_at IS NULL)
 	FROM messages m
 	JOIN users u ON m.user_id = u.id
 	JOIN (
@@ -169,6 +327,7 @@ func (c chatRepo) GetTrainerChats(ctx context.Context, trainerID int, search str
 			&chat.LastName,
 			&chat.LastMessage,
 			&chat.TimeLastMessage,
+			&chat.UnreadCount,
 		)
 		if err != nil {
 			return nil, err
@@ -182,3 +341,732 @@ func (c chatRepo) GetTrainerChats(ctx context.Context, trainerID int, search str
 
 	return chats, nil
 }
+
+// SearchMessages ищет по текстам не удаленных сообщений чатов аккаунта, с собеседником to или во всех при to = 0. Новые сообщения первыми
+func (c chatRepo) SearchMessages(ctx context.Context, accountType string, accountID int, search string, to, cursor int) (domain.MessageFoundPagination, error) {
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return domain.MessageFoundPagination{}, errs.ErrForbidden
+	}
+	counterpartType := chatCounterpart[accountType]
+
+	// Текст экранируется для HTML до выделения, чтобы в highlight разметкой были только <mark></mark>.
+	// Сущности вроде &lt; парсер разбирает одним токеном, поэтому фрагменты их не разрезают
+	query := fmt.Sprintf(`
+		SELECT m.id, m.user_id, m.trainer_id, m.is_to_user, m.time, a.id, a.photo_url, a.first_name, a.last_name,
+		       ts_headline('russian',
+		           replace(replace(replace(replace(replace(m.message, '&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;'),
+		           q.query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=3, MinWords=5, MaxWords=20, FragmentDelimiter=" … "')
+		FROM messages m
+		JOIN %s a ON a.id = m.%s
+		CROSS JOIN (SELECT websearch_to_tsquery('russian', $2) || websearch_to_tsquery('english', $2) AS query) q
+		WHERE m.%s = $1 AND m.deleted_at IS NULL AND m.search @@ q.query AND (m.%s = $3 OR $3 = 0) AND (m.id <= $4 OR $4 = 0)
+		ORDER BY m.id DESC
+		LIMIT $5
+	`, accountTable[counterpartType], accountColumn[counterpartType], accountCol, accountColumn[counterpartType])
+
+	rows, err := c.db.QueryContext(ctx, query, accountID, search, to, cursor, c.entitiesPerRequest+1)
+	if err != nil {
+		return domain.MessageFoundPagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	var messages []domain.MessageFound
+	for rows.Next() {
+		var message domain.MessageFound
+
+		err = rows.Scan(&message.ID, &message.UserID, &message.TrainerID, &message.IsToUser, &message.Time, &message.ChatID, &message.PhotoUrl,
+			&message.FirstName, &message.LastName, &message.Highlight)
+		if err != nil {
+			return domain.MessageFoundPagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		messages = append(messages, message)
+	}
+
+	if err = rows.Err(); err != nil {
+		return domain.MessageFoundPagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	var nextCursor int
+	if len(messages) == c.entitiesPerRequest+1 {
+		nextCursor = messages[c.entitiesPerRequest].ID
+		messages = messages[:c.entitiesPerRequest]
+	}
+
+	return domain.MessageFoundPagination{
+		Messages: messages,
+		Cursor:   nextCursor,
+	}, nil
+}
+
+func (c chatRepo) MarkDelivered(ctx context.Context, accountType string, accountID, messageID int) (domain.Receipt, error) {
+	return c.mark(ctx, `delivered_at = NOW()`, `delivered_at`, accountType, accountID, messageID)
+}
+
+func (c chatRepo) MarkRead(ctx context.Context, accountType string, accountID, messageID int) (domain.Receipt, error) {
+	return c.mark(ctx, `read_at = NOW(), delivered_at = COALESCE(delivered_at, NOW())`, `read_at`, accountType, accountID, messageID)
+}
+
+// mark проставляет отметку всем еще не отмеченным сообщениям чата до messageID включительно, адресованным аккаунту.
+// Если отмечать нечего, возвращается пустой Receipt
+func (c chatRepo) mark(ctx context.Context, set, column, accountType string, accountID, messageID int) (domain.Receipt, error) {
+	var receipt domain.Receipt
+
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return domain.Receipt{}, errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`
+		WITH target AS (
+			SELECT user_id, trainer_id, is_to_user
+			FROM messages
+			WHERE id = $1 AND %[3]s = $2 AND is_to_user = $3
+		), updated AS (
+			UPDATE messages m
+			SET %[1]s
+			FROM target t
+			WHERE m.user_id = t.user_id AND m.trainer_id = t.trainer_id AND m.is_to_user = t.is_to_user AND m.id <= $1 AND m.%[2]s IS NULL
+			RETURNING m.user_id, m.trainer_id, m.is_to_user, m.id, m.%[2]s
+		)
+		SELECT user_id, trainer_id, is_to_user, MAX(id), MAX(%[2]s)
+		FROM updated
+		GROUP BY user_id, trainer_id, is_to_user
+	`, set, column, accountCol)
+
+	err := c.db.QueryRowContext(ctx, query, messageID, accountID, !accountAuthoredMessages[accountType]).
+		Scan(&receipt.UserID, &receipt.TrainerID, &receipt.IsToUser, &receipt.MessageID, &receipt.Time)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Receipt{}, nil
+		}
+		return domain.Receipt{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	receipt.Time = receipt.Time.UTC()
+
+	return receipt, nil
+}
+
+// GetMissedMessages возвращает сообщения всех чатов аккаунта с id больше afterID в порядке отправки.
+// При undeliveredOnly - только еще не доставленные аккаунту
+func (c chatRepo) GetMissedMessages(ctx context.Context, accountType string, accountID, afterID int, undeliveredOnly bool) (domain.MessagePagination, error) {
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return domain.MessagePagination{}, errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`
+		SELECT `+messageColumns+`
+		FROM messages m
+		WHERE m.%s = $1 AND m.id > $2 AND (NOT $3 OR (m.is_to_user = $4 AND m.delivered_at IS NULL))
+		ORDER BY m.id
+		LIMIT $5
+	`, accountCol)
+
+	rows, err := c.db.QueryContext(ctx, query, accountID, afterID, undeliveredOnly, !accountAuthoredMessages[accountType], c.entitiesPerRequest+1)
+	if err != nil {
+		return domain.MessagePagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	var messages []domain.Message
+	for rows.Next() {
+		var msg domain.Message
+
+		if err = scanMessage(rows, &msg); err != nil {
+			return domain.MessagePagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		messages = append(messages, msg)
+	}
+
+	if err = rows.Err(); err != nil {
+		return domain.MessagePagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	// В отличие от истории чата, курсор - id последнего отданного сообщения
+	var nextCursor int
+	if len(messages) == c.entitiesPerRequest+1 {
+		messages = messages[:c.entitiesPerRequest]
+		nextCursor = messages[c.entitiesPerRequest-1].ID
+	}
+
+	if err = c.loadAttachments(ctx, c.db, messages); err != nil {
+		return domain.MessagePagination{}, err
+	}
+
+	return domain.MessagePagination{
+		Messages: messages,
+		Cursor:   nextCursor,
+	}, nil
+}
+
+func (c chatRepo) SetLastSeen(ctx context.Context, accountType string, accountID int) error {
+	table, ok := accountTable[accountType]
+	if !ok {
+		return errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET last_seen_at = NOW() WHERE id = $1`, table)
+
+	if _, err := c.db.ExecContext(ctx, query, accountID); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	return nil
+}
+
+// GetLastSeen возвращает время последней активности существующих аккаунтов из accountIDs
+func (c chatRepo) GetLastSeen(ctx context.Context, accountType string, accountIDs []int) ([]domain.Presence, error) {
+	table, ok := accountTable[accountType]
+	if !ok {
+		return nil, errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`SELECT id, last_seen_at FROM %s WHERE id = ANY($1) ORDER BY id`, table)
+
+	rows, err := c.db.QueryContext(ctx, query, pq.Array(accountIDs))
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	var presences []domain.Presence
+	for rows.Next() {
+		var presence domain.Presence
+
+		if err = rows.Scan(&presence.ID, &presence.LastSeenAt); err != nil {
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		presences = append(presences, presence)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	return presences, nil
+}
+
+// EditMessage сохраняет предыдущий текст в историю и заменяет его. Изменить можно только свое не удаленное сообщение
+func (c chatRepo) EditMessage(ctx context.Context, accountType string, accountID, messageID int, text string) (domain.Message, error) {
+	var message domain.Message
+
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return domain.Message{}, errs.ErrForbidden
+	}
+
+	tx, err := c.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	historyQuery := fmt.Sprintf(`
+		INSERT INTO message_edits (message_id, message)
+		SELECT id, message
+		FROM messages
+		WHERE id = $1 AND %s = $2 AND is_to_user = $3 AND is_system = false AND deleted_at IS NULL AND NOT %s
+		FOR UPDATE
+	`, accountCol, settledOfferCondition)
+
+	res, err := tx.ExecContext(ctx, historyQuery, messageID, accountID, accountAuthoredMessages[accountType])
+	if err != nil {
+		tx.Rollback()
+		return domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		tx.Rollback()
+
+		_, isSettled, err := c.ownMessageState(ctx, accountCol, accountType, accountID, messageID)
+		if err != nil {
+			return domain.Message{}, err
+		}
+		if isSettled {
+			return domain.Message{}, errs.ErrOfferClosed
+		}
+
+		return domain.Message{}, errs.ErrNoMessage
+	}
+
+	updateQuery := `UPDATE messages m SET message = $2, edited_at = NOW() WHERE m.id = $1 RETURNING ` + messageColumns
+
+	if err = scanMessage(tx.QueryRowContext(ctx, updateQuery, messageID, text), &message); err != nil {
+		tx.Rollback()
+		return domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	messages := []domain.Message{message}
+	if err = c.loadAttachments(ctx, tx, messages); err != nil {
+		tx.Rollback()
+		return domain.Message{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
+	return messages[0], nil
+}
+
+// DeleteMessage помечает свое сообщение удаленным у всех, если оно отправлено не раньше after
+func (c chatRepo) DeleteMessage(ctx context.Context, accountType string, accountID, messageID int, after time.Time) (domain.Message, error) {
+	var message domain.Message
+
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return domain.Message{}, errs.ErrForbidden
+	}
+
+	deleteQuery := fmt.Sprintf(`
+		UPDATE messages m SET deleted_at = NOW()
+		WHERE m.id = $1 AND m.%s = $2 AND m.is_to_user = $3 AND m.is_system = false AND m.deleted_at IS NULL AND m.time >= $4
+		  AND NOT %s
+		RETURNING `+messageColumns, accountCol, settledOfferCondition)
+
+	err := scanMessage(c.db.QueryRowContext(ctx, deleteQuery, messageID, accountID, accountAuthoredMessages[accountType], after), &message)
+	if err == nil {
+		return message, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	// Сообщение не найдено, предложение в нем уже решено или время на удаление истекло
+	exists, isSettled, err := c.ownMessageState(ctx, accountCol, accountType, accountID, messageID)
+	if err != nil {
+		return domain.Message{}, err
+	}
+
+	switch {
+	case !exists:
+		return domain.Message{}, errs.ErrNoMessage
+	case isSettled:
+		return domain.Message{}, errs.ErrOfferClosed
+	default:
+		return domain.Message{}, errs.ErrDeleteTimeExpired
+	}
+}
+
+// Принятое или отклоненное предложение и сообщение с договором - запись о том, с чем согласился пользователь.
+// Их, как и системные сообщения, нельзя изменить или удалить
+const settledOfferCondition = `(offer_status IS NOT NULL AND offer_status <> 'pending' OR contract_id IS NOT NULL)`
+
+// ownMessageState проверяет, есть ли у аккаунта свое неудаленное сообщение messageID и решено ли предложение в нем
+func (c chatRepo) ownMessageState(ctx context.Context, accountCol, accountType string, accountID, messageID int) (bool, bool, error) {
+	var isSettled bool
+
+	query := fmt.Sprintf(`
+		SELECT %s
+		FROM messages
+		WHERE id = $1 AND %s = $2 AND is_to_user = $3 AND is_system = false AND deleted_at IS NULL
+	`, settledOfferCondition, accountCol)
+
+	err := c.db.QueryRowContext(ctx, query, messageID, accountID, accountAuthoredMessages[accountType]).Scan(&isSettled)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return true, isSettled, nil
+}
+
+// AnswerOffer переводит действующее предложение услуги пользователю userID в статус status
+func (c chatRepo) AnswerOffer(ctx context.Context, userID, messageID int, status string) (domain.Message, error) {
+	var message domain.Message
+
+	answerQuery := `
+		UPDATE messages m SET offer_status = $3
+		WHERE m.id = $1 AND m.user_id = $2 AND m.is_to_user = true AND m.service_id IS NOT NULL AND m.deleted_at IS NULL
+		  AND m.offer_status = 'pending' AND m.offer_expires_at > NOW()
+		RETURNING ` + messageColumns
+
+	err := scanMessage(c.db.QueryRowContext(ctx, answerQuery, messageID, userID, status), &message)
+	if err == nil {
+		return message, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	// Предложение не найдено или на него уже нельзя ответить
+	var exists bool
+
+	existsQuery := `SELECT EXISTS(SELECT 1 FROM messages WHERE id = $1 AND user_id = $2 AND is_to_user = true AND offer_status IS NOT NULL AND deleted_at IS NULL)`
+
+	err = c.db.QueryRowContext(ctx, existsQuery, messageID, userID).Scan(&exists)
+	if err != nil {
+		return domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	if exists {
+		return domain.Message{}, errs.ErrOfferClosed
+	}
+
+	return domain.Message{}, errs.ErrNoOffer
+}
+
+// ReopenOffer возвращает принятое предложение в ожидание, если договор по нему не удалось заключить
+func (c chatRepo) ReopenOffer(ctx context.Context, messageID int) error {
+	reopenQuery := `UPDATE messages SET offer_status = 'pending' WHERE id = $1 AND offer_status = 'accepted' AND contract_id IS NULL`
+
+	if _, err := c.db.ExecContext(ctx, reopenQuery, messageID); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	return nil
+}
+
+// ConfirmOffer привязывает договор к принятому предложению и создает системное сообщение о нем в ответ на предложение.
+// Возвращает предложение и системное сообщение
+func (c chatRepo) ConfirmOffer(ctx context.Context, messageID, contractID int) (domain.Message, domain.Message, error) {
+	var offer, system domain.Message
+
+	tx, err := c.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return domain.Message{}, domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	confirmQuery := `UPDATE messages m SET contract_id = $2 WHERE m.id = $1 RETURNING ` + messageColumns
+
+	if err = scanMessage(tx.QueryRowContext(ctx, confirmQuery, messageID, contractID), &offer); err != nil {
+		tx.Rollback()
+		return domain.Message{}, domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	systemQuery := `
+		INSERT INTO messages AS m (user_id, trainer_id, message, service_id, reply_to_id, contract_id, is_to_user, is_system)
+		SELECT $1, $2, 'Договор на услугу «' || s.name || '» заключен', s.id, $3, $4, false, true
+		FROM services s
+		WHERE s.id = $5
+		RETURNING ` + messageColumns
+
+	err = scanMessage(tx.QueryRowContext(ctx, systemQuery, offer.UserID, offer.TrainerID, offer.ID, contractID, offer.ServiceID), &system)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return domain.Message{}, domain.Message{}, errs.ErrNoService
+	}
+	if err != nil {
+		tx.Rollback()
+		return domain.Message{}, domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	messages := []domain.Message{offer}
+	if err = c.loadAttachments(ctx, tx, messages); err != nil {
+		tx.Rollback()
+		return domain.Message{}, domain.Message{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domain.Message{}, domain.Message{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
+	return messages[0], system, nil
+}
+
+// GetMessageEdits возвращает историю изменений не удаленного сообщения из чата аккаунта
+func (c chatRepo) GetMessageEdits(ctx context.Context, accountType string, accountID, messageID int) ([]domain.MessageEdit, error) {
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return nil, errs.ErrForbidden
+	}
+
+	var exists bool
+
+	existsQuery := fmt.Sprintf(`SELECT EXISTS(SELECT 1 FROM messages WHERE id = $1 AND %s = $2 AND deleted_at IS NULL)`, accountCol)
+
+	err := c.db.QueryRowContext(ctx, existsQuery, messageID, accountID).Scan(&exists)
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	if !exists {
+		return nil, errs.ErrNoMessage
+	}
+
+	// У обезличенного сообщения автора уже нет, его правки не отдаются, даже если остались в БД
+	query := `
+		SELECT e.message, e.edited_at
+		FROM message_edits e
+			JOIN messages m ON m.id = e.message_id
+		WHERE e.message_id = $1
+		  AND CASE WHEN m.is_to_user THEN m.trainer_id ELSE m.user_id END IS NOT NULL
+		ORDER BY e.edited_at, e.id`
+
+	rows, err := c.db.QueryContext(ctx, query, messageID)
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	edits := []domain.MessageEdit{}
+	for rows.Next() {
+		var edit domain.MessageEdit
+
+		if err = rows.Scan(&edit.Message, &edit.EditedAt); err != nil {
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		edits = append(edits, edit)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	return edits, nil
+}
+
+// loadAttachments заполняет вложения сообщений одним запросом
+func (c chatRepo) loadAttachments(ctx context.Context, q sqlx.QueryerContext, messages []domain.Message) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(messages))
+	byID := make(map[int]*domain.Message, len(messages))
+	for i := range messages {
+		ids[i] = messages[i].ID
+		byID[messages[i].ID] = &messages[i]
+	}
+
+	query := `SELECT ` + attachmentColumns + ` FROM attachments a WHERE a.message_id = ANY($1) ORDER BY a.id`
+
+	rows, err := q.QueryContext(ctx, query, pq.Array(ids))
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var attachment domain.Attachment
+
+		if err = scanAttachment(rows, &attachment); err != nil {
+			return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		message := byID[int(attachment.MessageID.Int64)]
+		message.Attachments = append(message.Attachments, attachment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	return nil
+}
+
+func (c chatRepo) CreateAttachment(ctx context.Context, accountType string, accountID int, attachment domain.Attachment) (domain.Attachment, error) {
+	var created domain.Attachment
+
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return domain.Attachment{}, errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO attachments AS a (%s, kind, file_url, thumbnail_url, name, content_type, size) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+attachmentColumns, accountCol)
+
+	err := scanAttachment(c.db.QueryRowContext(ctx, query, accountID, attachment.Kind, attachment.FileUrl, attachment.ThumbnailUrl,
+		attachment.Name, attachment.ContentType, attachment.Size), &created)
+	if err != nil {
+		return domain.Attachment{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return created, nil
+}
+
+// DeleteUnattached удаляет вложения, загруженные раньше before и так и не отправленные, и возвращает пути к их файлам
+func (c chatRepo) DeleteUnattached(ctx context.Context, before time.Time) ([]string, error) {
+	query := `DELETE FROM attachments WHERE message_id IS NULL AND created_at < $1 RETURNING file_url, thumbnail_url`
+
+	rows, err := c.db.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	var files []string
+	for rows.Next() {
+		var fileUrl string
+		var thumbnailUrl null.String
+
+		if err = rows.Scan(&fileUrl, &thumbnailUrl); err != nil {
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		files = append(files, fileUrl)
+		if thumbnailUrl.Valid {
+			files = append(files, thumbnailUrl.String)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	return files, nil
+}
+
+// Block добавляет собеседника counterpartID в черный список аккаунта, повторная блокировка ничего не меняет
+func (c chatRepo) Block(ctx context.Context, accountType string, accountID, counterpartID int) error {
+	userID, trainerID, err := chatMembers(accountType, accountID, counterpartID)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO blocks (user_id, trainer_id, by_user) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+
+	_, err = c.db.ExecContext(ctx, query, userID, trainerID, accountType == utils.User)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return accountNotFound[chatCounterpart[accountType]]
+		}
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	return nil
+}
+
+func (c chatRepo) Unblock(ctx context.Context, accountType string, accountID, counterpartID int) error {
+	userID, trainerID, err := chatMembers(accountType, accountID, counterpartID)
+	if err != nil {
+		return err
+	}
+
+	query := `DELETE FROM blocks WHERE user_id = $1 AND trainer_id = $2 AND by_user = $3`
+
+	if _, err = c.db.ExecContext(ctx, query, userID, trainerID, accountType == utils.User); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	return nil
+}
+
+// GetBlocked возвращает собеседников, которых заблокировал аккаунт, последние заблокированные первыми
+func (c chatRepo) GetBlocked(ctx context.Context, accountType string, accountID int) ([]domain.BlockedAccount, error) {
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return nil, errs.ErrForbidden
+	}
+	counterpartType := chatCounterpart[accountType]
+
+	query := fmt.Sprintf(`
+		SELECT a.id, a.photo_url, a.first_name, a.last_name, b.created_at
+		FROM blocks b
+		JOIN %s a ON a.id = b.%s
+		WHERE b.%s = $1 AND b.by_user = $2
+		ORDER BY b.created_at DESC
+	`, accountTable[counterpartType], accountColumn[counterpartType], accountCol)
+
+	rows, err := c.db.QueryContext(ctx, query, accountID, accountType == utils.User)
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	var blocked []domain.BlockedAccount
+	for rows.Next() {
+		var account domain.BlockedAccount
+
+		if err = rows.Scan(&account.ID, &account.PhotoUrl, &account.FirstName, &account.LastName, &account.CreatedAt); err != nil {
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		blocked = append(blocked, account)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	return blocked, nil
+}
+
+// IsBlocked проверяет, заблокировал ли кто-то из собеседников чата другого
+func (c chatRepo) IsBlocked(ctx context.Context, userID, trainerID int) (bool, error) {
+	var isBlocked bool
+
+	query := `SELECT EXISTS(SELECT 1 FROM blocks WHERE user_id = $1 AND trainer_id = $2)`
+
+	if err := c.db.QueryRowContext(ctx, query, userID, trainerID).Scan(&isBlocked); err != nil {
+		return false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return isBlocked, nil
+}
+
+// IsChatOpen проверяет, что у собеседников есть переписка или договор и никто из них не заблокировал другого
+func (c chatRepo) IsChatOpen(ctx context.Context, userID, trainerID int) (bool, error) {
+	var isOpen bool
+
+	query := `
+		SELECT NOT EXISTS(SELECT 1 FROM blocks WHERE user_id = $1 AND trainer_id = $2)
+		   AND (EXISTS(SELECT 1 FROM messages WHERE user_id = $1 AND trainer_id = $2)
+		     OR EXISTS(SELECT 1 FROM users_trainers_services WHERE user_id = $1 AND trainer_id = $2))
+	`
+
+	if err := c.db.QueryRowContext(ctx, query, userID, trainerID).Scan(&isOpen); err != nil {
+		return false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return isOpen, nil
+}
+
+// ReportMessage создает жалобу на сообщение, которое собеседник отправил аккаунту
+func (c chatRepo) ReportMessage(ctx context.Context, accountType string, accountID, messageID int, reason string) (int, error) {
+	var createdID int
+
+	accountCol, ok := accountColumn[accountType]
+	if !ok {
+		return 0, errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO message_reports (message_id, %s, reason)
+		SELECT id, $2, $3
+		FROM messages
+		WHERE id = $1 AND %s = $2 AND is_to_user = $4 AND is_system = false
+		RETURNING id
+	`, accountCol, accountCol)
+
+	err := c.db.QueryRowContext(ctx, query, messageID, accountID, reason, !accountAuthoredMessages[accountType]).Scan(&createdID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errs.ErrNoMessage
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return 0, errs.ErrAlreadyExist
+		}
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return createdID, nil
+}
+
+// chatMembers возвращает user_id и trainer_id чата аккаунта с собеседником
+func chatMembers(accountType string, accountID, counterpartID int) (int, int, error) {
+	switch accountType {
+	case utils.User:
+		return accountID, counterpartID, nil
+	case utils.Trainer:
+		return counterpartID, accountID, nil
+	default:
+		return 0, 0, errs.ErrForbidden
+	}
+}
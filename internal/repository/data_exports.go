@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/pkg/customerr"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"time"
+)
+
+type dataExportRepo struct {
+	db *sqlx.DB
+}
+
+func InitDataExportRepo(
+	db *sqlx.DB,
+) DataExports {
+	return &dataExportRepo{
+		db: db,
+	}
+}
+
+func (d dataExportRepo) Create(ctx context.Context, accountType string, accountID int) (int, error) {
+	var createdID int
+
+	column, ok := accountColumn[accountType]
+	if !ok {
+		return 0, errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`INSERT INTO data_exports (%s) VALUES ($1) RETURNING id`, column)
+
+	err := d.db.QueryRowContext(ctx, query, accountID).Scan(&createdID)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return 0, errs.ErrAlreadyExist
+		}
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return createdID, nil
+}
+
+// Get возвращает выгрузку, только если она принадлежит аккаунту
+func (d dataExportRepo) Get(ctx context.Context, accountType string, accountID, exportID int) (domain.DataExport, error) {
+	var export domain.DataExport
+
+	column, ok := accountColumn[accountType]
+	if !ok {
+		return domain.DataExport{}, errs.ErrNoExport
+	}
+
+	query := fmt.Sprintf(`SELECT id, status, file_path, created_at, ready_at FROM data_exports WHERE id = $1 AND %s = $2`, column)
+
+	err := d.db.QueryRowContext(ctx, query, exportID, accountID).Scan(&export.ID, &export.Status, &export.FilePath,
+		&export.CreatedAt, &export.ReadyAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.DataExport{}, errs.ErrNoExport
+		}
+		return domain.DataExport{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return export, nil
+}
+
+func (d dataExportRepo) SetReady(ctx context.Context, exportID int, filePath string) error {
+	query := `UPDATE data_exports SET status = $1, file_path = $2, ready_at = NOW() WHERE id = $3 AND status = $4`
+
+	return d.setStatus(ctx, query, domain.ExportReady, filePath, exportID, domain.ExportPending)
+}
+
+func (d dataExportRepo) SetFailed(ctx context.Context, exportID int) error {
+	query := `UPDATE data_exports SET status = $1 WHERE id = $2 AND status = $3`
+
+	return d.setStatus(ctx, query, domain.ExportFailed, exportID, domain.ExportPending)
+}
+
+// DeleteExpired удаляет выгрузки, созданные раньше before, в том числе зависшие в подготовке.
+// Возвращает пути архивов, которые нужно удалить с диска
+func (d dataExportRepo) DeleteExpired(ctx context.Context, before time.Time) ([]string, error) {
+	query := `DELETE FROM data_exports WHERE created_at < $1 RETURNING file_path`
+
+	rows, err := d.db.QueryContext(ctx, query, before)
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	var files []string
+	for rows.Next() {
+		var filePath sql.NullString
+
+		if err = rows.Scan(&filePath); err != nil {
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		if filePath.Valid {
+			files = append(files, filePath.String)
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	return files, nil
+}
+
+func (d dataExportRepo) setStatus(ctx context.Context, query string, args ...any) error {
+	res, err := d.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	// Выгрузку могли удалить как устаревшую, пока она готовилась
+	if count != 1 {
+		return errs.ErrNoExport
+	}
+
+	return nil
+}
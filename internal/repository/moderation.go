@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/pkg/customerr"
+	"context"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/guregu/null.v3"
+	"time"
+)
+
+// prefixScanner дописывает поля жалобы перед полями сообщения, чтобы переиспользовать scanMessage
+type prefixScanner struct {
+	row  scanner
+	dest []any
+}
+
+func (p prefixScanner) Scan(dest ...any) error {
+	return p.row.Scan(append(p.dest, dest...)...)
+}
+
+type moderationRepo struct {
+	db                 *sqlx.DB
+	entitiesPerRequest int
+}
+
+func InitModerationRepo(
+	db *sqlx.DB,
+	entitiesPerRequest int,
+) Moderation {
+	return &moderationRepo{
+		db:                 db,
+		entitiesPerRequest: entitiesPerRequest,
+	}
+}
+
+func (m moderationRepo) GetReports(ctx context.Context, filters domain.FiltersMessageReports) (domain.MessageReportPagination, error) {
+	selectQuery := `
+	SELECT r.id, r.user_id, r.trainer_id, r.reason, r.status, r.reviewed_by, r.created_at, r.reviewed_at, ` + messageColumns + `
+	FROM message_reports r
+	JOIN messages m ON m.id = r.message_id
+	WHERE r.id >= $1 AND ($2 = '' OR r.status = $2)
+	ORDER BY r.id
+	LIMIT $3`
+
+	rows, err := m.db.QueryContext(ctx, selectQuery, filters.Cursor, filters.Status, m.entitiesPerRequest+1)
+	if err != nil {
+		return domain.MessageReportPagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	var reports []domain.MessageReport
+	for rows.Next() {
+		var report domain.MessageReport
+
+		row := prefixScanner{row: rows, dest: []any{&report.ID, &report.UserID, &report.TrainerID, &report.Reason, &report.Status,
+			&report.ReviewedBy, &report.CreatedAt, &report.ReviewedAt}}
+
+		if err = scanMessage(row, &report.Message); err != nil {
+			return domain.MessageReportPagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		reports = append(reports, report)
+	}
+
+	if err = rows.Err(); err != nil {
+		return domain.MessageReportPagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	var nextCursor int
+	if len(reports) == m.entitiesPerRequest+1 {
+		nextCursor = reports[m.entitiesPerRequest].ID
+		reports = reports[:m.entitiesPerRequest]
+	}
+
+	return domain.MessageReportPagination{
+		Reports: reports,
+		Cursor:  nextCursor,
+	}, nil
+}
+
+// ReviewReport закрывает жалобу. Условие на статус не дает рассмотреть жалобу дважды
+func (m moderationRepo) ReviewReport(ctx context.Context, reportID, adminID int, status string) error {
+	query := `UPDATE message_reports SET status = $1, reviewed_by = $2, reviewed_at = NOW() WHERE id = $3 AND status = $4`
+
+	res, err := m.db.ExecContext(ctx, query, status, adminID, reportID, domain.ReportPending)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count == 1 {
+		return nil
+	}
+
+	// Жалоба не найдена или уже рассмотрена
+	var exists bool
+
+	existsQuery := `SELECT EXISTS(SELECT 1 FROM message_reports WHERE id = $1)`
+
+	if err = m.db.QueryRowContext(ctx, existsQuery, reportID).Scan(&exists); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	if exists {
+		return errs.ErrReportReviewed
+	}
+
+	return errs.ErrNoReport
+}
+
+// Suspend блокирует аккаунт до until или бессрочно, если until не задан. Повторный вызов заменяет срок и причину
+func (m moderationRepo) Suspend(ctx context.Context, accountType string, accountID int, until *time.Time, reason string) error {
+	table, ok := accountTable[accountType]
+	if !ok {
+		return errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET suspended_at = NOW(), suspended_until = $1, suspension_reason = $2 WHERE id = $3`, table)
+
+	return m.suspend(ctx, query, accountType, null.TimeFromPtr(until), reason, accountID)
+}
+
+func (m moderationRepo) Unsuspend(ctx context.Context, accountType string, accountID int) error {
+	table, ok := accountTable[accountType]
+	if !ok {
+		return errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET suspended_at = NULL, suspended_until = NULL, suspension_reason = NULL WHERE id = $1`, table)
+
+	return m.suspend(ctx, query, accountType, accountID)
+}
+
+func (m moderationRepo) suspend(ctx context.Context, query, accountType string, args ...any) error {
+	res, err := m.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		return accountNotFound[accountType]
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/pkg/customerr"
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+type policyRepo struct {
+	db *sqlx.DB
+}
+
+func InitPolicyRepo(
+	db *sqlx.DB,
+) Policies {
+	return &policyRepo{
+		db: db,
+	}
+}
+
+func (p policyRepo) GetPlanOwner(ctx context.Context, planID int) (int, error) {
+	var userID int
+
+	query := `SELECT user_id FROM plans WHERE id = $1`
+
+	err := p.db.QueryRowContext(ctx, query, planID).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errs.ErrNoPlan
+		}
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return userID, nil
+}
+
+func (p policyRepo) GetTrainingOwner(ctx context.Context, trainingID int) (domain.Owner, error) {
+	var owner domain.Owner
+
+	query := `SELECT user_id, trainer_id FROM trainings WHERE id = $1`
+
+	err := p.db.QueryRowContext(ctx, query, trainingID).Scan(&owner.UserID, &owner.TrainerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Owner{}, errs.ErrNoTraining
+		}
+		return domain.Owner{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return owner, nil
+}
+
+func (p policyRepo) GetUserTrainingsOwners(ctx context.Context, userTrainingIDs []int) ([]int, error) {
+	query := `SELECT user_id FROM users_trainings WHERE id = ANY($1)`
+
+	rows, err := p.db.QueryContext(ctx, query, pq.Array(userTrainingIDs))
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	var userIDs []int
+	for rows.Next() {
+		var userID int
+		if err := rows.Scan(&userID); err != nil {
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if len(userIDs) != countUnique(userTrainingIDs) {
+		return nil, errs.ErrNoTraining
+	}
+
+	return userIDs, nil
+}
+
+func (p policyRepo) GetTrainerServiceOwner(ctx context.Context, serviceID int) (int, error) {
+	var trainerID int
+
+	query := `SELECT trainer_id FROM services WHERE id = $1`
+
+	err := p.db.QueryRowContext(ctx, query, serviceID).Scan(&trainerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errs.ErrNoService
+		}
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return trainerID, nil
+}
+
+func (p policyRepo) GetUserTrainerServiceOwner(ctx context.Context, serviceID int) (domain.Owner, error) {
+	var owner domain.Owner
+
+	query := `SELECT user_id, trainer_id FROM users_trainers_services WHERE id = $1`
+
+	err := p.db.QueryRowContext(ctx, query, serviceID).Scan(&owner.UserID, &owner.TrainerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Owner{}, errs.ErrNoService
+		}
+		return domain.Owner{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return owner, nil
+}
+
+func (p policyRepo) GetSchedulesOwners(ctx context.Context, scheduleIDs []int) ([]domain.Owner, error) {
+	query := `
+	SELECT uts.user_id, uts.trainer_id
+	FROM users_trainers_services_schedule tuts
+		JOIN users_trainers_services uts ON tuts.users_trainers_services_id = uts.id
+	WHERE tuts.id = ANY($1)`
+
+	rows, err := p.db.QueryContext(ctx, query, pq.Array(scheduleIDs))
+	if err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	var owners []domain.Owner
+	for rows.Next() {
+		var owner domain.Owner
+		if err := rows.Scan(&owner.UserID, &owner.TrainerID); err != nil {
+			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+		owners = append(owners, owner)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if len(owners) != countUnique(scheduleIDs) {
+		return nil, errs.ErrNoSchedule
+	}
+
+	return owners, nil
+}
+
+func (p policyRepo) IsClient(ctx context.Context, userID, trainerID int) (bool, error) {
+	var isClient bool
+
+	query := `SELECT EXISTS(SELECT 1 FROM users_trainers_services WHERE user_id = $1 AND trainer_id = $2)`
+
+	err := p.db.QueryRowContext(ctx, query, userID, trainerID).Scan(&isClient)
+	if err != nil {
+		return false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return isClient, nil
+}
+
+// countUnique возвращает количество различных id, так как `= ANY` не дублирует строки
+func countUnique(ids []int) int {
+	unique := make(map[int]struct{}, len(ids))
+	for _, id := range ids {
+		unique[id] = struct{}{}
+	}
+
+	return len(unique)
+}
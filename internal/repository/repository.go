@@ -16,11 +16,81 @@ type Base interface {
 	GetTable() string
 }
 
+type Admins interface {
+	Save(ctx context.Context, admin domain.AdminCreate) (int, error)
+	GetByID(ctx context.Context, adminID int) (domain.Admin, error)
+	GetSecure(ctx context.Context, email string) (domain.AdminSecure, error)
+}
+
+type TwoFactor interface {
+	GetEmail(ctx context.Context, accountType string, accountID int) (string, error)
+	Get(ctx context.Context, accountType string, accountID int) (domain.TwoFactor, error)
+	SetSecret(ctx context.Context, accountType string, accountID int, secret string) error
+	Enable(ctx context.Context, twoFactorID int, step int64, codeHashes []string) error
+	UseStep(ctx context.Context, twoFactorID int, step int64) error
+	UseRecoveryCode(ctx context.Context, twoFactorID int, codeHash string) error
+	ReplaceRecoveryCodes(ctx context.Context, twoFactorID int, codeHashes []string) error
+	Delete(ctx context.Context, twoFactorID int) error
+}
+
+type TrainerApplications interface {
+	Create(ctx context.Context, application domain.TrainerApplicationCreate) (int, error)
+	GetByID(ctx context.Context, applicationID int) (domain.TrainerApplication, error)
+	GetCovers(ctx context.Context, filters domain.FiltersTrainerApplications) (domain.TrainerApplicationCoverPagination, error)
+	Approve(ctx context.Context, applicationID, adminID int, trainer domain.TrainerCreate, roleIDs, specializationIDs []int) (int, error)
+	Reject(ctx context.Context, applicationID, adminID int, reason string) error
+}
+
+type Calendar interface {
+	GetEvents(ctx context.Context, accountID int, accountType string, start, end time.Time) ([]domain.CalendarEvent, error)
+	GetSeries(ctx context.Context, userID int, dateStart, dateEnd time.Time) ([]domain.TrainingSeries, error)
+	GetTraining(ctx context.Context, userTrainingID int) (domain.CalendarEvent, error)
+	SetFeed(ctx context.Context, accountID int, accountType, tokenHash string) error
+	DeleteFeed(ctx context.Context, accountID int, accountType string) error
+	GetFeedOwner(ctx context.Context, tokenHash string) (int, string, error)
+	GetTimezone(ctx context.Context, accountType string, accountID int) (string, error)
+}
+
+type Availability interface {
+	GetAvailability(ctx context.Context, trainerID int, dateStart time.Time, dateEnd null.Time) (domain.Availability, error)
+	UpdateAvailability(ctx context.Context, trainerID int, weekly []domain.AvailabilityInterval) error
+	CreateException(ctx context.Context, exception domain.AvailabilityException) (int, error)
+	DeleteException(ctx context.Context, trainerID, exceptionID int) error
+	GetBusy(ctx context.Context, trainerID int, start, end time.Time) ([]domain.Slot, error)
+	ReplaceBusy(ctx context.Context, trainerID int, from time.Time, busy []domain.Slot) error
+	GetTimezone(ctx context.Context, accountType string, accountID int) (string, error)
+}
+
+type Moderation interface {
+	GetReports(ctx context.Context, filters domain.FiltersMessageReports) (domain.MessageReportPagination, error)
+	ReviewReport(ctx context.Context, reportID, adminID int, status string) error
+	Suspend(ctx context.Context, accountType string, accountID int, until *time.Time, reason string) error
+	Unsuspend(ctx context.Context, accountType string, accountID int) error
+}
+
+type AccountDeletion interface {
+	Schedule(ctx context.Context, accountType string, accountID int, deleteAt time.Time) error
+	Cancel(ctx context.Context, accountType string, accountID int) error
+	GetDue(ctx context.Context, accountType string) ([]int, error)
+	Delete(ctx context.Context, accountType string, accountID int) ([]string, error)
+}
+
+type DataExports interface {
+	Create(ctx context.Context, accountType string, accountID int) (int, error)
+	Get(ctx context.Context, accountType string, accountID, exportID int) (domain.DataExport, error)
+	SetReady(ctx context.Context, exportID int, filePath string) error
+	SetFailed(ctx context.Context, exportID int) error
+	DeleteExpired(ctx context.Context, before time.Time) ([]string, error)
+}
+
 type Users interface {
 	Create(ctx context.Context, user domain.UserCreate) (int, error)
 	GetByID(ctx context.Context, userID int) (domain.User, error)
 	GetCovers(ctx context.Context, search string, cursor int) (domain.UserCoverPagination, error)
-	GetSecure(ctx context.Context, email string) (int, string, error)
+	GetSecure(ctx context.Context, email string) (domain.Secure, error)
+	GetVerification(ctx context.Context, userID int) (string, bool, error)
+	SetVerified(ctx context.Context, userID int) error
+	UpdatePassword(ctx context.Context, userID int, password string) error
 	UpdateMain(ctx context.Context, user domain.UserUpdate) error
 	UpdatePhotoUrl(ctx context.Context, userID int, newPhotoUrl null.String) error
 }
@@ -29,29 +99,32 @@ type Trainers interface {
 	Create(ctx context.Context, trainer domain.TrainerCreate) (int, error)
 	GetByID(ctx context.Context, trainerID int) (domain.Trainer, error)
 	GetCovers(ctx context.Context, filters domain.FiltersTrainerCovers) (domain.TrainerCoverPagination, error)
-	GetSecure(ctx context.Context, email string) (int, string, error)
+	GetSecure(ctx context.Context, email string) (domain.Secure, error)
+	GetVerification(ctx context.Context, trainerID int) (string, bool, error)
+	SetVerified(ctx context.Context, trainerID int) error
+	UpdatePassword(ctx context.Context, trainerID int, password string) error
 	UpdateMain(ctx context.Context, trainer domain.TrainerUpdate) error
 	UpdatePhotoUrl(ctx context.Context, trainerID int, newPhotoUrl null.String) error
 	UpdateRoles(ctx context.Context, trainerID int, roleIDs []int) error
 	UpdateSpecializations(ctx context.Context, trainerID int, specializationIDs []int) error
 	CreateService(ctx context.Context, service domain.ServiceCreate) (int, error)
-	UpdateService(ctx context.Context, service domain.ServiceUpdate) error
+	UpdateService(ctx context.Context, trainerID int, service domain.ServiceUpdate) error
 	DeleteService(ctx context.Context, trainerID, serviceID int) error
 	CreateAchievement(ctx context.Context, trainerID int, achievement string) (int, error)
-	UpdateAchievementStatus(ctx context.Context, achievementID int, status bool) error
+	UpdateAchievementStatus(ctx context.Context, adminID, achievementID int, status bool) error
 	DeleteAchievement(ctx context.Context, trainerID, achievementID int) error
 }
 
 type UsersTrainersServices interface {
 	Create(ctx context.Context, service domain.UserTrainerServiceCreate) (int, error)
 	Schedule(ctx context.Context, schedule domain.ScheduleService) (int, error)
-	GetSchedule(ctx context.Context, month, trainerID int) ([]domain.TrainingSchedule, error)
 	GetSchedulesByIDs(ctx context.Context, scheduleIDs []int) ([]domain.ScheduleServiceUser, error)
 	DeleteScheduled(ctx context.Context, scheduleID int) error
 	GetUserServices(ctx context.Context, trainerID, cursor int) (domain.ServiceUserPagination, error)
 	GetTrainerServices(ctx context.Context, userID, cursor int) (domain.ServiceTrainerPagination, error)
 	UpdateStatus(ctx context.Context, field string, serviceID int, status bool) error
 	Delete(ctx context.Context, serviceID int) error
+	GetTimezone(ctx context.Context, accountType string, accountID int) (string, error)
 }
 
 type Trainings interface {
@@ -68,19 +141,55 @@ type Trainings interface {
 	GetTrainingTrainer(ctx context.Context, trainingID int) (domain.TrainingTrainer, error)
 	GetScheduleTrainings(ctx context.Context, userTrainingIDs []int) ([]domain.UserTraining, error)
 	ScheduleTraining(ctx context.Context, training domain.ScheduleTraining) (int, []int, error)
-	GetSchedule(ctx context.Context, month, userID int) ([]domain.TrainingSchedule, error)
 	DeleteUserTraining(ctx context.Context, trainingID int) error
 	DeleteScheduledTraining(ctx context.Context, userTrainingID int) error
+	CreateSeries(ctx context.Context, training domain.ScheduleTraining, dateEnd null.Time) (int, error)
+	GetSeries(ctx context.Context, userID, seriesID int) (domain.TrainingSeries, error)
+	UpdateSeries(ctx context.Context, series domain.TrainingSeries) error
+	DeleteSeries(ctx context.Context, userID, seriesID int) error
+	SaveOccurrence(ctx context.Context, occurrence domain.TrainingOccurrence) (int, error)
+	CancelOccurrence(ctx context.Context, seriesID int, occurrenceDate time.Time) error
 	CreatePlan(ctx context.Context, plan domain.PlanCreate) (int, error)
 	GetPlanCoversByUserID(ctx context.Context, userID int) ([]domain.PlanCover, error)
 	GetPlan(ctx context.Context, planID int) (domain.Plan, error)
 	DeletePlan(ctx context.Context, planID int) error
 	GetProgress(ctx context.Context, filters domain.FiltersProgress) (domain.ProgressPagination, error)
+	GetTimezone(ctx context.Context, accountType string, accountID int) (string, error)
 }
 
 type Chat interface {
-	CreateMessage(ctx context.Context, message domain.MessageCreate) (int, time.Time, error)
+	CreateMessage(ctx context.Context, message domain.MessageCreate) (domain.Message, bool, error)
 	GetUserChats(ctx context.Context, userID int, search string) ([]domain.Chat, error)
 	GetTrainerChats(ctx context.Context, trainerID int, search string) ([]domain.Chat, error)
 	GetChatMessage(ctx context.Context, userID, trainerID, cursor int) (domain.MessagePagination, error)
+	SearchMessages(ctx context.Context, accountType string, accountID int, search string, to, cursor int) (domain.MessageFoundPagination, error)
+	MarkDelivered(ctx context.Context, accountType string, accountID, messageID int) (domain.Receipt, error)
+	MarkRead(ctx context.Context, accountType string, accountID, messageID int) (domain.Receipt, error)
+	GetMissedMessages(ctx context.Context, accountType string, accountID, afterID int, undeliveredOnly bool) (domain.MessagePagination, error)
+	SetLastSeen(ctx context.Context, accountType string, accountID int) error
+	GetLastSeen(ctx context.Context, accountType string, accountIDs []int) ([]domain.Presence, error)
+	EditMessage(ctx context.Context, accountType string, accountID, messageID int, text string) (domain.Message, error)
+	DeleteMessage(ctx context.Context, accountType string, accountID, messageID int, after time.Time) (domain.Message, error)
+	AnswerOffer(ctx context.Context, userID, messageID int, status string) (domain.Message, error)
+	ReopenOffer(ctx context.Context, messageID int) error
+	ConfirmOffer(ctx context.Context, messageID, contractID int) (domain.Message, domain.Message, error)
+	GetMessageEdits(ctx context.Context, accountType string, accountID, messageID int) ([]domain.MessageEdit, error)
+	Block(ctx context.Context, accountType string, accountID, counterpartID int) error
+	Unblock(ctx context.Context, accountType string, accountID, counterpartID int) error
+	GetBlocked(ctx context.Context, accountType string, accountID int) ([]domain.BlockedAccount, error)
+	IsBlocked(ctx context.Context, userID, trainerID int) (bool, error)
+	IsChatOpen(ctx context.Context, userID, trainerID int) (bool, error)
+	ReportMessage(ctx context.Context, accountType string, accountID, messageID int, reason string) (int, error)
+	CreateAttachment(ctx context.Context, accountType string, accountID int, attachment domain.Attachment) (domain.Attachment, error)
+	DeleteUnattached(ctx context.Context, before time.Time) ([]string, error)
+}
+
+type Policies interface {
+	GetPlanOwner(ctx context.Context, planID int) (int, error)
+	GetTrainingOwner(ctx context.Context, trainingID int) (domain.Owner, error)
+	GetUserTrainingsOwners(ctx context.Context, userTrainingIDs []int) ([]int, error)
+	GetTrainerServiceOwner(ctx context.Context, serviceID int) (int, error)
+	GetUserTrainerServiceOwner(ctx context.Context, serviceID int) (domain.Owner, error)
+	GetSchedulesOwners(ctx context.Context, scheduleIDs []int) ([]domain.Owner, error)
+	IsClient(ctx context.Context, userID, trainerID int) (bool, error)
 }
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/pkg/customerr"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+// getTimezone возвращает часовой пояс IANA из профиля аккаунта. Его используют все репозитории расписания,
+// чтобы сервисы показывали время в часовом поясе того, кто его запрашивает
+func getTimezone(ctx context.Context, db *sqlx.DB, accountType string, accountID int) (string, error) {
+	var timezone string
+
+	table, ok := accountTable[accountType]
+	if !ok {
+		return "", errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`SELECT timezone FROM %s WHERE id = $1`, table)
+
+	if err := db.QueryRowContext(ctx, query, accountID).Scan(&timezone); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", accountNotFound[accountType]
+		}
+		return "", customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return timezone, nil
+}
@@ -0,0 +1,337 @@
+package repository
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/pkg/customerr"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"gopkg.in/guregu/null.v3"
+	"strings"
+)
+
+type trainerApplicationRepo struct {
+	db                 *sqlx.DB
+	entitiesPerRequest int
+}
+
+func InitTrainerApplicationRepo(
+	db *sqlx.DB,
+	entitiesPerRequest int,
+) TrainerApplications {
+	return &trainerApplicationRepo{
+		db:                 db,
+		entitiesPerRequest: entitiesPerRequest,
+	}
+}
+
+func (t trainerApplicationRepo) Create(ctx context.Context, application domain.TrainerApplicationCreate) (int, error) {
+	var (
+		createdID int
+		isTrainer bool
+	)
+
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	// Заявка от уже зарегистрированного тренера не имеет смысла
+	existsQuery := `SELECT EXISTS(SELECT 1 FROM trainers WHERE email = $1)`
+
+	if err = tx.QueryRowContext(ctx, existsQuery, application.Email).Scan(&isTrainer); err != nil {
+		tx.Rollback()
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	if isTrainer {
+		tx.Rollback()
+		return 0, errs.ErrAlreadyExist
+	}
+
+	createQuery := `INSERT INTO trainer_applications (email, first_name, last_name, age, sex, experience, quote)
+		VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id`
+
+	err = tx.QueryRowContext(ctx, createQuery, application.Email, application.FirstName, application.LastName,
+		application.Age, application.Sex, application.Experience, application.Quote).Scan(&createdID)
+	if err != nil {
+		tx.Rollback()
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return 0, errs.ErrAlreadyExist
+		}
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	rolesQuery := `INSERT INTO trainer_applications_roles (application_id, role_id)
+		SELECT $1, UNNEST($2::int[]) ON CONFLICT DO NOTHING`
+
+	if _, err = tx.ExecContext(ctx, rolesQuery, createdID, pq.Array(application.RoleIDs)); err != nil {
+		tx.Rollback()
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return 0, errs.ErrNoRole
+		}
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	specializationsQuery := `INSERT INTO trainer_applications_specializations (application_id, specialization_id)
+		SELECT $1, UNNEST($2::int[]) ON CONFLICT DO NOTHING`
+
+	if _, err = tx.ExecContext(ctx, specializationsQuery, createdID, pq.Array(application.SpecializationIDs)); err != nil {
+		tx.Rollback()
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return 0, errs.ErrNoSpecialization
+		}
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	if len(application.Certificates) > 0 {
+		names := make([]string, len(application.Certificates))
+		urls := make([]string, len(application.Certificates))
+		for i, certificate := range application.Certificates {
+			names[i] = certificate.Name
+			urls[i] = certificate.Url
+		}
+
+		certificatesQuery := `INSERT INTO trainer_applications_certificates (application_id, name, url)
+			SELECT $1, UNNEST($2::varchar[]), UNNEST($3::varchar[])`
+
+		if _, err = tx.ExecContext(ctx, certificatesQuery, createdID, pq.Array(names), pq.Array(urls)); err != nil {
+			tx.Rollback()
+			return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
+	return createdID, nil
+}
+
+func (t trainerApplicationRepo) GetByID(ctx context.Context, applicationID int) (domain.TrainerApplication, error) {
+	var (
+		application                          domain.TrainerApplication
+		roles, specializations, certificates []byte
+	)
+
+	selectQuery := `
+	SELECT ta.id, ta.email, ta.first_name, ta.last_name, ta.age, ta.sex, ta.experience, ta.quote, ta.status, ta.created_at,
+		ta.reason, ta.reviewed_by, ta.reviewed_at, ta.trainer_id,
+		jsonb_agg(DISTINCT jsonb_build_object('id', r.id, 'name', r.name)) FILTER (WHERE r.id IS NOT NULL) AS roles,
+		jsonb_agg(DISTINCT jsonb_build_object('id', s.id, 'name', s.name)) FILTER (WHERE s.id IS NOT NULL) AS specializations,
+		jsonb_agg(DISTINCT jsonb_build_object('id', c.id, 'name', c.name, 'url', c.url)) FILTER (WHERE c.id IS NOT NULL) AS certificates
+	FROM trainer_applications ta
+		LEFT JOIN trainer_applications_roles tar ON ta.id = tar.application_id
+		LEFT JOIN roles r ON tar.role_id = r.id
+		LEFT JOIN trainer_applications_specializations tas ON ta.id = tas.application_id
+		LEFT JOIN specializations s ON tas.specialization_id = s.id
+		LEFT JOIN trainer_applications_certificates c ON ta.id = c.application_id
+	WHERE ta.id = $1 GROUP BY ta.id`
+
+	err := t.db.QueryRowContext(ctx, selectQuery, applicationID).Scan(&application.ID, &application.Email,
+		&application.FirstName, &application.LastName, &application.Age, &application.Sex, &application.Experience,
+		&application.Quote, &application.Status, &application.CreatedAt, &application.Reason, &application.ReviewedBy,
+		&application.ReviewedAt, &application.TrainerID, &roles, &specializations, &certificates)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.TrainerApplication{}, errs.ErrNoApplication
+		}
+		return domain.TrainerApplication{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	if len(roles) > 0 {
+		if err := json.Unmarshal(roles, &application.Roles); err != nil {
+			return domain.TrainerApplication{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.JsonErr, Err: err})
+		}
+	}
+	if len(specializations) > 0 {
+		if err := json.Unmarshal(specializations, &application.Specializations); err != nil {
+			return domain.TrainerApplication{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.JsonErr, Err: err})
+		}
+	}
+	if len(certificates) > 0 {
+		if err := json.Unmarshal(certificates, &application.Certificates); err != nil {
+			return domain.TrainerApplication{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.JsonErr, Err: err})
+		}
+	}
+
+	return application, nil
+}
+
+func (t trainerApplicationRepo) GetCovers(ctx context.Context, filters domain.FiltersTrainerApplications) (domain.TrainerApplicationCoverPagination, error) {
+	selectQuery := `
+	SELECT id, email, first_name, last_name, age, sex, experience, quote, status, created_at
+	FROM trainer_applications
+	WHERE id >= $1 AND ($2 = '' OR status = $2)
+	ORDER BY id
+	LIMIT $3`
+
+	rows, err := t.db.QueryContext(ctx, selectQuery, filters.Cursor, filters.Status, t.entitiesPerRequest+1)
+	if err != nil {
+		return domain.TrainerApplicationCoverPagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	}
+	defer rows.Close()
+
+	var applications []domain.TrainerApplicationCover
+	for rows.Next() {
+		var application domain.TrainerApplicationCover
+
+		err := rows.Scan(&application.ID, &application.Email, &application.FirstName, &application.LastName, &application.Age,
+			&application.Sex, &application.Experience, &application.Quote, &application.Status, &application.CreatedAt)
+		if err != nil {
+			return domain.TrainerApplicationCoverPagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		}
+
+		applications = append(applications, application)
+	}
+
+	if err = rows.Err(); err != nil {
+		return domain.TrainerApplicationCoverPagination{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	var nextCursor int
+	if len(applications) == t.entitiesPerRequest+1 {
+		nextCursor = applications[t.entitiesPerRequest].ID
+		applications = applications[:t.entitiesPerRequest]
+	}
+
+	return domain.TrainerApplicationCoverPagination{
+		Applications: applications,
+		Cursor:       nextCursor,
+	}, nil
+}
+
+// Approve одобряет заявку и создает по ней тренера с ролями и специализациями одной транзакцией: при ошибке
+// на любом шаге тренер не создается, а заявка остается на рассмотрении. Условие на статус не дает одобрить заявку дважды
+func (t trainerApplicationRepo) Approve(ctx context.Context, applicationID, adminID int, trainer domain.TrainerCreate, roleIDs, specializationIDs []int) (int, error) {
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	claimQuery := `UPDATE trainer_applications SET status = $1, reviewed_by = $2, reviewed_at = NOW()
+		WHERE id = $3 AND status = $4`
+
+	if err = review(ctx, tx, claimQuery, domain.ApplicationApproved, adminID, applicationID, domain.ApplicationPending); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	trainerID, err := createTrainer(ctx, tx, trainer)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if _, err = tx.ExecContext(ctx, `UPDATE trainer_applications SET trainer_id = $1 WHERE id = $2`, trainerID, applicationID); err != nil {
+		tx.Rollback()
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	if err = replaceTrainerBases(ctx, tx, trainersRoles, trainerID, roleIDs); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err = replaceTrainerBases(ctx, tx, trainersSpecializations, trainerID, specializationIDs); err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
+	return trainerID, nil
+}
+
+func (t trainerApplicationRepo) Reject(ctx context.Context, applicationID, adminID int, reason string) error {
+	query := `UPDATE trainer_applications SET status = $1, reviewed_by = $2, reason = $3, reviewed_at = NOW()
+		WHERE id = $4 AND status = $5`
+
+	return review(ctx, t.db, query, domain.ApplicationRejected, adminID, null.NewString(reason, true), applicationID,
+		domain.ApplicationPending)
+}
+
+// review выполняет смену статуса. Заявка заранее получена через GetByID, поэтому 0 строк означает, что ее уже рассмотрели
+func review(ctx context.Context, db sqlx.ExecerContext, query string, args ...any) error {
+	res, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		return errs.ErrApplicationReviewed
+	}
+
+	return nil
+}
+
+// trainerBaseLink - таблица связи тренера с ролями или специализациями
+type trainerBaseLink struct {
+	table    string
+	column   string
+	notFound error
+}
+
+var (
+	trainersRoles           = trainerBaseLink{table: "trainers_roles", column: "role_id", notFound: errs.ErrNoRole}
+	trainersSpecializations = trainerBaseLink{table: "trainers_specializations", column: "specialization_id", notFound: errs.ErrNoSpecialization}
+)
+
+// replaceTrainerBases заменяет роли или специализации нового тренера в транзакции одобрения заявки. Транзакцию откатывает вызывающий
+func replaceTrainerBases(ctx context.Context, tx *sqlx.Tx, link trainerBaseLink, trainerID int, ids []int) error {
+	deleteQuery := fmt.Sprintf(`DELETE FROM %s WHERE trainer_id = $1`, link.table)
+	if _, err := tx.ExecContext(ctx, deleteQuery, trainerID); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	valueStrings := make([]string, 0, len(ids))
+	valueArgs := make([]interface{}, 0, len(ids)+1)
+	valueArgs = append(valueArgs, trainerID)
+	for i, id := range ids {
+		valueStrings = append(valueStrings, fmt.Sprintf("($1, $%d)", i+2))
+		valueArgs = append(valueArgs, id)
+	}
+
+	addQuery := fmt.Sprintf("INSERT INTO %s (trainer_id, %s) VALUES %s", link.table, link.column, strings.Join(valueStrings, ","))
+	res, err := tx.ExecContext(ctx, addQuery, valueArgs...)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return errs.ErrAlreadyExist
+		}
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" {
+			return link.notFound
+		}
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if int(count) != len(ids) {
+		return link.notFound
+	}
+
+	return nil
+}
@@ -32,6 +32,11 @@ func InitTrainerRepo(
 }
 
 func (t trainerRepo) Create(ctx context.Context, trainer domain.TrainerCreate) (int, error) {
+	return createTrainer(ctx, t.db, trainer)
+}
+
+// createTrainer добавляет тренера через db или транзакцию: ее использует одобрение заявки тренера
+func createTrainer(ctx context.Context, db sqlx.ExtContext, trainer domain.TrainerCreate) (int, error) {
 	var createdID int
 
 	hashedPassword := utils.HashPassword(trainer.Password)
@@ -39,7 +44,7 @@ func (t trainerRepo) Create(ctx context.Context, trainer domain.TrainerCreate) (
 	createQuery := ` INSERT INTO trainers (email, password, first_name, last_name, age, sex, experience, quote)
  		VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id`
 
-	err := t.db.QueryRowContext(ctx, createQuery, trainer.Email, hashedPassword, trainer.FirstName, trainer.LastName,
+	err := db.QueryRowxContext(ctx, createQuery, trainer.Email, hashedPassword, trainer.FirstName, trainer.LastName,
 		trainer.Age, trainer.Sex, trainer.Experience, trainer.Quote).Scan(&createdID)
 	if err != nil {
 		var pqErr *pq.Error
@@ -52,21 +57,83 @@ func (t trainerRepo) Create(ctx context.Context, trainer domain.TrainerCreate) (
 	return createdID, nil
 }
 
-func (t trainerRepo) GetSecure(ctx context.Context, email string) (int, string, error) {
-	var hashedPassword string
-	var id int
+func (t trainerRepo) GetSecure(ctx context.Context, email string) (domain.Secure, error) {
+	var secure domain.Secure
 
-	getQuery := `SELECT id, password FROM trainers WHERE email = $1`
+	getQuery := `SELECT id, password, is_verified,
+		suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > NOW())
+		FROM trainers WHERE email = $1`
 
-	err := t.db.QueryRowContext(ctx, getQuery, email).Scan(&id, &hashedPassword)
+	err := t.db.QueryRowContext(ctx, getQuery, email).Scan(&secure.ID, &secure.Password, &secure.IsVerified, &secure.IsSuspended)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return 0, "", errs.InvalidEmail
+			return domain.Secure{}, errs.InvalidEmail
 		}
-		return 0, "", customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		return domain.Secure{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return secure, nil
+}
+
+func (t trainerRepo) GetVerification(ctx context.Context, id int) (string, bool, error) {
+	var (
+		email      string
+		isVerified bool
+	)
+
+	getQuery := `SELECT email, is_verified FROM trainers WHERE id = $1`
+
+	err := t.db.QueryRowContext(ctx, getQuery, id).Scan(&email, &isVerified)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, errs.ErrNoTrainer
+		}
+		return "", false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return email, isVerified, nil
+}
+
+func (t trainerRepo) SetVerified(ctx context.Context, id int) error {
+	updateQuery := `UPDATE trainers SET is_verified = TRUE WHERE id = $1`
+
+	res, err := t.db.ExecContext(ctx, updateQuery, id)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
 	}
 
-	return id, hashedPassword, nil
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		return errs.ErrNoTrainer
+	}
+
+	return nil
+}
+
+func (t trainerRepo) UpdatePassword(ctx context.Context, id int, password string) error {
+	hashedPassword := utils.HashPassword(password)
+
+	updateQuery := `UPDATE trainers SET password = $1 WHERE id = $2`
+
+	res, err := t.db.ExecContext(ctx, updateQuery, hashedPassword, id)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		return errs.ErrNoTrainer
+	}
+
+	return nil
 }
 
 func (t trainerRepo) GetByID(ctx context.Context, trainerID int) (domain.Trainer, error) {
@@ -76,7 +143,7 @@ func (t trainerRepo) GetByID(ctx context.Context, trainerID int) (domain.Trainer
 	)
 
 	selectQuery := `
-	SELECT email, first_name, last_name, age, sex, experience, quote, photo_url,
+	SELECT email, first_name, last_name, age, sex, experience, quote, photo_url, timezone,
 		jsonb_agg(DISTINCT jsonb_build_object('id', r.id, 'name', r.name)) FILTER (WHERE r.id IS NOT NULL AND r.name IS NOT NULL) AS roles,
 		jsonb_agg(DISTINCT jsonb_build_object('id', s.id, 'name', s.name)) FILTER (WHERE s.id IS NOT NULL AND s.name IS NOT NULL) AS specializations,
 		jsonb_agg(DISTINCT jsonb_build_object('id', serv.id, 'name', serv.name, 'price', serv.price, 'profile_access', serv.profile_access)) FILTER (WHERE serv.id IS NOT NULL AND serv.name IS NOT NULL) AS services,
@@ -91,7 +158,7 @@ func (t trainerRepo) GetByID(ctx context.Context, trainerID int) (domain.Trainer
 	WHERE t.id = $1 GROUP BY t.id`
 
 	err := t.db.QueryRowxContext(ctx, selectQuery, trainerID).Scan(&trainer.Email, &trainer.FirstName, &trainer.LastName,
-		&trainer.Age, &trainer.Sex, &trainer.Experience, &trainer.Quote, &trainer.PhotoUrl, &roles, &specializations, &services, &achievements)
+		&trainer.Age, &trainer.Sex, &trainer.Experience, &trainer.Quote, &trainer.PhotoUrl, &trainer.Timezone, &roles, &specializations, &services, &achievements)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.Trainer{}, errs.ErrNoTrainer
@@ -198,10 +265,10 @@ func (t trainerRepo) UpdateMain(ctx context.Context, trainer domain.TrainerUpdat
 	}
 
 	updateQuery := `UPDATE trainers SET email = $1, first_name = $2, last_name = $3, age = $4, sex = $5,
-		experience = $6, quote = $7 WHERE id = $8`
+		experience = $6, quote = $7, timezone = COALESCE($9, timezone) WHERE id = $8`
 
 	res, err := tx.ExecContext(ctx, updateQuery, trainer.Email, trainer.FirstName, trainer.LastName, trainer.Age,
-		trainer.Sex, trainer.Experience, trainer.Quote, trainer.ID)
+		trainer.Sex, trainer.Experience, trainer.Quote, trainer.ID, trainer.Timezone)
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return customerr.ErrNormalizer(
@@ -453,15 +520,16 @@ func (t trainerRepo) CreateService(ctx context.Context, service domain.ServiceCr
 	return createdID, nil
 }
 
-func (t trainerRepo) UpdateService(ctx context.Context, service domain.ServiceUpdate) error {
+// UpdateService меняет услугу тренера trainerID. Чужая услуга не найдется и вернет errs.ErrNoService
+func (t trainerRepo) UpdateService(ctx context.Context, trainerID int, service domain.ServiceUpdate) error {
 	tx, err := t.db.Beginx()
 	if err != nil {
 		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
 	}
 
-	updateQuery := `UPDATE services SET name = $1, price = $2, profile_access = $3 WHERE id = $4`
+	updateQuery := `UPDATE services SET name = $1, price = $2, profile_access = $3 WHERE id = $4 AND trainer_id = $5`
 
-	res, err := tx.ExecContext(ctx, updateQuery, service.Name, service.Price, service.ProfileAccess, service.ID)
+	res, err := tx.ExecContext(ctx, updateQuery, service.Name, service.Price, service.ProfileAccess, service.ID, trainerID)
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return customerr.ErrNormalizer(
@@ -558,15 +626,15 @@ func (t trainerRepo) CreateAchievement(ctx context.Context, trainerID int, achie
 	return createdID, nil
 }
 
-func (t trainerRepo) UpdateAchievementStatus(ctx context.Context, achievementID int, status bool) error {
+func (t trainerRepo) UpdateAchievementStatus(ctx context.Context, adminID, achievementID int, status bool) error {
 	tx, err := t.db.Beginx()
 	if err != nil {
 		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
 	}
 
-	updateQuery := `UPDATE achievements SET is_confirmed = $1 WHERE id = $2`
+	updateQuery := `UPDATE achievements SET is_confirmed = $1, confirmed_by = $2 WHERE id = $3`
 
-	res, err := tx.ExecContext(ctx, updateQuery, status, achievementID)
+	res, err := tx.ExecContext(ctx, updateQuery, status, adminID, achievementID)
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return customerr.ErrNormalizer(
@@ -664,9 +732,9 @@ func (t trainerRepo) CreateServiceUser(ctx context.Context, service domain.UserT
 func (t trainerRepo) Schedule(ctx context.Context, schedule domain.ScheduleService) (int, error) {
 	var createdID int
 
-	createQuery := `INSERT INTO trainer_users_trainers_services (users_trainers_services_id, date, time_start, time_end) VALUES ($1, $2, $3, $4) RETURNING id`
+	createQuery := `INSERT INTO trainer_users_trainers_services (users_trainers_services_id, time_start, time_end) VALUES ($1, $2, $3) RETURNING id`
 
-	err := t.db.QueryRowContext(ctx, createQuery, schedule.ScheduleID, schedule.Date, schedule.TimeStart, schedule.TimeEnd).Scan(&createdID)
+	err := t.db.QueryRowContext(ctx, createQuery, schedule.ScheduleID, schedule.TimeStart, schedule.TimeEnd).Scan(&createdID)
 	if err != nil {
 		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
 	}
@@ -674,46 +742,6 @@ func (t trainerRepo) Schedule(ctx context.Context, schedule domain.ScheduleServi
 	return createdID, nil
 }
 
-func (t trainerRepo) GetSchedule(ctx context.Context, month int) ([]domain.TrainingSchedule, error) {
-	var schedules []domain.TrainingSchedule
-
-	query := `
-		SELECT date, array_agg(id) AS training_ids
-		FROM trainer_users_trainers_services
-		WHERE EXTRACT(MONTH FROM date) = $1
-		GROUP BY date
-		ORDER BY date
-	`
-	rows, err := t.db.QueryContext(ctx, query, month)
-	if err != nil {
-		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
-	}
-	defer rows.Close()
-
-	for rows.Next() {
-		var schedule domain.TrainingSchedule
-		var trainingIDs pq.Int64Array
-		err := rows.Scan(&schedule.Date, &trainingIDs)
-		if err != nil {
-			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
-		}
-
-		// Преобразование pq.Int64Array в []int
-		schedule.TrainingIDs = make([]int, len(trainingIDs))
-		for i, id := range trainingIDs {
-			schedule.TrainingIDs[i] = int(id)
-		}
-
-		schedules = append(schedules, schedule)
-	}
-
-	if err = rows.Err(); err != nil {
-		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
-	}
-
-	return schedules, nil
-}
-
 func (t trainerRepo) DeleteScheduled(ctx context.Context, scheduleID int) error {
 	query := `DELETE FROM trainer_users_trainers_services WHERE id = $1`
 
@@ -775,7 +803,7 @@ func (t trainerRepo) GetSchedulesByIDs(ctx context.Context, scheduleIDs []int) (
 	query := `
 	SELECT uts.id, uts.user_id, uts.trainer_id, uts.service_id, uts.is_payed, uts.trainer_confirm, uts.user_confirm,
 	       t.id, t.name, t.price, u.id, u.first_name, u.last_name, u.age, u.sex, u.photo_url,
-	       tuts.id, tuts.date, tuts.time_start, tuts.time_end
+	       tuts.id, tuts.time_start, tuts.time_end
 	FROM trainer_users_trainers_services tuts
 		JOIN users_trainers_services uts ON tuts.users_trainers_services_id = uts.id
 		JOIN users u ON uts.user_id = u.id
@@ -794,7 +822,7 @@ func (t trainerRepo) GetSchedulesByIDs(ctx context.Context, scheduleIDs []int) (
 
 		err := rows.Scan(&service.ID, &service.UserID, &service.TrainerID, &service.ScheduleID, &service.IsPayed, &service.TrainerConfirm, &service.UserConfirm,
 			&service.Service.ID, &service.Service.Name, &service.Service.Price, &service.User.ID, &service.User.FirstName, &service.User.LastName,
-			&service.User.Age, &service.User.Sex, &service.User.PhotoUrl, &service.ScheduleID, &service.Date, &service.TimeStart, &service.TimeEnd)
+			&service.User.Age, &service.User.Sex, &service.User.PhotoUrl, &service.ScheduleID, &service.TimeStart, &service.TimeEnd)
 		if err != nil {
 			return nil, err
 		}
@@ -5,10 +5,13 @@ import (
 	"BACKEND/internal/models/domain"
 	"BACKEND/pkg/customerr"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
+	"gopkg.in/guregu/null.v3"
 	"strings"
 	"time"
 )
@@ -437,7 +440,7 @@ func (t trainingRepo) GetTrainingCoversByTrainerID(ctx context.Context, search s
 
 func (t trainingRepo) GetScheduleTrainings(ctx context.Context, userTrainingIDs []int) ([]domain.UserTraining, error) {
 	query := `
-	SELECT ut.id, t.id, t.name, t.description, ut.date, ut.time_start, ut.time_end,
+	SELECT ut.id, t.id, t.name, t.description, ut.time_start, ut.time_end,
 	       e.id, e.name, e.muscle, e.additional_muscle, e.type, e.equipment, e.difficulty,
 	       e.photos, ute.sets, ute.reps, ute.weight, ute.status, te.step
 	FROM users_trainings ut
@@ -446,7 +449,7 @@ func (t trainingRepo) GetScheduleTrainings(ctx context.Context, userTrainingIDs
 		JOIN exercises e ON e.id = ute.exercise_id
 		JOIN trainings_exercises te ON te.training_id = t.id AND te.exercise_id = e.id
 	WHERE ut.id = ANY($1)
-	ORDER BY ut.date, ut.time_start, te.step
+	ORDER BY ut.time_start, te.step
 	`
 	rows, err := t.db.QueryContext(ctx, query, pq.Array(userTrainingIDs))
 	if err != nil {
@@ -460,7 +463,7 @@ func (t trainingRepo) GetScheduleTrainings(ctx context.Context, userTrainingIDs
 	for rows.Next() {
 		var exercise domain.Exercise
 
-		err := rows.Scan(&training.ID, &training.TrainingID, &training.Name, &training.Description, &training.Date, &training.TimeStart, &training.TimeEnd,
+		err := rows.Scan(&training.ID, &training.TrainingID, &training.Name, &training.Description, &training.TimeStart, &training.TimeEnd,
 			&exercise.ID, &exercise.Name, &exercise.Muscle, &exercise.AdditionalMuscle, &exercise.Type, &exercise.Equipment, &exercise.Difficulty,
 			pq.Array(&exercise.Photos), &exercise.Sets, &exercise.Reps, &exercise.Weight, &exercise.Status, &exercise.Step)
 		if err != nil {
@@ -584,10 +587,10 @@ func (t trainingRepo) ScheduleTraining(ctx context.Context, training domain.Sche
 
 	// Вставка записи в таблицу users_trainings
 	query := `
-		INSERT INTO users_trainings (user_id, training_id, date, time_start, time_end)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO users_trainings (user_id, training_id, time_start, time_end)
+		VALUES ($1, $2, $3, $4)
 		RETURNING id`
-	err = tx.QueryRowContext(ctx, query, training.UserID, training.TrainingID, training.Date, training.TimeStart, training.TimeEnd).Scan(&userTrainingID)
+	err = tx.QueryRowContext(ctx, query, training.UserID, training.TrainingID, training.TimeStart, training.TimeEnd).Scan(&userTrainingID)
 	if err != nil {
 		tx.Rollback()
 		return 0, nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
@@ -627,62 +630,274 @@ func (t trainingRepo) ScheduleTraining(ctx context.Context, training domain.Sche
 	return userTrainingID, userTrainingExerciseIDs, nil
 }
 
-func (t trainingRepo) GetSchedule(ctx context.Context, month, userID int) ([]domain.TrainingSchedule, error) {
+func (t trainingRepo) DeleteUserTraining(ctx context.Context, trainingID int) error {
+	query := `DELETE FROM trainings WHERE id = $1`
+	_, err := t.db.ExecContext(ctx, query, trainingID)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	return nil
+}
+
+// DeleteScheduledTraining удаляет тренировку из расписания. Если это сохраненное вхождение серии,
+// его исходный день отменяется, иначе вхождение снова появится в календаре
+func (t trainingRepo) DeleteScheduledTraining(ctx context.Context, userTrainingID int) error {
 	query := `
-		SELECT date, array_agg(id) AS training_ids
-		FROM users_trainings
-		WHERE EXTRACT(MONTH FROM date) = $1 AND user_id = $2
-		GROUP BY date
-		ORDER BY date
-	`
-	rows, err := t.db.QueryContext(ctx, query, month, userID)
+		WITH deleted AS (
+			DELETE FROM users_trainings WHERE id = $1
+			RETURNING series_id, occurrence_date
+		)
+		INSERT INTO users_trainings_series_exdates (series_id, date)
+		SELECT series_id, occurrence_date FROM deleted WHERE series_id IS NOT NULL
+		ON CONFLICT DO NOTHING`
+	_, err := t.db.ExecContext(ctx, query, userTrainingID)
 	if err != nil {
-		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
 	}
-	defer rows.Close()
 
-	var schedules []domain.TrainingSchedule
-	for rows.Next() {
-		var schedule domain.TrainingSchedule
-		var trainingIDs pq.Int64Array
-		err := rows.Scan(&schedule.Date, &trainingIDs)
+	return nil
+}
+
+// seriesColumns поля серии в порядке scanSeries. Ожидает псевдонимы s для серии и t для тренировки
+const seriesColumns = `
+	s.id, s.user_id, s.training_id, t.name, s.date_start, s.date_end, s.time_start, s.time_end, s.rrule, s.timezone,
+	ARRAY(SELECT to_char(e.date, 'YYYY-MM-DD') FROM users_trainings_series_exdates e WHERE e.series_id = s.id),
+	ARRAY(SELECT to_char(ut.occurrence_date, 'YYYY-MM-DD') FROM users_trainings ut WHERE ut.series_id = s.id)`
+
+func scanSeries(row interface{ Scan(dest ...any) error }) (domain.TrainingSeries, error) {
+	var series domain.TrainingSeries
+	var cancelled, overridden pq.StringArray
+
+	err := row.Scan(&series.ID, &series.UserID, &series.TrainingID, &series.Title, &series.DateStart, &series.DateEnd,
+		&series.TimeStart, &series.TimeEnd, &series.RRule, &series.Timezone, &cancelled, &overridden)
+	if err != nil {
+		return domain.TrainingSeries{}, err
+	}
+
+	if series.Cancelled, err = parseDates(cancelled); err != nil {
+		return domain.TrainingSeries{}, err
+	}
+	if series.Overridden, err = parseDates(overridden); err != nil {
+		return domain.TrainingSeries{}, err
+	}
+
+	return series, nil
+}
+
+func parseDates(values []string) ([]time.Time, error) {
+	dates := make([]time.Time, 0, len(values))
+	for _, value := range values {
+		date, err := time.Parse(time.DateOnly, value)
 		if err != nil {
-			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+			return nil, err
+		}
+		dates = append(dates, date)
+	}
+
+	return dates, nil
+}
+
+// CreateSeries создает серию повторяющихся тренировок с шаблоном упражнений для ее вхождений.
+// Время серии остается местным в часовом поясе, который сейчас указан в профиле пользователя
+func (t trainingRepo) CreateSeries(ctx context.Context, training domain.ScheduleTraining, dateEnd null.Time) (int, error) {
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	var seriesID int
+	query := `
+		INSERT INTO users_trainings_series (user_id, training_id, date_start, date_end, time_start, time_end, rrule, timezone)
+		SELECT $1, $2, $3, $4, $5, $6, $7, timezone FROM users WHERE id = $1
+		RETURNING id`
+	err = tx.QueryRowContext(ctx, query, training.UserID, training.TrainingID, training.TimeStart.Format(time.DateOnly), dateEnd,
+		training.TimeStart.Format(time.TimeOnly), training.TimeEnd.Format(time.TimeOnly), training.RRule.String).Scan(&seriesID)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errs.ErrNoUser
+		}
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	if len(training.Exercises) > 0 {
+		valueStrings := make([]string, 0, len(training.Exercises))
+		valueArgs := make([]interface{}, 0, len(training.Exercises)*5)
+		for i, exercise := range training.Exercises {
+			valueStrings = append(valueStrings, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d)", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5))
+			valueArgs = append(valueArgs, seriesID, exercise.ExerciseID, exercise.Sets, exercise.Reps, exercise.Weight)
 		}
 
-		// Преобразование pq.Int64Array в []int
-		schedule.TrainingIDs = make([]int, len(trainingIDs))
-		for i, id := range trainingIDs {
-			schedule.TrainingIDs[i] = int(id)
+		query = fmt.Sprintf("INSERT INTO users_trainings_series_exercises (series_id, exercise_id, sets, reps, weight) VALUES %s", strings.Join(valueStrings, ","))
+		if _, err = tx.ExecContext(ctx, query, valueArgs...); err != nil {
+			tx.Rollback()
+			return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
 		}
+	}
 
-		schedules = append(schedules, schedule)
+	if err = tx.Commit(); err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	return seriesID, nil
+}
+
+func (t trainingRepo) GetSeries(ctx context.Context, userID, seriesID int) (domain.TrainingSeries, error) {
+	query := `
+		SELECT ` + seriesColumns + `
+		FROM users_trainings_series s
+			JOIN trainings t ON t.id = s.training_id
+		WHERE s.id = $1 AND s.user_id = $2`
+
+	series, err := scanSeries(t.db.QueryRowContext(ctx, query, seriesID, userID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.TrainingSeries{}, errs.ErrNoSeries
+		}
+		return domain.TrainingSeries{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
 	}
 
-	return schedules, nil
+	return series, nil
 }
 
-func (t trainingRepo) DeleteUserTraining(ctx context.Context, trainingID int) error {
-	query := `DELETE FROM trainings WHERE id = $1`
-	_, err := t.db.ExecContext(ctx, query, trainingID)
+// UpdateSeries меняет правило и время всей серии. Новое время задано в текущем часовом поясе пользователя,
+// поэтому он переносится в серию. Отдельно сохраненные и отмененные вхождения не затрагиваются
+func (t trainingRepo) UpdateSeries(ctx context.Context, series domain.TrainingSeries) error {
+	query := `
+		UPDATE users_trainings_series s
+		SET date_start = $3, date_end = $4, time_start = $5, time_end = $6, rrule = $7, timezone = u.timezone
+		FROM users u
+		WHERE s.id = $1 AND s.user_id = $2 AND u.id = s.user_id`
+	res, err := t.db.ExecContext(ctx, query, series.ID, series.UserID, series.DateStart.Format(time.DateOnly), series.DateEnd,
+		series.TimeStart.Format(time.TimeOnly), series.TimeEnd.Format(time.TimeOnly), series.RRule)
 	if err != nil {
 		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
 	}
 
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+	if count == 0 {
+		return errs.ErrNoSeries
+	}
+
 	return nil
 }
 
-func (t trainingRepo) DeleteScheduledTraining(ctx context.Context, userTrainingID int) error {
-	query := `DELETE FROM users_trainings WHERE id = $1 `
-	_, err := t.db.ExecContext(ctx, query, userTrainingID)
+// DeleteSeries удаляет серию. Сохраненные вхождения остаются отдельными тренировками
+func (t trainingRepo) DeleteSeries(ctx context.Context, userID, seriesID int) error {
+	query := `DELETE FROM users_trainings_series WHERE id = $1 AND user_id = $2`
+	res, err := t.db.ExecContext(ctx, query, seriesID, userID)
 	if err != nil {
 		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
 	}
 
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+	if count == 0 {
+		return errs.ErrNoSeries
+	}
+
+	return nil
+}
+
+// SaveOccurrence переносит вхождение серии. При первом изменении вхождение сохраняется в users_trainings
+// вместе с упражнениями из шаблона серии, после чего по нему можно отмечать прогресс
+func (t trainingRepo) SaveOccurrence(ctx context.Context, occurrence domain.TrainingOccurrence) (int, error) {
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	occurrenceDate := occurrence.OccurrenceDate.Format(time.DateOnly)
+
+	var userTrainingID int
+	query := `
+		UPDATE users_trainings
+		SET time_start = $3, time_end = $4
+		WHERE series_id = $1 AND occurrence_date = $2
+		RETURNING id`
+	err = tx.QueryRowContext(ctx, query, occurrence.SeriesID, occurrenceDate, occurrence.TimeStart, occurrence.TimeEnd).Scan(&userTrainingID)
+	if err == nil {
+		if err = tx.Commit(); err != nil {
+			return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+		}
+		return userTrainingID, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	query = `
+		INSERT INTO users_trainings (user_id, training_id, time_start, time_end, series_id, occurrence_date)
+		SELECT user_id, training_id, $3, $4, id, $2
+		FROM users_trainings_series
+		WHERE id = $1
+		RETURNING id`
+	err = tx.QueryRowContext(ctx, query, occurrence.SeriesID, occurrenceDate, occurrence.TimeStart, occurrence.TimeEnd).Scan(&userTrainingID)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errs.ErrNoSeries
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return 0, errs.ErrAlreadyExist
+		}
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	query = `
+		INSERT INTO user_trainings_exercises (users_trainings_id, exercise_id, sets, reps, weight)
+		SELECT $1, exercise_id, sets, reps, weight
+		FROM users_trainings_series_exercises
+		WHERE series_id = $2
+		ORDER BY id`
+	if _, err = tx.ExecContext(ctx, query, userTrainingID, occurrence.SeriesID); err != nil {
+		tx.Rollback()
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
+	return userTrainingID, nil
+}
+
+// CancelOccurrence отменяет вхождение серии, удаляя и его сохраненную запись, если она есть
+func (t trainingRepo) CancelOccurrence(ctx context.Context, seriesID int, occurrenceDate time.Time) error {
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	date := occurrenceDate.Format(time.DateOnly)
+
+	query := `
+		INSERT INTO users_trainings_series_exdates (series_id, date)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
+	if _, err = tx.ExecContext(ctx, query, seriesID, date); err != nil {
+		tx.Rollback()
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	query = `DELETE FROM users_trainings WHERE series_id = $1 AND occurrence_date = $2`
+	if _, err = tx.ExecContext(ctx, query, seriesID, date); err != nil {
+		tx.Rollback()
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	if err = tx.Commit(); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
 	return nil
 }
 
@@ -806,6 +1021,7 @@ func (t trainingRepo) DeletePlan(ctx context.Context, planID int) error {
 	return nil
 }
 
+// GetProgress группирует прогресс по дням тренировок в часовом поясе пользователя
 func (t trainingRepo) GetProgress(ctx context.Context, filters domain.FiltersProgress) (domain.ProgressPagination, error) {
 	query := `
 	SELECT name, data
@@ -816,11 +1032,13 @@ func (t trainingRepo) GetProgress(ctx context.Context, filters domain.FiltersPro
 	        COUNT(*) AS array_length
 	    FROM exercises e
 	    JOIN (
-	        SELECT ute.exercise_id, ut.date, ute.weight, ute.reps, ute.sets
+	        SELECT ute.exercise_id, training_day.date, ute.weight, ute.reps, ute.sets
 	        FROM user_trainings_exercises ute
 	             JOIN users_trainings ut ON ute.users_trainings_id = ut.id
-	        WHERE ut.user_id = $1 AND ut.date BETWEEN $2 AND $3
-	        ORDER BY ut.date
+	             JOIN users u ON u.id = ut.user_id
+	             CROSS JOIN LATERAL (SELECT (ut.time_start AT TIME ZONE u.timezone)::date AS date) training_day
+	        WHERE ut.user_id = $1 AND training_day.date BETWEEN $2 AND $3
+	        ORDER BY training_day.date
 	    ) sub ON e.id = sub.exercise_id
 	    WHERE e.name LIKE '%' || $4 || '%'
 	    GROUP BY e.id, e.name
@@ -882,3 +1100,7 @@ func (t trainingRepo) GetProgress(ctx context.Context, filters domain.FiltersPro
 		IsMore:     isMore,
 	}, nil
 }
+
+func (t trainingRepo) GetTimezone(ctx context.Context, accountType string, accountID int) (string, error) {
+	return getTimezone(ctx, t.db, accountType, accountID)
+}
@@ -0,0 +1,241 @@
+package repository
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/pkg/customerr"
+	"BACKEND/pkg/utils"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+)
+
+// Колонки two_factor и таблицы аккаунтов по типу аккаунта
+var (
+	twoFactorOwnerColumn = map[string]string{
+		utils.Trainer: "trainer_id",
+		utils.Admin:   "admin_id",
+	}
+	twoFactorOwnerTable = map[string]string{
+		utils.Trainer: "trainers",
+		utils.Admin:   "admins",
+	}
+)
+
+type twoFactorRepo struct {
+	db *sqlx.DB
+}
+
+func InitTwoFactorRepo(
+	db *sqlx.DB,
+) TwoFactor {
+	return &twoFactorRepo{
+		db: db,
+	}
+}
+
+func (t twoFactorRepo) GetEmail(ctx context.Context, accountType string, accountID int) (string, error) {
+	var email string
+
+	table, ok := twoFactorOwnerTable[accountType]
+	if !ok {
+		return "", errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`SELECT email FROM %s WHERE id = $1`, table)
+
+	err := t.db.QueryRowContext(ctx, query, accountID).Scan(&email)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", errs.ErrNoUser
+		}
+		return "", customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return email, nil
+}
+
+func (t twoFactorRepo) Get(ctx context.Context, accountType string, accountID int) (domain.TwoFactor, error) {
+	var twoFactor domain.TwoFactor
+
+	column, ok := twoFactorOwnerColumn[accountType]
+	if !ok {
+		return domain.TwoFactor{}, errs.ErrNoTwoFactor
+	}
+
+	query := fmt.Sprintf(`SELECT id, secret, is_enabled, last_used_step FROM two_factor WHERE %s = $1`, column)
+
+	err := t.db.QueryRowContext(ctx, query, accountID).Scan(&twoFactor.ID, &twoFactor.Secret, &twoFactor.IsEnabled, &twoFactor.LastUsedStep)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.TwoFactor{}, errs.ErrNoTwoFactor
+		}
+		return domain.TwoFactor{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return twoFactor, nil
+}
+
+// SetSecret сохраняет новый секрет, пока двухфакторная аутентификация не включена
+func (t twoFactorRepo) SetSecret(ctx context.Context, accountType string, accountID int, secret string) error {
+	column, ok := twoFactorOwnerColumn[accountType]
+	if !ok {
+		return errs.ErrForbidden
+	}
+
+	query := fmt.Sprintf(`INSERT INTO two_factor (%[1]s, secret) VALUES ($1, $2)
+		ON CONFLICT (%[1]s) DO UPDATE SET secret = excluded.secret, last_used_step = 0
+		WHERE two_factor.is_enabled = FALSE`, column)
+
+	res, err := t.db.ExecContext(ctx, query, accountID, secret)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		return errs.ErrTwoFactorEnabled
+	}
+
+	return nil
+}
+
+func (t twoFactorRepo) Enable(ctx context.Context, twoFactorID int, step int64, codeHashes []string) error {
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	updateQuery := `UPDATE two_factor SET is_enabled = TRUE, last_used_step = $1 WHERE id = $2 AND is_enabled = FALSE`
+
+	res, err := tx.ExecContext(ctx, updateQuery, step, twoFactorID)
+	if err != nil {
+		tx.Rollback()
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		tx.Rollback()
+		return errs.ErrTwoFactorEnabled
+	}
+
+	if err = replaceRecoveryCodes(ctx, tx, twoFactorID, codeHashes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
+	return nil
+}
+
+// UseStep отмечает шаг TOTP использованным. Повторный или более старый код отклоняется
+func (t twoFactorRepo) UseStep(ctx context.Context, twoFactorID int, step int64) error {
+	query := `UPDATE two_factor SET last_used_step = $1 WHERE id = $2 AND last_used_step < $1`
+
+	res, err := t.db.ExecContext(ctx, query, step, twoFactorID)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		return errs.ErrInvalidCode
+	}
+
+	return nil
+}
+
+func (t twoFactorRepo) UseRecoveryCode(ctx context.Context, twoFactorID int, codeHash string) error {
+	query := `UPDATE two_factor_recovery_codes SET used_at = NOW()
+		WHERE two_factor_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	res, err := t.db.ExecContext(ctx, query, twoFactorID, codeHash)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count == 0 {
+		return errs.ErrInvalidCode
+	}
+
+	return nil
+}
+
+func (t twoFactorRepo) ReplaceRecoveryCodes(ctx context.Context, twoFactorID int, codeHashes []string) error {
+	tx, err := t.db.Beginx()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
+	}
+
+	if err = replaceRecoveryCodes(ctx, tx, twoFactorID, codeHashes); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
+	}
+
+	return nil
+}
+
+func (t twoFactorRepo) Delete(ctx context.Context, twoFactorID int) error {
+	query := `DELETE FROM two_factor WHERE id = $1`
+
+	res, err := t.db.ExecContext(ctx, query, twoFactorID)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		return errs.ErrNoTwoFactor
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, tx *sqlx.Tx, twoFactorID int, codeHashes []string) error {
+	deleteQuery := `DELETE FROM two_factor_recovery_codes WHERE two_factor_id = $1`
+
+	if _, err := tx.ExecContext(ctx, deleteQuery, twoFactorID); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	insertQuery := `INSERT INTO two_factor_recovery_codes (two_factor_id, code_hash) SELECT $1, UNNEST($2::VARCHAR[])`
+
+	if _, err := tx.ExecContext(ctx, insertQuery, twoFactorID, pq.Array(codeHashes)); err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	return nil
+}
@@ -51,11 +51,11 @@ func (u userRepo) Create(ctx context.Context, user domain.UserCreate) (int, erro
 func (u userRepo) GetByID(ctx context.Context, userID int) (domain.User, error) {
 	var user domain.User
 
-	selectQuery := `SELECT email, first_name, last_name, age, sex, photo_url
+	selectQuery := `SELECT email, first_name, last_name, age, sex, photo_url, timezone
 		FROM users WHERE id = $1`
 
 	err := u.db.QueryRowxContext(ctx, selectQuery, userID).Scan(&user.Email, &user.FirstName, &user.LastName,
-		&user.Age, &user.Sex, &user.PhotoUrl)
+		&user.Age, &user.Sex, &user.PhotoUrl, &user.Timezone)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return domain.User{}, errs.ErrNoUser
@@ -93,21 +93,83 @@ func (u userRepo) GetCovers(ctx context.Context, search string, cursor int) (dom
 	}, nil
 }
 
-func (u userRepo) GetSecure(ctx context.Context, email string) (int, string, error) {
-	var hashedPassword string
-	var id int
+func (u userRepo) GetSecure(ctx context.Context, email string) (domain.Secure, error) {
+	var secure domain.Secure
 
-	getQuery := `SELECT id, password FROM users WHERE email = $1`
+	getQuery := `SELECT id, password, is_verified,
+		suspended_at IS NOT NULL AND (suspended_until IS NULL OR suspended_until > NOW())
+		FROM users WHERE email = $1`
 
-	err := u.db.QueryRowContext(ctx, getQuery, email).Scan(&id, &hashedPassword)
+	err := u.db.QueryRowContext(ctx, getQuery, email).Scan(&secure.ID, &secure.Password, &secure.IsVerified, &secure.IsSuspended)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return 0, "", errs.InvalidEmail
+			return domain.Secure{}, errs.InvalidEmail
 		}
-		return 0, "", customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		return domain.Secure{}, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
 	}
 
-	return id, hashedPassword, nil
+	return secure, nil
+}
+
+func (u userRepo) GetVerification(ctx context.Context, id int) (string, bool, error) {
+	var (
+		email      string
+		isVerified bool
+	)
+
+	getQuery := `SELECT email, is_verified FROM users WHERE id = $1`
+
+	err := u.db.QueryRowContext(ctx, getQuery, id).Scan(&email, &isVerified)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", false, errs.ErrNoUser
+		}
+		return "", false, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
+
+	return email, isVerified, nil
+}
+
+func (u userRepo) SetVerified(ctx context.Context, id int) error {
+	updateQuery := `UPDATE users SET is_verified = TRUE WHERE id = $1`
+
+	res, err := u.db.ExecContext(ctx, updateQuery, id)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		return errs.ErrNoUser
+	}
+
+	return nil
+}
+
+func (u userRepo) UpdatePassword(ctx context.Context, id int, password string) error {
+	hashedPassword := utils.HashPassword(password)
+
+	updateQuery := `UPDATE users SET password = $1 WHERE id = $2`
+
+	res, err := u.db.ExecContext(ctx, updateQuery, hashedPassword, id)
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ExecErr, Err: err})
+	}
+
+	count, err := res.RowsAffected()
+	if err != nil {
+		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	}
+
+	if count != 1 {
+		return errs.ErrNoUser
+	}
+
+	return nil
 }
 
 func (u userRepo) UpdateMain(ctx context.Context, user domain.UserUpdate) error {
@@ -116,9 +178,10 @@ func (u userRepo) UpdateMain(ctx context.Context, user domain.UserUpdate) error
 		return customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
 	}
 
-	updateQuery := `UPDATE users SET email = $1, first_name = $2, last_name = $3, age = $4, sex = $5 WHERE id = $6`
+	updateQuery := `UPDATE users SET email = $1, first_name = $2, last_name = $3, age = $4, sex = $5,
+		timezone = COALESCE($7, timezone) WHERE id = $6`
 
-	res, err := tx.ExecContext(ctx, updateQuery, user.Email, user.FirstName, user.LastName, user.Age, user.Sex, user.ID)
+	res, err := tx.ExecContext(ctx, updateQuery, user.Email, user.FirstName, user.LastName, user.Age, user.Sex, user.ID, user.Timezone)
 	if err != nil {
 		if rbErr := tx.Rollback(); rbErr != nil {
 			return customerr.ErrNormalizer(
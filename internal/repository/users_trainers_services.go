@@ -5,7 +5,9 @@ import (
 	"BACKEND/internal/models/domain"
 	"BACKEND/pkg/customerr"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/jmoiron/sqlx"
 	"github.com/lib/pq"
@@ -45,58 +47,76 @@ func (s usersTrainersServicesRepo) Create(ctx context.Context, service domain.Us
 	return createdID, nil
 }
 
+// Schedule записывает занятие по договору. Время должно попадать в рабочее время тренера в его часовом поясе
+// и не пересекаться с импортированным занятым временем, а пересечение с другими его занятиями отклоняет
+// ограничение исключения users_trainers_services_schedule_overlap
 func (s usersTrainersServicesRepo) Schedule(ctx context.Context, schedule domain.ScheduleService) (int, error) {
-	var createdID int
-
-	createQuery := `INSERT INTO users_trainers_services_schedule (users_trainers_services_id, date, time_start, time_end) VALUES ($1, $2, $3, $4) RETURNING id`
+	var (
+		createdID   int
+		trainerID   int
+		timezone    string
+		isAvailable bool
+	)
 
-	err := s.db.QueryRowContext(ctx, createQuery, schedule.ScheduleID, schedule.Date, schedule.TimeStart, schedule.TimeEnd).Scan(&createdID)
+	tx, err := s.db.Beginx()
 	if err != nil {
-		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.TransactionErr, Err: err})
 	}
 
-	return createdID, nil
-}
+	// Блокировка тренера на чтение не дает заменить шаблон рабочего времени до конца записи
+	trainerQuery := `SELECT uts.trainer_id, t.timezone FROM users_trainers_services uts JOIN trainers t ON t.id = uts.trainer_id WHERE uts.id = $1 FOR SHARE OF t`
 
-func (s usersTrainersServicesRepo) GetSchedule(ctx context.Context, month, trainerID int) ([]domain.TrainingSchedule, error) {
-	var schedules []domain.TrainingSchedule
+	if err = tx.QueryRowContext(ctx, trainerQuery, schedule.ScheduleID).Scan(&trainerID, &timezone); err != nil {
+		tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, errs.ErrNoService
+		}
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
+	}
 
-	query := `
-		SELECT date, array_agg(tuts.id) AS training_ids
-		FROM users_trainers_services_schedule tuts
-		JOIN users_trainers_services uts ON tuts.users_trainers_services_id = uts.id
-		WHERE EXTRACT(MONTH FROM date) = $1 AND uts.trainer_id = $2
-		GROUP BY date
-		ORDER BY date
-	`
-	rows, err := s.db.QueryContext(ctx, query, month, trainerID)
-	if err != nil {
-		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.QueryErr, Err: err})
+	// Шаблон и исключения заданы местным временем тренера, поэтому занятие сравнивается с ними в его часовом поясе
+	availableQuery := `
+		WITH trainer_local AS (
+			SELECT $2::timestamptz AT TIME ZONE $4 AS time_start, $3::timestamptz AT TIME ZONE $4 AS time_end
+		)
+		SELECT trainer_local.time_start::date = trainer_local.time_end::date
+		   AND EXISTS(SELECT 1 FROM trainer_availability a
+		              WHERE a.trainer_id = $1 AND a.weekday = EXTRACT(ISODOW FROM trainer_local.time_start)
+		                AND a.time_start <= trainer_local.time_start::time AND a.time_end >= trainer_local.time_end::time)
+		   AND NOT EXISTS(SELECT 1 FROM trainer_availability_exceptions e
+		                  WHERE e.trainer_id = $1 AND trainer_local.time_start::date BETWEEN e.date_start AND e.date_end)
+		   AND NOT EXISTS(SELECT 1 FROM trainer_busy b
+		                  WHERE b.trainer_id = $1 AND b.time_start < $3::timestamptz AND b.time_end > $2::timestamptz)
+		FROM trainer_local`
+
+	if err = tx.QueryRowContext(ctx, availableQuery, trainerID, schedule.TimeStart, schedule.TimeEnd, timezone).Scan(&isAvailable); err != nil {
+		tx.Rollback()
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
 	}
-	defer rows.Close()
 
-	for rows.Next() {
-		var schedule domain.TrainingSchedule
-		var trainingIDs pq.Int64Array
-		err := rows.Scan(&schedule.Date, &trainingIDs)
-		if err != nil {
-			return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
-		}
+	if !isAvailable {
+		tx.Rollback()
+		return 0, errs.ErrSlotUnavailable
+	}
 
-		// Преобразование pq.Int64Array в []int
-		schedule.TrainingIDs = make([]int, len(trainingIDs))
-		for i, id := range trainingIDs {
-			schedule.TrainingIDs[i] = int(id)
-		}
+	createQuery := `INSERT INTO users_trainers_services_schedule (users_trainers_services_id, trainer_id, time_start, time_end)
+		VALUES ($1, $2, $3, $4) RETURNING id`
 
-		schedules = append(schedules, schedule)
+	err = tx.QueryRowContext(ctx, createQuery, schedule.ScheduleID, trainerID, schedule.TimeStart, schedule.TimeEnd).Scan(&createdID)
+	if err != nil {
+		tx.Rollback()
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23P01" {
+			return 0, errs.ErrScheduleConflict
+		}
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.ScanErr, Err: err})
 	}
 
-	if err = rows.Err(); err != nil {
-		return nil, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.RowsErr, Err: err})
+	if err = tx.Commit(); err != nil {
+		return 0, customerr.ErrNormalizer(customerr.ErrorPair{Message: customerr.CommitErr, Err: err})
 	}
 
-	return schedules, nil
+	return createdID, nil
 }
 
 func (s usersTrainersServicesRepo) DeleteScheduled(ctx context.Context, scheduleID int) error {
@@ -160,7 +180,7 @@ func (s usersTrainersServicesRepo) GetSchedulesByIDs(ctx context.Context, schedu
 	query := `
 	SELECT uts.id, uts.user_id, uts.trainer_id, uts.service_id, uts.is_payed, uts.trainer_confirm, uts.user_confirm,
 	       s.id, s.name, s.price, s.profile_access, u.id, u.first_name, u.last_name, u.age, u.sex, u.photo_url,
-	       tuts.id, tuts.date, tuts.time_start, tuts.time_end
+	       tuts.id, tuts.time_start, tuts.time_end
 	FROM users_trainers_services_schedule tuts
 		JOIN users_trainers_services uts ON tuts.users_trainers_services_id = uts.id
 		JOIN users u ON uts.user_id = u.id
@@ -179,7 +199,7 @@ func (s usersTrainersServicesRepo) GetSchedulesByIDs(ctx context.Context, schedu
 
 		err := rows.Scan(&service.ID, &service.UserID, &service.TrainerID, &service.ScheduleID, &service.IsPayed, &service.TrainerConfirm, &service.UserConfirm,
 			&service.Service.ID, &service.Service.Name, &service.Service.Price, &service.Service.ProfileAccess, &service.User.ID, &service.User.FirstName, &service.User.LastName,
-			&service.User.Age, &service.User.Sex, &service.User.PhotoUrl, &service.ScheduleID, &service.Date, &service.TimeStart, &service.TimeEnd)
+			&service.User.Age, &service.User.Sex, &service.User.PhotoUrl, &service.ScheduleID, &service.TimeStart, &service.TimeEnd)
 		if err != nil {
 			return nil, err
 		}
@@ -314,3 +334,7 @@ func (s usersTrainersServicesRepo) Delete(ctx context.Context, serviceID int) er
 
 	return nil
 }
+
+func (s usersTrainersServicesRepo) GetTimezone(ctx context.Context, accountType string, accountID int) (string, error) {
+	return getTimezone(ctx, s.db, accountType, accountID)
+}
@@ -0,0 +1,325 @@
+package services
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/repository"
+	"BACKEND/pkg/config"
+	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"os"
+	"time"
+)
+
+// accountRepo - общая часть репозиториев пользователей и тренеров, нужная для работы с аккаунтом
+type accountRepo interface {
+	GetSecure(ctx context.Context, email string) (domain.Secure, error)
+	GetVerification(ctx context.Context, id int) (string, bool, error)
+	SetVerified(ctx context.Context, id int) error
+	UpdatePassword(ctx context.Context, id int, password string) error
+}
+
+type accountService struct {
+	userRepo              repository.Users
+	trainerRepo           repository.Trainers
+	deletionRepo          repository.AccountDeletion
+	session               utils.Session
+	mailer                utils.Mailer
+	frontendURL           string
+	verificationTokenTime time.Duration
+	resetTokenTime        time.Duration
+	deletionTime          time.Duration
+	dbResponseTime        time.Duration
+	logger                zerolog.Logger
+}
+
+func InitAccountService(
+	userRepo repository.Users,
+	trainerRepo repository.Trainers,
+	deletionRepo repository.AccountDeletion,
+	session utils.Session,
+	mailer utils.Mailer,
+	dbResponseTime time.Duration,
+	logger zerolog.Logger,
+) Accounts {
+	return &accountService{
+		userRepo:              userRepo,
+		trainerRepo:           trainerRepo,
+		deletionRepo:          deletionRepo,
+		session:               session,
+		mailer:                mailer,
+		frontendURL:           viper.GetString(config.FrontendURL),
+		verificationTokenTime: time.Duration(viper.GetInt(config.VerificationTokenTime)) * time.Hour,
+		resetTokenTime:        time.Duration(viper.GetInt(config.ResetTokenTime)) * time.Minute,
+		deletionTime:          time.Duration(viper.GetInt(config.AccountDeletionTime)) * 24 * time.Hour,
+		dbResponseTime:        dbResponseTime,
+		logger:                logger,
+	}
+}
+
+func (a accountService) SendVerification(ctx context.Context, userID int, userType string) error {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	email, isVerified, err := a.repo(userType).GetVerification(ctx, userID)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	if isVerified {
+		return errs.ErrAlreadyVerified
+	}
+
+	token, err := a.session.SetToken(ctx, utils.VerificationToken, utils.SessionData{
+		UserID:   userID,
+		UserType: userType,
+	}, a.verificationTokenTime)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	err = a.mailer.Send(ctx, utils.Mail{
+		To:      email,
+		Subject: "Подтверждение почты",
+		Body: fmt.Sprintf("Для подтверждения почты перейдите по ссылке: %s/verify?token=%s\r\n\r\n"+
+			"Ссылка действительна %d ч. Если вы не регистрировались, просто проигнорируйте это письмо.",
+			a.frontendURL, token, int(a.verificationTokenTime.Hours())),
+	})
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.SendMail, utils.VerificationToken, userType, userID))
+
+	return nil
+}
+
+func (a accountService) Verify(ctx context.Context, token string) error {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	data, err := a.session.PopToken(ctx, utils.VerificationToken, token)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	err = a.repo(data.UserType).SetVerified(ctx, data.UserID)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	err = a.session.SetVerified(ctx, data.UserID, data.UserType)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.VerifyEmail, data.UserType, data.UserID))
+
+	return nil
+}
+
+func (a accountService) SendPasswordReset(ctx context.Context, email, userType string) error {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	secure, err := a.repo(userType).GetSecure(ctx, email)
+	if err != nil {
+		// Не сообщаем, зарегистрирована ли почта
+		if errors.Is(err, errs.InvalidEmail) {
+			a.logger.Warn().Msg(err.Error())
+			return nil
+		}
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	token, err := a.session.SetToken(ctx, utils.ResetToken, utils.SessionData{
+		UserID:   secure.ID,
+		UserType: userType,
+	}, a.resetTokenTime)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	err = a.mailer.Send(ctx, utils.Mail{
+		To:      email,
+		Subject: "Восстановление пароля",
+		Body: fmt.Sprintf("Для смены пароля перейдите по ссылке: %s/reset-password?token=%s\r\n\r\n"+
+			"Ссылка действительна %d мин. Если вы не запрашивали смену пароля, просто проигнорируйте это письмо.",
+			a.frontendURL, token, int(a.resetTokenTime.Minutes())),
+	})
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.SendMail, utils.ResetToken, userType, secure.ID))
+
+	return nil
+}
+
+func (a accountService) ResetPassword(ctx context.Context, token, password string) error {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	data, err := a.session.PopToken(ctx, utils.ResetToken, token)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	repo := a.repo(data.UserType)
+
+	err = repo.UpdatePassword(ctx, data.UserID, password)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	// Письмо пришло на почту аккаунта, значит она подтверждена
+	err = repo.SetVerified(ctx, data.UserID)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	// Старый пароль мог быть скомпрометирован, поэтому завершаем все сессии
+	err = a.session.DeleteAll(ctx, data.UserID, data.UserType)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.ResetPassword, data.UserType, data.UserID))
+
+	return nil
+}
+
+// RequestDeletion планирует удаление аккаунта. До наступления срока удаление можно отменить
+func (a accountService) RequestDeletion(ctx context.Context, userID int, userType, password string) (dto.DeletionSchedule, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	repo := a.repo(userType)
+
+	email, _, err := repo.GetVerification(ctx, userID)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return dto.DeletionSchedule{}, err
+	}
+
+	// Удаление подтверждается паролем, чтобы его нельзя было запросить с чужой открытой сессии
+	secure, err := repo.GetSecure(ctx, email)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return dto.DeletionSchedule{}, err
+	}
+
+	if !utils.ComparePassword(secure.Password, password) {
+		return dto.DeletionSchedule{}, errs.InvalidPassword
+	}
+
+	deleteAt := time.Now().Add(a.deletionTime)
+
+	err = a.deletionRepo.Schedule(ctx, userType, userID, deleteAt)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return dto.DeletionSchedule{}, err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.ScheduleDeletion, userType, userID, deleteAt.Format(time.DateTime)))
+
+	// Удаление уже запланировано, поэтому ошибка письма его не отменяет
+	err = a.mailer.Send(ctx, utils.Mail{
+		To:      email,
+		Subject: "Удаление аккаунта",
+		Body: fmt.Sprintf("Ваш аккаунт и все его данные будут удалены %s. До этого момента удаление можно отменить в настройках аккаунта.\r\n\r\n"+
+			"Если вы не запрашивали удаление, отмените его и смените пароль.", deleteAt.Format("02.01.2006 15:04")),
+	})
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+	} else {
+		a.logger.Info().Msg(log.Normalizer(log.SendMail, domain.AccountDeletion, userType, userID))
+	}
+
+	return dto.DeletionSchedule{DeleteAt: deleteAt}, nil
+}
+
+func (a accountService) CancelDeletion(ctx context.Context, userID int, userType string) error {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	err := a.deletionRepo.Cancel(ctx, userType, userID)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.CancelDeletion, userType, userID))
+
+	return nil
+}
+
+// PurgeDeleted удаляет аккаунты, срок удаления которых наступил. Ошибка одного аккаунта не мешает удалению остальных
+func (a accountService) PurgeDeleted(ctx context.Context) {
+	for _, userType := range []string{utils.User, utils.Trainer} {
+		dueCtx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+		accountIDs, err := a.deletionRepo.GetDue(dueCtx, userType)
+		cancel()
+		if err != nil {
+			a.logger.Error().Msg(err.Error())
+			continue
+		}
+
+		for _, accountID := range accountIDs {
+			a.deleteAccount(ctx, userType, accountID)
+		}
+	}
+}
+
+func (a accountService) deleteAccount(ctx context.Context, userType string, accountID int) {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	files, err := a.deletionRepo.Delete(ctx, userType, accountID)
+	if err != nil {
+		// Удаление успели отменить
+		if errors.Is(err, errs.ErrNoDeletion) {
+			return
+		}
+		a.logger.Error().Msg(err.Error())
+		return
+	}
+
+	for _, file := range files {
+		os.Remove(".." + file)
+	}
+
+	// Аккаунта больше нет, а токены могли остаться действительными до истечения сессии
+	if err = a.session.DeleteAll(ctx, accountID, userType); err != nil {
+		a.logger.Error().Msg(err.Error())
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.DeleteAccount, userType, accountID))
+}
+
+func (a accountService) repo(userType string) accountRepo {
+	if userType == utils.Trainer {
+		return a.trainerRepo
+	}
+
+	return a.userRepo
+}
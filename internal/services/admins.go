@@ -0,0 +1,99 @@
+package services
+
+import (
+	"BACKEND/internal/converters"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/repository"
+	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
+	"context"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type adminService struct {
+	adminRepo      repository.Admins
+	converter      converters.AdminConverter
+	guard          loginGuard
+	dbResponseTime time.Duration
+	logger         zerolog.Logger
+}
+
+func InitAdminService(
+	adminRepo repository.Admins,
+	limiter utils.LoginLimiter,
+	dbResponseTime time.Duration,
+	logger zerolog.Logger,
+) Admins {
+	return &adminService{
+		adminRepo:      adminRepo,
+		converter:      converters.InitAdminConverter(),
+		guard:          loginGuard{limiter: limiter, logger: logger},
+		dbResponseTime: dbResponseTime,
+		logger:         logger,
+	}
+}
+
+func (a adminService) Save(ctx context.Context, admin domain.AdminCreate) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	for _, role := range admin.Roles {
+		if !utils.IsAdminRole(role) {
+			a.logger.Error().Msg(errs.ErrNoAdminRole.Error())
+			return 0, errs.ErrNoAdminRole
+		}
+	}
+
+	id, err := a.adminRepo.Save(ctx, admin)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.CreateObject, log.Admin, id))
+
+	return id, nil
+}
+
+func (a adminService) Login(ctx context.Context, auth dto.Auth, ip string) (int, []string, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	if err := a.guard.check(ctx, utils.Admin, auth.Email, ip); err != nil {
+		return 0, nil, err
+	}
+
+	secure, err := a.adminRepo.GetSecure(ctx, auth.Email)
+	if err != nil {
+		return 0, nil, a.guard.fail(ctx, utils.Admin, auth.Email, ip, err)
+	}
+
+	isCompare := utils.ComparePassword(secure.Password, auth.Password)
+	if !isCompare {
+		return 0, nil, a.guard.fail(ctx, utils.Admin, auth.Email, ip, errs.InvalidPassword)
+	}
+
+	a.guard.success(ctx, utils.Admin, auth.Email)
+
+	a.logger.Info().Msg(log.Normalizer(log.AuthorizeAdmin, auth.Email))
+
+	return secure.ID, secure.Roles, nil
+}
+
+func (a adminService) GetByID(ctx context.Context, adminID int) (dto.Admin, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	admin, err := a.adminRepo.GetByID(ctx, adminID)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return dto.Admin{}, err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.GetObject, log.Admin, adminID))
+
+	return a.converter.AdminDomainToDTO(admin), nil
+}
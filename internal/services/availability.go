@@ -0,0 +1,317 @@
+package services
+
+import (
+	"BACKEND/internal/converters"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/repository"
+	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
+	"context"
+	"github.com/rs/zerolog"
+	"gopkg.in/guregu/null.v3"
+	"mime/multipart"
+	"sort"
+	"time"
+)
+
+const (
+	// Наибольший промежуток, на который считаются свободные слоты
+	maxSlotsDays = 62
+	// Занятое время импортируется из календаря на год вперед, но не больше maxImportedBusy промежутков
+	importBusyDays  = 366
+	maxImportedBusy = 5000
+)
+
+type availabilityService struct {
+	availabilityRepo repository.Availability
+	converter        converters.AvailabilityConverter
+	dbResponseTime   time.Duration
+	logger           zerolog.Logger
+}
+
+func InitAvailabilityService(
+	availabilityRepo repository.Availability,
+	dbResponseTime time.Duration,
+	logger zerolog.Logger,
+) Availability {
+	return &availabilityService{
+		availabilityRepo: availabilityRepo,
+		converter:        converters.InitAvailabilityConverter(),
+		dbResponseTime:   dbResponseTime,
+		logger:           logger,
+	}
+}
+
+// GetAvailability возвращает шаблон тренера и исключения, которые еще не закончились.
+// Шаблон задан в часовом поясе тренера, в нем же показывается занятое время
+func (a availabilityService) GetAvailability(ctx context.Context, trainerID int) (dto.Availability, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	loc, err := accountLocation(ctx, a.availabilityRepo, utils.Trainer, trainerID)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return dto.Availability{}, err
+	}
+
+	availability, err := a.availabilityRepo.GetAvailability(ctx, trainerID, dayStart(time.Now().In(loc)), null.Time{})
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return dto.Availability{}, err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.GetObject, log.Available, trainerID))
+
+	return a.converter.AvailabilityDomainToDTO(availability, loc), nil
+}
+
+func (a availabilityService) UpdateAvailability(ctx context.Context, trainerID int, weekly []domain.AvailabilityInterval) error {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	// Промежутки одного дня не должны пересекаться, иначе свободные слоты задвоятся
+	sorted := append([]domain.AvailabilityInterval(nil), weekly...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Weekday != sorted[j].Weekday {
+			return sorted[i].Weekday < sorted[j].Weekday
+		}
+		return clock(sorted[i].TimeStart) < clock(sorted[j].TimeStart)
+	})
+
+	for i, interval := range sorted {
+		if clock(interval.TimeStart) >= clock(interval.TimeEnd) {
+			return errs.ErrBadBody
+		}
+		if i > 0 && sorted[i-1].Weekday == interval.Weekday && clock(sorted[i-1].TimeEnd) > clock(interval.TimeStart) {
+			return errs.ErrBadBody
+		}
+	}
+
+	if err := a.availabilityRepo.UpdateAvailability(ctx, trainerID, sorted); err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.UpdateAvailability, trainerID, len(sorted)))
+
+	return nil
+}
+
+func (a availabilityService) CreateException(ctx context.Context, exception domain.AvailabilityException) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	if exception.DateEnd.Before(exception.DateStart) {
+		return 0, errs.ErrBadBody
+	}
+
+	id, err := a.availabilityRepo.CreateException(ctx, exception)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.CreateObject, log.Exception, id))
+
+	return id, nil
+}
+
+func (a availabilityService) DeleteException(ctx context.Context, trainerID, exceptionID int) error {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	if err := a.availabilityRepo.DeleteException(ctx, trainerID, exceptionID); err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.DeleteObject, log.Exception, exceptionID))
+
+	return nil
+}
+
+// GetSlots делит рабочее время тренера в днях с DateStart по DateEnd на слоты длиной Duration.
+// Дни считаются в часовом поясе того, кто смотрит слоты, а шаблон тренера - в часовом поясе тренера.
+// Дни исключений, занятия и уже прошедшее время в слоты не попадают
+func (a availabilityService) GetSlots(ctx context.Context, filters domain.FiltersSlots) ([]dto.Slot, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	dateStart, dateEnd := dayStart(filters.DateStart), dayStart(filters.DateEnd)
+	if dateEnd.Before(dateStart) || dateEnd.Sub(dateStart) > maxSlotsDays*24*time.Hour {
+		return nil, errs.ErrBadQuery
+	}
+
+	viewerLoc, err := accountLocation(ctx, a.availabilityRepo, filters.Viewer.Type, filters.Viewer.ID)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return nil, err
+	}
+
+	trainerLoc, err := accountLocation(ctx, a.availabilityRepo, utils.Trainer, filters.TrainerID)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return nil, err
+	}
+
+	rangeStart, rangeEnd := localTime(dateStart, viewerLoc), localTime(dateEnd.AddDate(0, 0, 1), viewerLoc)
+	// Дни тренера, которые пересекаются с днями того, кто смотрит слоты
+	trainerStart, trainerEnd := dayStart(rangeStart.In(trainerLoc)), dayStart(rangeEnd.In(trainerLoc))
+
+	availability, err := a.availabilityRepo.GetAvailability(ctx, filters.TrainerID, trainerStart, null.TimeFrom(trainerEnd))
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return nil, err
+	}
+
+	busy, err := a.availabilityRepo.GetBusy(ctx, filters.TrainerID, rangeStart, rangeEnd)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return nil, err
+	}
+
+	now := time.Now()
+	slots := make([]domain.Slot, 0)
+
+	for day := trainerStart; !day.After(trainerEnd); day = day.AddDate(0, 0, 1) {
+		if isException(availability.Exceptions, day) {
+			continue
+		}
+
+		for _, interval := range availability.Weekly {
+			if interval.Weekday != isoWeekday(day) {
+				continue
+			}
+
+			free := domain.Slot{
+				TimeStart: localTime(day.Add(clock(interval.TimeStart)), trainerLoc),
+				TimeEnd:   localTime(day.Add(clock(interval.TimeEnd)), trainerLoc),
+			}
+			for _, window := range subtractBusy(free, busy) {
+				for start := window.TimeStart; !start.Add(filters.Duration).After(window.TimeEnd); start = start.Add(filters.Duration) {
+					if start.Before(now) || start.Before(rangeStart) || !start.Before(rangeEnd) {
+						continue
+					}
+					slots = append(slots, domain.Slot{TimeStart: start, TimeEnd: start.Add(filters.Duration)})
+				}
+			}
+		}
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Slot))
+
+	return a.converter.SlotsDomainToDTO(slots, viewerLoc), nil
+}
+
+// ImportBusy заменяет будущее занятое время тренера событиями календаря .ics. Время событий без зоны
+// считается временем в часовом поясе тренера. Возвращает число импортированных промежутков
+func (a availabilityService) ImportBusy(ctx context.Context, trainerID int, file *multipart.FileHeader) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	src, err := file.Open()
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+	defer src.Close()
+
+	loc, err := accountLocation(ctx, a.availabilityRepo, utils.Trainer, trainerID)
+	if err != nil {
+		a.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+
+	now := time.Now()
+	events, err := utils.ParseICal(src, loc, now, now.AddDate(0, 0, importBusyDays))
+	if err != nil {
+		return 0, errs.ErrBadCalendar
+	}
+	if len(events) > maxImportedBusy {
+		return 0, errs.ErrFileTooLarge
+	}
+
+	busy := make([]domain.Slot, 0, len(events))
+	for _, event := range events {
+		if !event.End.After(event.Start) {
+			continue
+		}
+		busy = append(busy, domain.Slot{TimeStart: event.Start, TimeEnd: event.End})
+	}
+
+	if err = a.availabilityRepo.ReplaceBusy(ctx, trainerID, now, busy); err != nil {
+		a.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.ImportBusy, trainerID, len(busy)))
+
+	return len(busy), nil
+}
+
+// ClearBusy удаляет будущее импортированное занятое время тренера
+func (a availabilityService) ClearBusy(ctx context.Context, trainerID int) error {
+	ctx, cancel := context.WithTimeout(ctx, a.dbResponseTime)
+	defer cancel()
+
+	if err := a.availabilityRepo.ReplaceBusy(ctx, trainerID, time.Now(), nil); err != nil {
+		a.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	a.logger.Info().Msg(log.Normalizer(log.ImportBusy, trainerID, 0))
+
+	return nil
+}
+
+// subtractBusy вычитает занятия из промежутка. busy отсортированы по началу
+func subtractBusy(free domain.Slot, busy []domain.Slot) []domain.Slot {
+	var windows []domain.Slot
+
+	start := free.TimeStart
+	for _, session := range busy {
+		if !session.TimeEnd.After(start) || !session.TimeStart.Before(free.TimeEnd) {
+			continue
+		}
+		if session.TimeStart.After(start) {
+			windows = append(windows, domain.Slot{TimeStart: start, TimeEnd: session.TimeStart})
+		}
+		start = session.TimeEnd
+	}
+
+	if start.Before(free.TimeEnd) {
+		windows = append(windows, domain.Slot{TimeStart: start, TimeEnd: free.TimeEnd})
+	}
+
+	return windows
+}
+
+func isException(exceptions []domain.AvailabilityException, day time.Time) bool {
+	for _, exception := range exceptions {
+		if !day.Before(dayStart(exception.DateStart)) && !day.After(dayStart(exception.DateEnd)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isoWeekday возвращает день недели ISO: 1 - понедельник, 7 - воскресенье
+func isoWeekday(day time.Time) int {
+	if day.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(day.Weekday())
+}
+
+// dayStart отбрасывает время, оставляя дату в UTC
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// clock возвращает время суток как смещение от начала дня
+func clock(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/pkg/utils"
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"gopkg.in/guregu/null.v3"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testTrainerID = 7
+
+// availabilityRepoStub отдает заданные шаблон, исключения, занятое время и часовые пояса аккаунтов
+type availabilityRepoStub struct {
+	availability domain.Availability
+	busy         []domain.Slot
+	timezones    map[string]string
+}
+
+func (a availabilityRepoStub) GetAvailability(context.Context, int, time.Time, null.Time) (domain.Availability, error) {
+	return a.availability, nil
+}
+
+func (a availabilityRepoStub) UpdateAvailability(context.Context, int, []domain.AvailabilityInterval) error {
+	return nil
+}
+
+func (a availabilityRepoStub) CreateException(context.Context, domain.AvailabilityException) (int, error) {
+	return 0, nil
+}
+
+func (a availabilityRepoStub) DeleteException(context.Context, int, int) error {
+	return nil
+}
+
+func (a availabilityRepoStub) GetBusy(context.Context, int, time.Time, time.Time) ([]domain.Slot, error) {
+	return a.busy, nil
+}
+
+func (a availabilityRepoStub) ReplaceBusy(context.Context, int, time.Time, []domain.Slot) error {
+	return nil
+}
+
+func (a availabilityRepoStub) GetTimezone(_ context.Context, accountType string, _ int) (string, error) {
+	return a.timezones[accountType], nil
+}
+
+func mustLoadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := utils.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return loc
+}
+
+func clockTime(hour, minute int) time.Time {
+	return time.Date(0, 1, 1, hour, minute, 0, 0, time.UTC)
+}
+
+func TestSubtractBusy(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2030, 1, 7, hour, minute, 0, 0, time.UTC)
+	}
+	slot := func(startHour, startMinute, endHour, endMinute int) domain.Slot {
+		return domain.Slot{TimeStart: at(startHour, startMinute), TimeEnd: at(endHour, endMinute)}
+	}
+	free := slot(10, 0, 14, 0)
+
+	tests := []struct {
+		name string
+		busy []domain.Slot
+		want []domain.Slot
+	}{
+		{name: "no busy time", want: []domain.Slot{free}},
+		{name: "busy before and after", busy: []domain.Slot{slot(8, 0, 10, 0), slot(14, 0, 15, 0)}, want: []domain.Slot{free}},
+		{name: "busy in the middle", busy: []domain.Slot{slot(11, 0, 12, 0)}, want: []domain.Slot{slot(10, 0, 11, 0), slot(12, 0, 14, 0)}},
+		{name: "busy at the start", busy: []domain.Slot{slot(9, 0, 10, 30)}, want: []domain.Slot{slot(10, 30, 14, 0)}},
+		{name: "busy at the end", busy: []domain.Slot{slot(13, 30, 15, 0)}, want: []domain.Slot{slot(10, 0, 13, 30)}},
+		{name: "busy covers everything", busy: []domain.Slot{slot(9, 0, 15, 0)}},
+		{name: "adjacent busy", busy: []domain.Slot{slot(11, 0, 12, 0), slot(12, 0, 13, 0)}, want: []domain.Slot{slot(10, 0, 11, 0), slot(13, 0, 14, 0)}},
+		{name: "overlapping busy", busy: []domain.Slot{slot(11, 0, 12, 30), slot(12, 0, 12, 45)}, want: []domain.Slot{slot(10, 0, 11, 0), slot(12, 45, 14, 0)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := subtractBusy(free, tt.busy); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("subtractBusy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetSlots(t *testing.T) {
+	moscow := mustLoadLocation(t, "Europe/Moscow")
+	// 7 января 2030 - понедельник
+	monday := time.Date(2030, 1, 7, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+	mondayMorning := []domain.AvailabilityInterval{{Weekday: 1, TimeStart: clockTime(10, 0), TimeEnd: clockTime(12, 0)}}
+
+	tests := []struct {
+		name       string
+		weekly     []domain.AvailabilityInterval
+		exceptions []domain.AvailabilityException
+		busy       []domain.Slot
+		viewerZone string
+		dateStart  time.Time
+		dateEnd    time.Time
+		duration   time.Duration
+		want       []string
+		wantErr    error
+	}{
+		{
+			name:      "template split into hour slots",
+			weekly:    mondayMorning,
+			dateStart: monday, dateEnd: monday, duration: time.Hour,
+			want: []string{"2030-01-07T10:00:00+03:00", "2030-01-07T11:00:00+03:00"},
+		},
+		{
+			name:      "only the weekday of the template",
+			weekly:    mondayMorning,
+			dateStart: tuesday, dateEnd: tuesday.AddDate(0, 0, 5), duration: time.Hour,
+		},
+		{
+			name:      "busy time is subtracted",
+			weekly:    mondayMorning,
+			busy:      []domain.Slot{{TimeStart: time.Date(2030, 1, 7, 10, 30, 0, 0, moscow), TimeEnd: time.Date(2030, 1, 7, 11, 0, 0, 0, moscow)}},
+			dateStart: monday, dateEnd: monday, duration: 30 * time.Minute,
+			want: []string{"2030-01-07T10:00:00+03:00", "2030-01-07T11:00:00+03:00", "2030-01-07T11:30:00+03:00"},
+		},
+		{
+			name:       "exception day has no slots",
+			weekly:     mondayMorning,
+			exceptions: []domain.AvailabilityException{{DateStart: monday, DateEnd: monday}},
+			dateStart:  monday, dateEnd: monday, duration: time.Hour,
+		},
+		{
+			name:       "slots are shown in the viewer zone",
+			weekly:     mondayMorning,
+			viewerZone: "Asia/Tokyo",
+			dateStart:  monday, dateEnd: monday, duration: time.Hour,
+			want: []string{"2030-01-07T16:00:00+09:00", "2030-01-07T17:00:00+09:00"},
+		},
+		{
+			name:       "trainer evening is the next day for the viewer",
+			weekly:     []domain.AvailabilityInterval{{Weekday: 1, TimeStart: clockTime(20, 0), TimeEnd: clockTime(22, 0)}},
+			viewerZone: "Asia/Tokyo",
+			dateStart:  tuesday, dateEnd: tuesday, duration: time.Hour,
+			want: []string{"2030-01-08T02:00:00+09:00", "2030-01-08T03:00:00+09:00"},
+		},
+		{
+			name:       "trainer evening is outside the viewer day",
+			weekly:     []domain.AvailabilityInterval{{Weekday: 1, TimeStart: clockTime(20, 0), TimeEnd: clockTime(22, 0)}},
+			viewerZone: "Asia/Tokyo",
+			dateStart:  monday, dateEnd: monday, duration: time.Hour,
+		},
+		{
+			name:      "past slots are skipped",
+			weekly:    mondayMorning,
+			dateStart: time.Date(2020, 1, 6, 0, 0, 0, 0, time.UTC), dateEnd: time.Date(2020, 1, 6, 0, 0, 0, 0, time.UTC), duration: time.Hour,
+		},
+		{
+			name:      "end before start",
+			dateStart: tuesday, dateEnd: monday, duration: time.Hour,
+			wantErr: errs.ErrBadQuery,
+		},
+		{
+			name:      "range is too long",
+			dateStart: monday, dateEnd: monday.AddDate(0, 0, maxSlotsDays+1), duration: time.Hour,
+			wantErr: errs.ErrBadQuery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viewerZone := tt.viewerZone
+			if viewerZone == "" {
+				viewerZone = "Europe/Moscow"
+			}
+
+			repo := availabilityRepoStub{
+				availability: domain.Availability{Weekly: tt.weekly, Exceptions: tt.exceptions},
+				busy:         tt.busy,
+				timezones:    map[string]string{utils.Trainer: "Europe/Moscow", utils.User: viewerZone},
+			}
+			service := InitAvailabilityService(repo, time.Second, zerolog.Nop())
+
+			slots, err := service.GetSlots(context.Background(), domain.FiltersSlots{
+				TrainerID: testTrainerID,
+				Viewer:    domain.Caller{ID: 1, Type: utils.User},
+				DateStart: tt.dateStart,
+				DateEnd:   tt.dateEnd,
+				Duration:  tt.duration,
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSlots() error = %v, want %v", err, tt.wantErr)
+			}
+
+			var got []string
+			for _, slot := range slots {
+				got = append(got, slot.TimeStart.Format(time.RFC3339))
+				if !slot.TimeEnd.Equal(slot.TimeStart.Add(tt.duration)) {
+					t.Errorf("slot %v ends at %v", slot.TimeStart, slot.TimeEnd)
+				}
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetSlots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
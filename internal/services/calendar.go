@@ -0,0 +1,300 @@
+package services
+
+import (
+	"BACKEND/internal/converters"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/repository"
+	"BACKEND/pkg/config"
+	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"gopkg.in/guregu/null.v3"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	feedName = "LADYA"
+
+	// Окно событий в подписке на календарь относительно текущего дня
+	feedPastDays   = 90
+	feedFutureDays = 366
+
+	feedTokenSize = 32
+)
+
+type calendarService struct {
+	calendarRepo   repository.Calendar
+	converter      converters.CalendarConverter
+	apiURL         string
+	uidDomain      string
+	dbResponseTime time.Duration
+	logger         zerolog.Logger
+}
+
+func InitCalendarService(
+	calendarRepo repository.Calendar,
+	dbResponseTime time.Duration,
+	logger zerolog.Logger,
+) Calendar {
+	apiURL := strings.TrimRight(viper.GetString(config.APIURL), "/")
+
+	// UID событий должны быть глобально уникальны, поэтому в них входит хост сервиса
+	uidDomain := "ladya"
+	if parsed, err := url.Parse(apiURL); err == nil && parsed.Hostname() != "" {
+		uidDomain = parsed.Hostname()
+	}
+
+	return &calendarService{
+		calendarRepo:   calendarRepo,
+		converter:      converters.InitCalendarConverter(),
+		apiURL:         apiURL,
+		uidDomain:      uidDomain,
+		dbResponseTime: dbResponseTime,
+		logger:         logger,
+	}
+}
+
+// GetEvents возвращает события в днях с DateStart по DateEnd. Дни и время считаются в часовом поясе аккаунта
+func (c calendarService) GetEvents(ctx context.Context, filters domain.FiltersCalendar) ([]dto.CalendarEvent, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	loc, err := accountLocation(ctx, c.calendarRepo, filters.AccountType, filters.AccountID)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return nil, err
+	}
+
+	events, err := c.events(ctx, filters, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Calendar))
+
+	return c.converter.CalendarEventsDomainToDTO(events, loc), nil
+}
+
+// CreateFeed создает секретную ссылку на подписку на календарь аккаунта, заменяя прежнюю.
+// Токен не хранится, поэтому ссылка показывается только один раз
+func (c calendarService) CreateFeed(ctx context.Context, accountID int, accountType string) (dto.CalendarFeed, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	raw := make([]byte, feedTokenSize)
+	if _, err := rand.Read(raw); err != nil {
+		c.logger.Error().Msg(err.Error())
+		return dto.CalendarFeed{}, err
+	}
+	token := hex.EncodeToString(raw)
+
+	if err := c.calendarRepo.SetFeed(ctx, accountID, accountType, hashFeedToken(token)); err != nil {
+		c.logger.Error().Msg(err.Error())
+		return dto.CalendarFeed{}, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.CreateFeed, accountType, accountID))
+
+	return dto.CalendarFeed{URL: fmt.Sprintf("%s/api/calendar/feed/%s.ics", c.apiURL, token)}, nil
+}
+
+func (c calendarService) DeleteFeed(ctx context.Context, accountID int, accountType string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	if err := c.calendarRepo.DeleteFeed(ctx, accountID, accountType); err != nil {
+		c.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.DeleteFeed, accountType, accountID))
+
+	return nil
+}
+
+// GetFeed возвращает календарь .ics владельца токена за feedPastDays дней до и feedFutureDays после текущего дня
+func (c calendarService) GetFeed(ctx context.Context, token string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	accountID, accountType, err := c.calendarRepo.GetFeedOwner(ctx, hashFeedToken(token))
+	if err != nil {
+		if !errors.Is(err, errs.ErrNoFeed) {
+			c.logger.Error().Msg(err.Error())
+		}
+		return nil, err
+	}
+
+	loc, err := accountLocation(ctx, c.calendarRepo, accountType, accountID)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return nil, err
+	}
+
+	today := dayStart(time.Now().In(loc))
+	events, err := c.events(ctx, domain.FiltersCalendar{
+		AccountID:   accountID,
+		AccountType: accountType,
+		DateStart:   today.AddDate(0, 0, -feedPastDays),
+		DateEnd:     today.AddDate(0, 0, feedFutureDays),
+	}, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	icalEvents := make([]utils.ICalEvent, len(events))
+	for i, event := range events {
+		icalEvents[i] = c.icalEvent(event)
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.GetFeed, accountType, accountID))
+
+	return utils.WriteICal(feedName, icalEvents, time.Now()), nil
+}
+
+// GetTrainingICal возвращает одну тренировку из расписания файлом .ics
+func (c calendarService) GetTrainingICal(ctx context.Context, userTrainingID int) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	event, err := c.calendarRepo.GetTraining(ctx, userTrainingID)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return nil, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.GetObject, log.Schedule, userTrainingID))
+
+	return utils.WriteICal(event.Title, []utils.ICalEvent{c.icalEvent(event)}, time.Now()), nil
+}
+
+// events возвращает события календаря в днях с DateStart по DateEnd часового пояса loc
+// вместе с развернутыми вхождениями серий тренировок в порядке начала
+func (c calendarService) events(ctx context.Context, filters domain.FiltersCalendar, loc *time.Location) ([]domain.CalendarEvent, error) {
+	dateStart, dateEnd := dayStart(filters.DateStart), dayStart(filters.DateEnd)
+	start, end := localTime(dateStart, loc), localTime(dateEnd.AddDate(0, 0, 1), loc)
+
+	events, err := c.calendarRepo.GetEvents(ctx, filters.AccountID, filters.AccountType, start, end)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return nil, err
+	}
+
+	if filters.AccountType == utils.User {
+		// Серия повторяется в своем часовом поясе, и ее день может не совпадать с днем календаря, поэтому дни берутся с запасом
+		series, err := c.calendarRepo.GetSeries(ctx, filters.AccountID, dateStart.AddDate(0, 0, -1), dateEnd.AddDate(0, 0, 1))
+		if err != nil {
+			c.logger.Error().Msg(err.Error())
+			return nil, err
+		}
+
+		for _, item := range series {
+			occurrences, err := expandSeries(item, start, end)
+			if err != nil {
+				c.logger.Error().Msg(err.Error())
+				return nil, err
+			}
+			events = append(events, occurrences...)
+		}
+
+		sort.SliceStable(events, func(i, j int) bool {
+			if !events[i].TimeStart.Equal(events[j].TimeStart) {
+				return events[i].TimeStart.Before(events[j].TimeStart)
+			}
+			return events[i].ID < events[j].ID
+		})
+	}
+
+	return events, nil
+}
+
+// icalEvent переводит событие в VEVENT. UID не меняется при переносе события, а у вхождения серии
+// остается тем же и после его сохранения отдельной записью
+func (c calendarService) icalEvent(event domain.CalendarEvent) utils.ICalEvent {
+	var uid string
+	switch {
+	case event.Type == domain.EventSession:
+		uid = fmt.Sprintf("session-%d@%s", event.ID, c.uidDomain)
+	case event.SeriesID.Valid:
+		uid = fmt.Sprintf("series-%d-%s@%s", event.SeriesID.Int64, event.OccurrenceDate.Time.Format("20060102"), c.uidDomain)
+	default:
+		uid = fmt.Sprintf("training-%d@%s", event.ID, c.uidDomain)
+	}
+
+	summary := event.Title
+	if event.Counterpart.ID.Valid {
+		summary = strings.TrimSpace(fmt.Sprintf("%s: %s %s", event.Title, event.Counterpart.FirstName.String, event.Counterpart.LastName.String))
+	}
+
+	return utils.ICalEvent{
+		UID:     uid,
+		Summary: summary,
+		Start:   event.TimeStart,
+		End:     event.TimeEnd,
+	}
+}
+
+// hashFeedToken - токены подписки случайные и длинные, поэтому хватает sha256 без соли
+func hashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// expandSeries разворачивает серию в события, которые начинаются с start до end. Время вхождений считается
+// в часовом поясе серии. Отмененные вхождения пропускаются, сохраненные отдельно уже есть среди записей
+// users_trainings и тоже пропускаются
+func expandSeries(series domain.TrainingSeries, start, end time.Time) ([]domain.CalendarEvent, error) {
+	rule, err := utils.ParseRRule(series.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	loc, err := utils.LoadLocation(series.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	skip := make(map[time.Time]bool, len(series.Cancelled)+len(series.Overridden))
+	for _, days := range [][]time.Time{series.Cancelled, series.Overridden} {
+		for _, day := range days {
+			skip[dayStart(day)] = true
+		}
+	}
+
+	var events []domain.CalendarEvent
+	for _, day := range rule.Occurrences(series.DateStart, end.In(loc)) {
+		if day.Before(dayStart(start.In(loc))) || skip[day] {
+			continue
+		}
+
+		timeStart := localTime(day.Add(clock(series.TimeStart)), loc)
+		if timeStart.Before(start) || !timeStart.Before(end) {
+			continue
+		}
+
+		events = append(events, domain.CalendarEvent{
+			Type:           domain.EventTraining,
+			Title:          series.Title,
+			TimeStart:      timeStart,
+			TimeEnd:        localTime(day.Add(clock(series.TimeEnd)), loc),
+			TrainingID:     null.IntFrom(int64(series.TrainingID)),
+			SeriesID:       null.IntFrom(int64(series.ID)),
+			OccurrenceDate: null.TimeFrom(day),
+		})
+	}
+
+	return events, nil
+}
@@ -2,17 +2,38 @@ package services
 
 import (
 	"BACKEND/internal/converters"
+	"BACKEND/internal/errs"
 	"BACKEND/internal/models/domain"
 	"BACKEND/internal/models/dto"
 	"BACKEND/internal/repository"
 	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
 	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog"
+	"gopkg.in/guregu/null.v3"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
+const (
+	// Большая сторона превью изображений в пикселях
+	thumbnailSize = 320
+	// Время, после которого неотправленные вложения удаляются
+	unattachedTime = 24 * time.Hour
+)
+
 type chatService struct {
 	chatRepo       repository.Chat
+	serviceService UserTrainerServices
+	presence       utils.Presence
+	deleteTime     time.Duration
+	offerTime      time.Duration
 	converter      converters.ChatConverter
 	dbResponseTime time.Duration
 	logger         zerolog.Logger
@@ -20,30 +41,56 @@ type chatService struct {
 
 func InitChatService(
 	chatRepo repository.Chat,
+	serviceService UserTrainerServices,
+	presence utils.Presence,
+	deleteTime time.Duration,
+	offerTime time.Duration,
 	dbResponseTime time.Duration,
 	logger zerolog.Logger,
 ) Chat {
 	return &chatService{
 		chatRepo:       chatRepo,
+		serviceService: serviceService,
+		presence:       presence,
+		deleteTime:     deleteTime,
+		offerTime:      offerTime,
 		converter:      converters.InitChatConverter(),
 		dbResponseTime: dbResponseTime,
 		logger:         logger,
 	}
 }
 
-func (c chatService) CreateMessage(ctx context.Context, message domain.MessageCreate) (int, time.Time, error) {
+func (c chatService) CreateMessage(ctx context.Context, message domain.MessageCreate) (dto.Message, bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
 	defer cancel()
 
-	createdID, t, err := c.chatRepo.CreateMessage(ctx, message)
+	// Услуга в сообщении тренера - предложение, по умолчанию действует OFFER_TIME
+	if message.IsToUser && message.ServiceID.Valid {
+		now := time.Now().UTC()
+
+		if !message.OfferExpiresAt.Valid {
+			message.OfferExpiresAt = null.TimeFrom(now.Add(c.offerTime))
+		}
+
+		message.OfferExpiresAt.Time = message.OfferExpiresAt.Time.UTC()
+		if !message.OfferExpiresAt.Time.After(now) {
+			return dto.Message{}, false, errs.ErrBadBody
+		}
+	}
+
+	created, isCreated, err := c.chatRepo.CreateMessage(ctx, message)
 	if err != nil {
 		c.logger.Error().Msg(err.Error())
-		return 0, time.Time{}, err
+		return dto.Message{}, false, err
 	}
 
-	c.logger.Info().Msg(log.Normalizer(log.CreateObject, log.Message, createdID))
+	if isCreated {
+		c.logger.Info().Msg(log.Normalizer(log.CreateObject, log.Message, created.ID))
+	} else {
+		c.logger.Info().Msg(log.Normalizer(log.DuplicateMessage, created.ID, message.ClientID.String))
+	}
 
-	return createdID, t, nil
+	return c.converter.MessageDomainToDTO(created), isCreated, nil
 }
 
 func (c chatService) GetUserChats(ctx context.Context, userID int, search string) ([]dto.Chat, error) {
@@ -90,3 +137,396 @@ func (c chatService) GetChatMessage(ctx context.Context, userID, trainerID, curs
 
 	return c.converter.MessagePaginationDomainToDTO(messages), nil
 }
+
+func (c chatService) SearchMessages(ctx context.Context, userID int, userType string, search string, to, cursor int) (dto.MessageFoundPagination, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	messages, err := c.chatRepo.SearchMessages(ctx, userType, userID, search, to, cursor)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return dto.MessageFoundPagination{}, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Message))
+
+	return c.converter.MessageFoundPaginationDomainToDTO(messages), nil
+}
+
+func (c chatService) MarkDelivered(ctx context.Context, userID int, userType string, messageID int) (domain.Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	receipt, err := c.chatRepo.MarkDelivered(ctx, userType, userID, messageID)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return domain.Receipt{}, err
+	}
+
+	if receipt.MessageID != 0 {
+		c.logger.Info().Msg(log.Normalizer(log.DeliverMessages, receipt.MessageID, userType, userID))
+	}
+
+	return receipt, nil
+}
+
+func (c chatService) MarkRead(ctx context.Context, userID int, userType string, messageID int) (domain.Receipt, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	receipt, err := c.chatRepo.MarkRead(ctx, userType, userID, messageID)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return domain.Receipt{}, err
+	}
+
+	if receipt.MessageID != 0 {
+		c.logger.Info().Msg(log.Normalizer(log.ReadMessages, receipt.MessageID, userType, userID))
+	}
+
+	return receipt, nil
+}
+
+// GetMissedMessages возвращает сообщения, пропущенные клиентом: после lastID, а если он не передан - все недоставленные
+func (c chatService) GetMissedMessages(ctx context.Context, userID int, userType string, lastID, cursor int) (dto.MessagePagination, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	afterID := max(lastID, cursor)
+
+	messages, err := c.chatRepo.GetMissedMessages(ctx, userType, userID, afterID, lastID == 0)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return dto.MessagePagination{}, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Message))
+
+	return c.converter.MessagePaginationDomainToDTO(messages), nil
+}
+
+// SetOnline отмечает подключение в сети, повторный вызов продлевает его
+func (c chatService) SetOnline(ctx context.Context, userID int, userType, connID string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	if err := c.presence.Set(ctx, userType, userID, connID); err != nil {
+		c.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	if err := c.chatRepo.SetLastSeen(ctx, userType, userID); err != nil {
+		c.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (c chatService) SetOffline(ctx context.Context, userID int, userType, connID string) error {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	if err := c.presence.Delete(ctx, userType, userID, connID); err != nil {
+		c.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	if err := c.chatRepo.SetLastSeen(ctx, userType, userID); err != nil {
+		c.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	return nil
+}
+
+// GetPresence возвращает статус существующих аккаунтов из userIDs, несуществующие пропускаются
+func (c chatService) GetPresence(ctx context.Context, userType string, userIDs []int) ([]dto.Presence, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	presences, err := c.chatRepo.GetLastSeen(ctx, userType, userIDs)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return []dto.Presence{}, err
+	}
+
+	online, err := c.presence.Online(ctx, userType, userIDs)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return []dto.Presence{}, err
+	}
+
+	for i := range presences {
+		presences[i].IsOnline = online[presences[i].ID]
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Presence))
+
+	return c.converter.PresencesDomainToDTO(presences), nil
+}
+
+func (c chatService) EditMessage(ctx context.Context, userID int, userType string, messageID int, text string) (dto.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	message, err := c.chatRepo.EditMessage(ctx, userType, userID, messageID, text)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return dto.Message{}, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.EditMessage, messageID, userType, userID))
+
+	return c.converter.MessageDomainToDTO(message), nil
+}
+
+// DeleteMessage удаляет сообщение у всех, если с отправки прошло не больше MESSAGE_DELETE_TIME
+func (c chatService) DeleteMessage(ctx context.Context, userID int, userType string, messageID int) (dto.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	message, err := c.chatRepo.DeleteMessage(ctx, userType, userID, messageID, time.Now().UTC().Add(-c.deleteTime))
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return dto.Message{}, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.DeleteMessage, messageID, userType, userID))
+
+	return c.converter.MessageDomainToDTO(message), nil
+}
+
+// AnswerOffer принимает или отклоняет предложение услуги. При принятии заключается договор и возвращается системное сообщение о нем,
+// иначе второе сообщение пустое
+func (c chatService) AnswerOffer(ctx context.Context, userID, messageID int, accept bool) (dto.Message, dto.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	status := domain.OfferDeclined
+	if accept {
+		status = domain.OfferAccepted
+	}
+
+	// Смена статуса первой не дает заключить два договора по одному предложению
+	offer, err := c.chatRepo.AnswerOffer(ctx, userID, messageID, status)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return dto.Message{}, dto.Message{}, err
+	}
+
+	if !accept {
+		c.logger.Info().Msg(log.Normalizer(log.AnswerOffer, messageID, status, userID))
+		return c.converter.MessageDomainToDTO(offer), dto.Message{}, nil
+	}
+
+	// Договор не заключается, пока один из собеседников заблокировал другого
+	isBlocked, err := c.chatRepo.IsBlocked(ctx, offer.UserID, offer.TrainerID)
+	if err != nil || isBlocked {
+		c.reopenOffer(messageID)
+		if err != nil {
+			c.logger.Error().Msg(err.Error())
+			return dto.Message{}, dto.Message{}, err
+		}
+		return dto.Message{}, dto.Message{}, errs.ErrBlocked
+	}
+
+	contractID, err := c.serviceService.Create(ctx, domain.UserTrainerServiceCreate{
+		UserID:    offer.UserID,
+		TrainerID: offer.TrainerID,
+		ServiceID: *offer.ServiceID,
+	})
+	if err != nil {
+		c.reopenOffer(messageID)
+		return dto.Message{}, dto.Message{}, err
+	}
+
+	offer, system, err := c.chatRepo.ConfirmOffer(ctx, messageID, contractID)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		if deleteErr := c.serviceService.Delete(context.Background(), contractID); deleteErr == nil {
+			c.reopenOffer(messageID)
+		}
+		return dto.Message{}, dto.Message{}, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.AnswerOffer, messageID, status, userID))
+
+	return c.converter.MessageDomainToDTO(offer), c.converter.MessageDomainToDTO(system), nil
+}
+
+func (c chatService) Block(ctx context.Context, userID int, userType string, counterpartID int) error {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	err := c.chatRepo.Block(ctx, userType, userID, counterpartID)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.BlockChat, userType, userID, counterpartID))
+
+	return nil
+}
+
+func (c chatService) Unblock(ctx context.Context, userID int, userType string, counterpartID int) error {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	err := c.chatRepo.Unblock(ctx, userType, userID, counterpartID)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.UnblockChat, userType, userID, counterpartID))
+
+	return nil
+}
+
+func (c chatService) GetBlocked(ctx context.Context, userID int, userType string) ([]dto.BlockedAccount, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	blocked, err := c.chatRepo.GetBlocked(ctx, userType, userID)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return nil, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Block))
+
+	return c.converter.BlockedDomainToDTO(blocked), nil
+}
+
+func (c chatService) IsChatOpen(ctx context.Context, userID, trainerID int) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	isOpen, err := c.chatRepo.IsChatOpen(ctx, userID, trainerID)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return false, err
+	}
+
+	return isOpen, nil
+}
+
+func (c chatService) ReportMessage(ctx context.Context, userID int, userType string, messageID int, reason string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	id, err := c.chatRepo.ReportMessage(ctx, userType, userID, messageID, reason)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.CreateObject, log.Report, id))
+
+	return id, nil
+}
+
+// reopenOffer дает ответить на предложение повторно, если договор не был заключен
+func (c chatService) reopenOffer(messageID int) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.dbResponseTime)
+	defer cancel()
+
+	if err := c.chatRepo.ReopenOffer(ctx, messageID); err != nil {
+		c.logger.Error().Msg(err.Error())
+	}
+}
+
+func (c chatService) GetMessageEdits(ctx context.Context, userID int, userType string, messageID int) ([]dto.MessageEdit, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	edits, err := c.chatRepo.GetMessageEdits(ctx, userType, userID, messageID)
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return []dto.MessageEdit{}, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.GetObjects, log.MessageEdit))
+
+	return c.converter.MessageEditsDomainToDTO(edits), nil
+}
+
+// UploadAttachment сохраняет вложение, для изображений создается превью.
+// `c *gin.Context` передается во избежаниe выноса бизнес логики с хендлерный слой
+func (c chatService) UploadAttachment(gc *gin.Context, file *multipart.FileHeader, kind string, userID int, userType string) (dto.Attachment, error) {
+	ctx, cancel := context.WithTimeout(gc.Request.Context(), c.dbResponseTime)
+	defer cancel()
+
+	key := uuid.New().String()
+
+	filePath := fmt.Sprintf("/static/chat/attachments/%s%s", key, strings.ToLower(filepath.Ext(file.Filename)))
+	if err := gc.SaveUploadedFile(file, ".."+filePath); err != nil {
+		c.logger.Error().Msg("Failed to save uploaded file")
+		return dto.Attachment{}, err
+	}
+
+	attachment := domain.Attachment{
+		Kind:        kind,
+		FileUrl:     filePath,
+		Name:        filepath.Base(file.Filename),
+		ContentType: file.Header.Get("Content-Type"),
+		Size:        file.Size,
+	}
+
+	if kind == domain.AttachmentImage {
+		thumbnailPath := fmt.Sprintf("/static/chat/thumbnails/%s.jpg", key)
+
+		if err := os.MkdirAll(filepath.Dir(".."+thumbnailPath), 0750); err != nil {
+			os.Remove(".." + filePath)
+			c.logger.Error().Msg(err.Error())
+			return dto.Attachment{}, err
+		}
+
+		// Файл, который не удалось декодировать, не является изображением
+		if err := utils.MakeThumbnail(".."+filePath, ".."+thumbnailPath, thumbnailSize); err != nil {
+			os.Remove(".." + filePath)
+			c.logger.Error().Msg(err.Error())
+			return dto.Attachment{}, errs.ErrBadFile
+		}
+
+		attachment.ThumbnailUrl = null.NewString(thumbnailPath, true)
+	}
+
+	created, err := c.chatRepo.CreateAttachment(ctx, userType, userID, attachment)
+	if err != nil {
+		os.Remove(".." + filePath)
+		if attachment.ThumbnailUrl.Valid {
+			os.Remove(".." + attachment.ThumbnailUrl.String)
+		}
+		c.logger.Error().Msg(err.Error())
+		return dto.Attachment{}, err
+	}
+
+	c.logger.Info().Msg(log.Normalizer(log.CreateObject, log.Attachment, created.ID))
+
+	return c.converter.AttachmentDomainToDTO(created), nil
+}
+
+// DeleteUnattached удаляет вложения, которые так и не отправили в течение unattachedTime
+func (c chatService) DeleteUnattached(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, c.dbResponseTime)
+	defer cancel()
+
+	files, err := c.chatRepo.DeleteUnattached(ctx, time.Now().UTC().Add(-unattachedTime))
+	if err != nil {
+		c.logger.Error().Msg(err.Error())
+		return
+	}
+
+	for _, file := range files {
+		if err = os.Remove(".." + file); err != nil && !os.IsNotExist(err) {
+			c.logger.Error().Msg(err.Error())
+		}
+	}
+
+	if len(files) != 0 {
+		c.logger.Info().Msg(log.Normalizer(log.DeleteUnattached, len(files)))
+	}
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+)
+
+// Хеш для сравнения пароля несуществующего аккаунта, чтобы время ответа не выдавало наличие почты
+var dummyPassword = string(utils.HashPassword("dummy-password"))
+
+// loginGuard ограничивает перебор паролей и приводит ошибки входа к одной
+type loginGuard struct {
+	limiter utils.LoginLimiter
+	logger  zerolog.Logger
+}
+
+func (g loginGuard) check(ctx context.Context, accountType, email, ip string) error {
+	lock, err := g.limiter.Check(ctx, accountType, email, ip)
+	if err != nil {
+		g.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	if lock > 0 {
+		g.logger.Warn().Msg(log.Normalizer(log.LoginRejected, accountType, email, ip, lock))
+		return errs.ErrTooManyAttempts
+	}
+
+	return nil
+}
+
+// fail превращает ошибку поиска аккаунта или проверки пароля в ErrInvalidCredentials и учитывает попытку
+func (g loginGuard) fail(ctx context.Context, accountType, email, ip string, loginErr error) error {
+	if !errors.Is(loginErr, errs.InvalidEmail) && !errors.Is(loginErr, errs.InvalidPassword) {
+		g.logger.Error().Msg(loginErr.Error())
+		return loginErr
+	}
+
+	if errors.Is(loginErr, errs.InvalidEmail) {
+		utils.ComparePassword(dummyPassword, "")
+	}
+
+	failure, err := g.limiter.Fail(ctx, accountType, email, ip)
+	if err != nil {
+		g.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	g.logger.Warn().Msg(log.Normalizer(log.LoginFailed, accountType, email, ip, failure.EmailAttempts))
+
+	if failure.EmailLock > 0 {
+		g.logger.Warn().Msg(log.Normalizer(log.LoginLocked, accountType, email, failure.EmailLock, failure.EmailAttempts))
+	}
+	if failure.IPLock > 0 {
+		g.logger.Warn().Msg(log.Normalizer(log.LoginIPLocked, ip, failure.IPLock, failure.IPAttempts))
+	}
+
+	return errs.ErrInvalidCredentials
+}
+
+func (g loginGuard) success(ctx context.Context, accountType, email string) {
+	// Ошибка сброса не мешает входу, счетчик истечет сам
+	if err := g.limiter.Reset(ctx, accountType, email); err != nil {
+		g.logger.Error().Msg(err.Error())
+	}
+}
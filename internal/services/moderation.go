@@ -0,0 +1,121 @@
+package services
+
+import (
+	"BACKEND/internal/converters"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/repository"
+	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
+	"context"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type moderationService struct {
+	moderationRepo repository.Moderation
+	session        utils.Session
+	converter      converters.ModerationConverter
+	dbResponseTime time.Duration
+	logger         zerolog.Logger
+}
+
+func InitModerationService(
+	moderationRepo repository.Moderation,
+	session utils.Session,
+	dbResponseTime time.Duration,
+	logger zerolog.Logger,
+) Moderation {
+	return &moderationService{
+		moderationRepo: moderationRepo,
+		session:        session,
+		converter:      converters.InitModerationConverter(),
+		dbResponseTime: dbResponseTime,
+		logger:         logger,
+	}
+}
+
+func (m moderationService) GetReports(ctx context.Context, filters domain.FiltersMessageReports) (dto.MessageReportPagination, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.dbResponseTime)
+	defer cancel()
+
+	reports, err := m.moderationRepo.GetReports(ctx, filters)
+	if err != nil {
+		m.logger.Error().Msg(err.Error())
+		return dto.MessageReportPagination{}, err
+	}
+
+	m.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Report))
+
+	return m.converter.MessageReportPaginationDomainToDTO(reports), nil
+}
+
+func (m moderationService) ReviewReport(ctx context.Context, adminID, reportID int, status string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.dbResponseTime)
+	defer cancel()
+
+	if err := m.moderationRepo.ReviewReport(ctx, reportID, adminID, status); err != nil {
+		m.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	m.logger.Info().Msg(log.Normalizer(log.ReviewReport, reportID, status, adminID))
+
+	return nil
+}
+
+// Suspend блокирует аккаунт в базе и во всех его сессиях, чтобы запрет действовал без перелогина
+func (m moderationService) Suspend(ctx context.Context, adminID int, accountType string, accountID int, suspension dto.Suspension) error {
+	ctx, cancel := context.WithTimeout(ctx, m.dbResponseTime)
+	defer cancel()
+
+	until := suspension.Until
+	if until != nil {
+		utc := until.UTC()
+		if !utc.After(time.Now().UTC()) {
+			return errs.ErrBadBody
+		}
+		until = &utc
+	}
+
+	if err := m.moderationRepo.Suspend(ctx, accountType, accountID, until, suspension.Reason); err != nil {
+		m.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	if err := m.session.SetSuspended(ctx, accountID, accountType, true, until); err != nil {
+		m.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	m.logger.Info().Msg(log.Normalizer(log.SuspendAccount, accountType, accountID, suspensionEnd(until), adminID))
+
+	return nil
+}
+
+func (m moderationService) Unsuspend(ctx context.Context, adminID int, accountType string, accountID int) error {
+	ctx, cancel := context.WithTimeout(ctx, m.dbResponseTime)
+	defer cancel()
+
+	if err := m.moderationRepo.Unsuspend(ctx, accountType, accountID); err != nil {
+		m.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	if err := m.session.SetSuspended(ctx, accountID, accountType, false, nil); err != nil {
+		m.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	m.logger.Info().Msg(log.Normalizer(log.UnsuspendAccount, accountType, accountID, adminID))
+
+	return nil
+}
+
+func suspensionEnd(until *time.Time) string {
+	if until == nil {
+		return "forever"
+	}
+	return until.Format(time.RFC3339)
+}
@@ -0,0 +1,451 @@
+package services
+
+import (
+	"BACKEND/internal/converters"
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/repository"
+	"BACKEND/pkg/config"
+	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Период для прогресса и календаря: в выгрузку попадают все тренировки и занятия аккаунта
+var (
+	exportDateStart = time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)
+	exportDateEnd   = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+)
+
+// exportFile - файл архива выгрузки
+type exportFile struct {
+	name string
+	data any
+}
+
+type personalDataService struct {
+	exportRepo     repository.DataExports
+	userService    Users
+	trainerService Trainers
+	trainService   Trainings
+	serviceService UserTrainerServices
+	chatService    Chat
+	calendar       Calendar
+	mailer         utils.Mailer
+	converter      converters.DataExportConverter
+	exportTime     time.Duration
+	dbResponseTime time.Duration
+	logger         zerolog.Logger
+}
+
+func InitPersonalDataService(
+	exportRepo repository.DataExports,
+	userService Users,
+	trainerService Trainers,
+	trainService Trainings,
+	serviceService UserTrainerServices,
+	chatService Chat,
+	calendar Calendar,
+	mailer utils.Mailer,
+	dbResponseTime time.Duration,
+	logger zerolog.Logger,
+) PersonalData {
+	return &personalDataService{
+		exportRepo:     exportRepo,
+		userService:    userService,
+		trainerService: trainerService,
+		trainService:   trainService,
+		serviceService: serviceService,
+		chatService:    chatService,
+		calendar:       calendar,
+		mailer:         mailer,
+		converter:      converters.InitDataExportConverter(),
+		exportTime:     time.Duration(viper.GetInt(config.DataExportTime)) * time.Hour,
+		dbResponseTime: dbResponseTime,
+		logger:         logger,
+	}
+}
+
+// RequestExport создает выгрузку и собирает архив в фоне. Готовность проверяется через GetExport
+func (p personalDataService) RequestExport(ctx context.Context, userID int, userType string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	exportID, err := p.exportRepo.Create(ctx, userType, userID)
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+
+	p.logger.Info().Msg(log.Normalizer(log.CreateObject, log.DataExport, exportID))
+
+	// Контекст запроса отменится после ответа, поэтому архив собирается в своем
+	go p.export(context.Background(), exportID, userID, userType)
+
+	return exportID, nil
+}
+
+func (p personalDataService) GetExport(ctx context.Context, userID int, userType string, exportID int) (dto.DataExport, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	export, err := p.exportRepo.Get(ctx, userType, userID, exportID)
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		return dto.DataExport{}, err
+	}
+
+	p.logger.Info().Msg(log.Normalizer(log.GetObject, log.DataExport, exportID))
+
+	return p.converter.DataExportDomainToDTO(export), nil
+}
+
+// GetExportFile возвращает путь к готовому архиву относительно рабочей директории
+func (p personalDataService) GetExportFile(ctx context.Context, userID int, userType string, exportID int) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	export, err := p.exportRepo.Get(ctx, userType, userID, exportID)
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		return "", err
+	}
+
+	if export.Status != domain.ExportReady || !export.FilePath.Valid {
+		return "", errs.ErrExportNotReady
+	}
+
+	p.logger.Info().Msg(log.Normalizer(log.GetObject, log.DataExport, exportID))
+
+	return ".." + export.FilePath.String, nil
+}
+
+// DeleteExpired удаляет устаревшие выгрузки вместе с архивами
+func (p personalDataService) DeleteExpired(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	files, err := p.exportRepo.DeleteExpired(ctx, time.Now().Add(-p.exportTime))
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		return
+	}
+
+	for _, file := range files {
+		os.Remove(".." + file)
+	}
+}
+
+func (p personalDataService) export(ctx context.Context, exportID, userID int, userType string) {
+	var (
+		email string
+		files []exportFile
+		err   error
+	)
+
+	if userType == utils.Trainer {
+		email, files, err = p.collectTrainer(ctx, userID)
+	} else {
+		email, files, err = p.collectUser(ctx, userID)
+	}
+
+	var filePath string
+	if err == nil {
+		filePath, err = p.writeArchive(files)
+	}
+
+	statusCtx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		p.logger.Warn().Msg(log.Normalizer(log.ExportFailed, exportID, userType, userID))
+
+		if err = p.exportRepo.SetFailed(statusCtx, exportID); err != nil {
+			p.logger.Error().Msg(err.Error())
+		}
+		return
+	}
+
+	if err = p.exportRepo.SetReady(statusCtx, exportID, filePath); err != nil {
+		p.logger.Error().Msg(err.Error())
+		os.Remove(".." + filePath)
+		return
+	}
+
+	p.logger.Info().Msg(log.Normalizer(log.ExportReady, exportID, userType, userID))
+
+	// Архив уже доступен в приложении, поэтому ошибка письма только логируется
+	err = p.mailer.Send(statusCtx, utils.Mail{
+		To:      email,
+		Subject: "Выгрузка данных готова",
+		Body: fmt.Sprintf("Архив с вашими данными готов, скачать его можно в настройках аккаунта.\r\n\r\n"+
+			"Архив будет доступен %d ч.", int(p.exportTime.Hours())),
+	})
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		return
+	}
+
+	p.logger.Info().Msg(log.Normalizer(log.SendMail, log.DataExport, userType, userID))
+}
+
+func (p personalDataService) collectUser(ctx context.Context, userID int) (string, []exportFile, error) {
+	profile, err := p.userService.GetByID(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var trainings []dto.TrainingCover
+	for cursor := 0; ; {
+		page, err := p.trainService.GetTrainingCoversByUserID(ctx, "", userID, cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		trainings = append(trainings, page.Trainings...)
+
+		if cursor = page.Cursor; cursor == 0 {
+			break
+		}
+	}
+
+	scheduleIDs, err := p.collectEvents(ctx, userID, utils.User, domain.EventTraining)
+	if err != nil {
+		return "", nil, err
+	}
+
+	scheduled := []dto.UserTraining{}
+	if len(scheduleIDs) > 0 {
+		if scheduled, err = p.trainService.GetScheduleTrainings(ctx, domain.Caller{ID: userID, Type: utils.User}, scheduleIDs); err != nil {
+			return "", nil, err
+		}
+	}
+
+	var progress []dto.Progress
+	for page := 1; ; page++ {
+		progressPage, err := p.trainService.GetProgress(ctx, domain.FiltersProgress{
+			UserID:  userID,
+			DateEnd: exportDateEnd,
+			Page:    page,
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		progress = append(progress, progressPage.Progresses...)
+
+		if !progressPage.IsMore {
+			break
+		}
+	}
+
+	planCovers, err := p.trainService.GetPlanCoversByUserID(ctx, userID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	plans := make([]dto.Plan, 0, len(planCovers))
+	for _, cover := range planCovers {
+		plan, err := p.trainService.GetPlan(ctx, cover.ID)
+		if err != nil {
+			return "", nil, err
+		}
+		plans = append(plans, plan)
+	}
+
+	var services []dto.ServiceTrainer
+	for cursor := 0; ; {
+		page, err := p.serviceService.GetTrainerServices(ctx, userID, cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		services = append(services, page.Services...)
+
+		if cursor = page.Cursor; cursor == 0 {
+			break
+		}
+	}
+
+	chats, err := p.chatService.GetUserChats(ctx, userID, "")
+	if err != nil {
+		return "", nil, err
+	}
+
+	messages := make(map[int][]dto.Message, len(chats))
+	for _, chat := range chats {
+		if messages[chat.ID], err = p.collectMessages(ctx, userID, chat.ID); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return profile.Email, []exportFile{
+		{name: "profile.json", data: profile},
+		{name: "trainings.json", data: trainings},
+		{name: "schedules.json", data: scheduled},
+		{name: "progress.json", data: progress},
+		{name: "plans.json", data: plans},
+		{name: "services.json", data: services},
+		{name: "chats.json", data: chats},
+		{name: "messages.json", data: messages},
+	}, nil
+}
+
+// collectEvents возвращает id записей календаря аккаунта вида eventType за все время
+func (p personalDataService) collectEvents(ctx context.Context, accountID int, accountType, eventType string) ([]int, error) {
+	events, err := p.calendar.GetEvents(ctx, domain.FiltersCalendar{
+		AccountID:   accountID,
+		AccountType: accountType,
+		DateStart:   exportDateStart,
+		DateEnd:     exportDateEnd,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, event := range events {
+		// Несохраненные вхождения серий не имеют своей записи, в выгрузку попадают только сохраненные
+		if event.Type == eventType && event.ID != 0 {
+			ids = append(ids, event.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+func (p personalDataService) collectTrainer(ctx context.Context, trainerID int) (string, []exportFile, error) {
+	profile, err := p.trainerService.GetByID(ctx, trainerID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var trainings []dto.TrainingCoverTrainer
+	for cursor := 0; ; {
+		page, err := p.trainService.GetTrainingCoversByTrainerID(ctx, "", trainerID, cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		trainings = append(trainings, page.Trainings...)
+
+		if cursor = page.Cursor; cursor == 0 {
+			break
+		}
+	}
+
+	scheduleIDs, err := p.collectEvents(ctx, trainerID, utils.Trainer, domain.EventSession)
+	if err != nil {
+		return "", nil, err
+	}
+
+	scheduled := []dto.ScheduleServiceUser{}
+	if len(scheduleIDs) > 0 {
+		if scheduled, err = p.serviceService.GetSchedulesByIDs(ctx, domain.Caller{ID: trainerID, Type: utils.Trainer}, scheduleIDs); err != nil {
+			return "", nil, err
+		}
+	}
+
+	var services []dto.ServiceUser
+	for cursor := 0; ; {
+		page, err := p.serviceService.GetUserServices(ctx, trainerID, cursor)
+		if err != nil {
+			return "", nil, err
+		}
+		services = append(services, page.Services...)
+
+		if cursor = page.Cursor; cursor == 0 {
+			break
+		}
+	}
+
+	chats, err := p.chatService.GetTrainerChats(ctx, trainerID, "")
+	if err != nil {
+		return "", nil, err
+	}
+
+	messages := make(map[int][]dto.Message, len(chats))
+	for _, chat := range chats {
+		if messages[chat.ID], err = p.collectMessages(ctx, chat.ID, trainerID); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return profile.Email, []exportFile{
+		{name: "profile.json", data: profile},
+		{name: "trainings.json", data: trainings},
+		{name: "schedules.json", data: scheduled},
+		{name: "services.json", data: services},
+		{name: "chats.json", data: chats},
+		{name: "messages.json", data: messages},
+	}, nil
+}
+
+func (p personalDataService) collectMessages(ctx context.Context, userID, trainerID int) ([]dto.Message, error) {
+	var messages []dto.Message
+	for cursor := 0; ; {
+		page, err := p.chatService.GetChatMessage(ctx, userID, trainerID, cursor)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, page.Messages...)
+
+		if cursor = page.Cursor; cursor == 0 {
+			break
+		}
+	}
+
+	return messages, nil
+}
+
+// writeArchive сохраняет файлы выгрузки в zip вне /static, чтобы архив отдавался только владельцу
+func (p personalDataService) writeArchive(files []exportFile) (string, error) {
+	filePath := fmt.Sprintf("/exports/%s.zip", uuid.New().String())
+
+	if err := os.MkdirAll(filepath.Dir(".."+filePath), 0750); err != nil {
+		return "", err
+	}
+
+	archive, err := os.OpenFile(".."+filePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0640)
+	if err != nil {
+		return "", err
+	}
+
+	err = writeFiles(archive, files)
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(".." + filePath)
+		return "", err
+	}
+
+	return filePath, nil
+}
+
+func writeFiles(archive *os.File, files []exportFile) error {
+	writer := zip.NewWriter(archive)
+
+	for _, file := range files {
+		fileWriter, err := writer.Create(file.name)
+		if err != nil {
+			return err
+		}
+
+		encoder := json.NewEncoder(fileWriter)
+		encoder.SetIndent("", "  ")
+		if err = encoder.Encode(file.data); err != nil {
+			return err
+		}
+	}
+
+	return writer.Close()
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/repository"
+	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
+	"context"
+	"github.com/rs/zerolog"
+	"time"
+)
+
+type policyService struct {
+	policyRepo     repository.Policies
+	dbResponseTime time.Duration
+	logger         zerolog.Logger
+}
+
+func InitPolicyService(
+	policyRepo repository.Policies,
+	dbResponseTime time.Duration,
+	logger zerolog.Logger,
+) Policies {
+	return &policyService{
+		policyRepo:     policyRepo,
+		dbResponseTime: dbResponseTime,
+		logger:         logger,
+	}
+}
+
+func (p policyService) CheckPlan(ctx context.Context, caller domain.Caller, planID int) error {
+	ctx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	userID, err := p.policyRepo.GetPlanOwner(ctx, planID)
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	return p.checkUserResource(ctx, caller, userID, log.Plan, planID)
+}
+
+func (p policyService) CheckTraining(ctx context.Context, caller domain.Caller, trainingID int) error {
+	ctx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	owner, err := p.policyRepo.GetTrainingOwner(ctx, trainingID)
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	// Базовые тренировки не принадлежат никому и удаляются только администратором
+	switch {
+	case owner.UserID.Valid:
+		return p.checkUserResource(ctx, caller, int(owner.UserID.Int64), log.Training, trainingID)
+	case owner.TrainerID.Valid && caller.Type == utils.Trainer && caller.ID == int(owner.TrainerID.Int64):
+		return nil
+	}
+
+	return p.deny(caller, log.Training, trainingID)
+}
+
+func (p policyService) CheckUserTraining(ctx context.Context, caller domain.Caller, userTrainingID int) error {
+	return p.CheckUserTrainings(ctx, caller, []int{userTrainingID})
+}
+
+func (p policyService) CheckUserTrainings(ctx context.Context, caller domain.Caller, userTrainingIDs []int) error {
+	ctx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	userIDs, err := p.policyRepo.GetUserTrainingsOwners(ctx, userTrainingIDs)
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	for _, userID := range userIDs {
+		if err = p.checkUserResource(ctx, caller, userID, log.Schedule, userTrainingIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p policyService) CheckTrainerService(ctx context.Context, caller domain.Caller, serviceID int) error {
+	ctx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	trainerID, err := p.policyRepo.GetTrainerServiceOwner(ctx, serviceID)
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	if caller.Type != utils.Trainer || caller.ID != trainerID {
+		return p.deny(caller, log.Service, serviceID)
+	}
+
+	return nil
+}
+
+func (p policyService) CheckUserTrainerService(ctx context.Context, caller domain.Caller, serviceID int) error {
+	ctx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	owner, err := p.policyRepo.GetUserTrainerServiceOwner(ctx, serviceID)
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	if !isParticipant(caller, owner) {
+		return p.deny(caller, log.Contract, serviceID)
+	}
+
+	return nil
+}
+
+func (p policyService) CheckSchedule(ctx context.Context, caller domain.Caller, scheduleID int) error {
+	return p.CheckSchedules(ctx, caller, []int{scheduleID})
+}
+
+func (p policyService) CheckSchedules(ctx context.Context, caller domain.Caller, scheduleIDs []int) error {
+	ctx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	owners, err := p.policyRepo.GetSchedulesOwners(ctx, scheduleIDs)
+	if err != nil {
+		p.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	for _, owner := range owners {
+		if !isParticipant(caller, owner) {
+			return p.deny(caller, log.Schedule, scheduleIDs)
+		}
+	}
+
+	return nil
+}
+
+func (p policyService) CheckClient(ctx context.Context, caller domain.Caller, userID int) error {
+	ctx, cancel := context.WithTimeout(ctx, p.dbResponseTime)
+	defer cancel()
+
+	return p.checkUserResource(ctx, caller, userID, log.User, userID)
+}
+
+// checkUserResource пропускает владельца ресурса и тренеров, у которых владелец является клиентом
+func (p policyService) checkUserResource(ctx context.Context, caller domain.Caller, userID int, object string, objectID any) error {
+	switch caller.Type {
+	case utils.User:
+		if caller.ID == userID {
+			return nil
+		}
+	case utils.Trainer:
+		isClient, err := p.policyRepo.IsClient(ctx, userID, caller.ID)
+		if err != nil {
+			p.logger.Error().Msg(err.Error())
+			return err
+		}
+		if isClient {
+			return nil
+		}
+	}
+
+	return p.deny(caller, object, objectID)
+}
+
+func (p policyService) deny(caller domain.Caller, object string, objectID any) error {
+	p.logger.Warn().Msg(log.Normalizer(log.AccessDenied, object, objectID, caller.Type, caller.ID))
+
+	return errs.ErrForbidden
+}
+
+func isParticipant(caller domain.Caller, owner domain.Owner) bool {
+	switch caller.Type {
+	case utils.User:
+		return owner.UserID.Valid && int(owner.UserID.Int64) == caller.ID
+	case utils.Trainer:
+		return owner.TrainerID.Valid && int(owner.TrainerID.Int64) == caller.ID
+	}
+
+	return false
+}
@@ -4,6 +4,7 @@ import (
 	"BACKEND/internal/models/domain"
 	"BACKEND/internal/models/dto"
 	"BACKEND/pkg/responses"
+	"BACKEND/pkg/utils"
 	"context"
 	"github.com/gin-gonic/gin"
 	"mime/multipart"
@@ -17,9 +18,15 @@ type Base interface {
 	Delete(ctx context.Context, baseIDs []int) error
 }
 
+type Admins interface {
+	Save(ctx context.Context, admin domain.AdminCreate) (int, error)
+	Login(ctx context.Context, auth dto.Auth, ip string) (int, []string, error)
+	GetByID(ctx context.Context, adminID int) (dto.Admin, error)
+}
+
 type Users interface {
 	Register(ctx context.Context, user domain.UserCreate) (int, error)
-	Login(ctx context.Context, auth dto.Auth) (int, error)
+	Login(ctx context.Context, auth dto.Auth, ip string) (int, bool, error)
 	GetByID(ctx context.Context, userID int) (dto.User, error)
 	GetCovers(ctx context.Context, search string, cursor int) (dto.UserCoverPagination, error)
 	UpdateMain(ctx context.Context, user domain.UserUpdate) error
@@ -28,7 +35,7 @@ type Users interface {
 
 type Trainers interface {
 	Register(ctx context.Context, trainer domain.TrainerCreate) (int, error)
-	Login(ctx context.Context, auth dto.Auth) (int, error)
+	Login(ctx context.Context, auth dto.Auth, ip string) (int, bool, error)
 	GetByID(ctx context.Context, trainerID int) (dto.Trainer, error)
 	GetCovers(ctx context.Context, filters domain.FiltersTrainerCovers) (dto.TrainerCoverPagination, error)
 	UpdateMain(ctx context.Context, trainer domain.TrainerUpdate) error
@@ -36,18 +43,17 @@ type Trainers interface {
 	UpdateRoles(ctx context.Context, trainerID int, roleIDs []int) error
 	UpdateSpecializations(ctx context.Context, trainerID int, specializationIDs []int) error
 	CreateService(ctx context.Context, service domain.ServiceCreate) (int, error)
-	UpdateService(ctx context.Context, service domain.ServiceUpdate) error
+	UpdateService(ctx context.Context, trainerID int, service domain.ServiceUpdate) error
 	DeleteService(ctx context.Context, trainerID, serviceID int) error
 	CreateAchievement(ctx context.Context, trainerID int, achievement string) (int, error)
-	UpdateAchievementStatus(ctx context.Context, achievementID int, status bool) error
+	UpdateAchievementStatus(ctx context.Context, adminID, achievementID int, status bool) error
 	DeleteAchievement(ctx context.Context, trainerID, achievementID int) error
 }
 
 type UserTrainerServices interface {
 	Create(ctx context.Context, service domain.UserTrainerServiceCreate) (int, error)
-	Schedule(ctx context.Context, schedule domain.ScheduleService) (int, error)
-	GetSchedule(ctx context.Context, month, trainerID int) ([]dto.TrainingSchedule, error)
-	GetSchedulesByIDs(ctx context.Context, scheduleIDs []int) ([]dto.ScheduleServiceUser, error)
+	Schedule(ctx context.Context, caller domain.Caller, schedule domain.ScheduleService) (int, error)
+	GetSchedulesByIDs(ctx context.Context, viewer domain.Caller, scheduleIDs []int) ([]dto.ScheduleServiceUser, error)
 	DeleteScheduled(ctx context.Context, scheduleID int) error
 	GetUserServices(ctx context.Context, trainerID, cursor int) (dto.ServiceUserPagination, error)
 	GetTrainerServices(ctx context.Context, userID, cursor int) (dto.ServiceTrainerPagination, error)
@@ -55,9 +61,71 @@ type UserTrainerServices interface {
 	Delete(ctx context.Context, serviceID int) error
 }
 
+type Accounts interface {
+	SendVerification(ctx context.Context, userID int, userType string) error
+	Verify(ctx context.Context, token string) error
+	SendPasswordReset(ctx context.Context, email, userType string) error
+	ResetPassword(ctx context.Context, token, password string) error
+	RequestDeletion(ctx context.Context, userID int, userType, password string) (dto.DeletionSchedule, error)
+	CancelDeletion(ctx context.Context, userID int, userType string) error
+	PurgeDeleted(ctx context.Context)
+}
+
+type PersonalData interface {
+	RequestExport(ctx context.Context, userID int, userType string) (int, error)
+	GetExport(ctx context.Context, userID int, userType string, exportID int) (dto.DataExport, error)
+	GetExportFile(ctx context.Context, userID int, userType string, exportID int) (string, error)
+	DeleteExpired(ctx context.Context)
+}
+
+type TwoFactor interface {
+	Setup(ctx context.Context, accountID int, accountType string) (dto.TwoFactorSetup, error)
+	Enable(ctx context.Context, accountID int, accountType, code string) (dto.RecoveryCodes, error)
+	Disable(ctx context.Context, accountID int, accountType, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, accountID int, accountType, code string) (dto.RecoveryCodes, error)
+	CreateChallenge(ctx context.Context, data utils.SessionData) (dto.TwoFactorChallenge, bool, error)
+	VerifyChallenge(ctx context.Context, challengeToken, code string) (utils.SessionData, error)
+}
+
+type TrainerApplications interface {
+	Submit(c *gin.Context, application dto.TrainerApplicationCreate, certificates []*multipart.FileHeader) (int, error)
+	GetByID(ctx context.Context, applicationID int) (dto.TrainerApplication, error)
+	GetCovers(ctx context.Context, filters domain.FiltersTrainerApplications) (dto.TrainerApplicationCoverPagination, error)
+	Approve(ctx context.Context, adminID, applicationID int) (int, error)
+	Reject(ctx context.Context, adminID, applicationID int, reason string) error
+}
+
+type Calendar interface {
+	GetEvents(ctx context.Context, filters domain.FiltersCalendar) ([]dto.CalendarEvent, error)
+	CreateFeed(ctx context.Context, accountID int, accountType string) (dto.CalendarFeed, error)
+	DeleteFeed(ctx context.Context, accountID int, accountType string) error
+	GetFeed(ctx context.Context, token string) ([]byte, error)
+	GetTrainingICal(ctx context.Context, userTrainingID int) ([]byte, error)
+}
+
+type Availability interface {
+	GetAvailability(ctx context.Context, trainerID int) (dto.Availability, error)
+	UpdateAvailability(ctx context.Context, trainerID int, weekly []domain.AvailabilityInterval) error
+	CreateException(ctx context.Context, exception domain.AvailabilityException) (int, error)
+	DeleteException(ctx context.Context, trainerID, exceptionID int) error
+	GetSlots(ctx context.Context, filters domain.FiltersSlots) ([]dto.Slot, error)
+	ImportBusy(ctx context.Context, trainerID int, file *multipart.FileHeader) (int, error)
+	ClearBusy(ctx context.Context, trainerID int) error
+}
+
+type Moderation interface {
+	GetReports(ctx context.Context, filters domain.FiltersMessageReports) (dto.MessageReportPagination, error)
+	ReviewReport(ctx context.Context, adminID, reportID int, status string) error
+	Suspend(ctx context.Context, adminID int, accountType string, accountID int, suspension dto.Suspension) error
+	Unsuspend(ctx context.Context, adminID int, accountType string, accountID int) error
+}
+
 type Tokens interface {
-	Create(ctx context.Context, userID int, userType string) (responses.TokenResponse, error)
-	Refresh(ctx context.Context, refreshToken string) (responses.TokenResponse, error)
+	Create(ctx context.Context, data utils.SessionData) (responses.TokenResponse, error)
+	Refresh(ctx context.Context, refreshToken, ip string) (responses.TokenResponse, error)
+	GetSessions(ctx context.Context, userID int, userType, currentSessionID string) ([]dto.Session, error)
+	Logout(ctx context.Context, userID int, userType, sessionID string) error
+	LogoutAll(ctx context.Context, userID int, userType string) error
 }
 
 type Trainings interface {
@@ -72,11 +140,15 @@ type Trainings interface {
 	GetTrainingCoversByTrainerID(ctx context.Context, search string, trainerID, cursor int) (dto.TrainingCoverTrainerPagination, error)
 	GetTraining(ctx context.Context, trainingID int) (dto.Training, error)
 	GetTrainingTrainer(ctx context.Context, trainingID int) (dto.TrainingTrainer, error)
-	GetScheduleTrainings(ctx context.Context, userTrainingIDs []int) ([]dto.UserTraining, error)
+	GetScheduleTrainings(ctx context.Context, viewer domain.Caller, userTrainingIDs []int) ([]dto.UserTraining, error)
 	ScheduleTraining(ctx context.Context, training domain.ScheduleTraining) (int, []int, error)
-	GetSchedule(ctx context.Context, month, userID int) ([]dto.TrainingSchedule, error)
 	DeleteUserTraining(ctx context.Context, trainingID int) error
 	DeleteScheduledTraining(ctx context.Context, userTrainingID int) error
+	ScheduleSeries(ctx context.Context, training domain.ScheduleTraining) (int, error)
+	UpdateSeries(ctx context.Context, series domain.TrainingSeries) error
+	DeleteSeries(ctx context.Context, userID, seriesID int) error
+	SaveOccurrence(ctx context.Context, occurrence domain.TrainingOccurrence) (int, error)
+	CancelOccurrence(ctx context.Context, userID, seriesID int, occurrenceDate time.Time) error
 	CreatePlan(ctx context.Context, plan domain.PlanCreate) (int, error)
 	GetPlanCoversByUserID(ctx context.Context, userID int) ([]dto.PlanCover, error)
 	GetPlan(ctx context.Context, planID int) (dto.Plan, error)
@@ -85,8 +157,38 @@ type Trainings interface {
 }
 
 type Chat interface {
-	CreateMessage(ctx context.Context, message domain.MessageCreate) (int, time.Time, error)
+	CreateMessage(ctx context.Context, message domain.MessageCreate) (dto.Message, bool, error)
 	GetUserChats(ctx context.Context, userID int, search string) ([]dto.Chat, error)
 	GetTrainerChats(ctx context.Context, trainerID int, search string) ([]dto.Chat, error)
 	GetChatMessage(ctx context.Context, userID, trainerID, cursor int) (dto.MessagePagination, error)
+	SearchMessages(ctx context.Context, userID int, userType string, search string, to, cursor int) (dto.MessageFoundPagination, error)
+	MarkDelivered(ctx context.Context, userID int, userType string, messageID int) (domain.Receipt, error)
+	MarkRead(ctx context.Context, userID int, userType string, messageID int) (domain.Receipt, error)
+	GetMissedMessages(ctx context.Context, userID int, userType string, lastID, cursor int) (dto.MessagePagination, error)
+	SetOnline(ctx context.Context, userID int, userType, connID string) error
+	SetOffline(ctx context.Context, userID int, userType, connID string) error
+	GetPresence(ctx context.Context, userType string, userIDs []int) ([]dto.Presence, error)
+	EditMessage(ctx context.Context, userID int, userType string, messageID int, text string) (dto.Message, error)
+	DeleteMessage(ctx context.Context, userID int, userType string, messageID int) (dto.Message, error)
+	AnswerOffer(ctx context.Context, userID, messageID int, accept bool) (dto.Message, dto.Message, error)
+	GetMessageEdits(ctx context.Context, userID int, userType string, messageID int) ([]dto.MessageEdit, error)
+	Block(ctx context.Context, userID int, userType string, counterpartID int) error
+	Unblock(ctx context.Context, userID int, userType string, counterpartID int) error
+	GetBlocked(ctx context.Context, userID int, userType string) ([]dto.BlockedAccount, error)
+	IsChatOpen(ctx context.Context, userID, trainerID int) (bool, error)
+	ReportMessage(ctx context.Context, userID int, userType string, messageID int, reason string) (int, error)
+	UploadAttachment(c *gin.Context, file *multipart.FileHeader, kind string, userID int, userType string) (dto.Attachment, error)
+	DeleteUnattached(ctx context.Context)
+}
+
+type Policies interface {
+	CheckPlan(ctx context.Context, caller domain.Caller, planID int) error
+	CheckTraining(ctx context.Context, caller domain.Caller, trainingID int) error
+	CheckUserTraining(ctx context.Context, caller domain.Caller, userTrainingID int) error
+	CheckUserTrainings(ctx context.Context, caller domain.Caller, userTrainingIDs []int) error
+	CheckTrainerService(ctx context.Context, caller domain.Caller, serviceID int) error
+	CheckUserTrainerService(ctx context.Context, caller domain.Caller, serviceID int) error
+	CheckSchedule(ctx context.Context, caller domain.Caller, scheduleID int) error
+	CheckSchedules(ctx context.Context, caller domain.Caller, scheduleIDs []int) error
+	CheckClient(ctx context.Context, caller domain.Caller, userID int) error
 }
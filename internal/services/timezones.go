@@ -0,0 +1,27 @@
+package services
+
+import (
+	"BACKEND/pkg/utils"
+	"context"
+	"time"
+)
+
+// timezones - репозитории расписания, которые знают часовой пояс профиля
+type timezones interface {
+	GetTimezone(ctx context.Context, accountType string, accountID int) (string, error)
+}
+
+// accountLocation возвращает часовой пояс из профиля аккаунта. В нем вводится и показывается время расписания
+func accountLocation(ctx context.Context, repo timezones, accountType string, accountID int) (*time.Location, error) {
+	timezone, err := repo.GetTimezone(ctx, accountType, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return utils.LoadLocation(timezone)
+}
+
+// localTime считает дату и время t без учета зоны местным временем в loc
+func localTime(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, loc)
+}
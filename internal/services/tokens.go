@@ -1,50 +1,73 @@
 package services
 
 import (
+	"BACKEND/internal/converters"
+	"BACKEND/internal/models/dto"
 	"BACKEND/pkg/responses"
 	"BACKEND/pkg/utils"
 	"context"
+	"sort"
 )
 
 type tokenService struct {
-	jwtUtil utils.JWT
-	session utils.Session
+	jwtUtil   utils.JWT
+	session   utils.Session
+	converter converters.SessionConverter
 }
 
 func InitTokenService(jwtUtil utils.JWT, session utils.Session) Tokens {
 	return &tokenService{
-		jwtUtil: jwtUtil,
-		session: session,
+		jwtUtil:   jwtUtil,
+		session:   session,
+		converter: converters.InitSessionConverter(),
 	}
 }
 
-func (t tokenService) Create(ctx context.Context, userID int, userType string) (responses.TokenResponse, error) {
-	accessToken := t.jwtUtil.CreateToken(userID, userType)
-
-	refreshToken, err := t.session.Set(ctx, utils.SessionData{
-		UserID:   userID,
-		UserType: userType,
-	})
+func (t tokenService) Create(ctx context.Context, data utils.SessionData) (responses.TokenResponse, error) {
+	refreshToken, data, err := t.session.Set(ctx, data)
 	if err != nil {
 		return responses.TokenResponse{}, err
 	}
 
+	accessToken := t.jwtUtil.CreateToken(data.UserID, data.UserType, data.ID)
+
 	return responses.TokenResponse{
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 	}, nil
 }
 
-func (t tokenService) Refresh(ctx context.Context, refreshToken string) (responses.TokenResponse, error) {
-	newRefreshToken, data, err := t.session.GetAndUpdate(ctx, refreshToken)
+func (t tokenService) Refresh(ctx context.Context, refreshToken, ip string) (responses.TokenResponse, error) {
+	newRefreshToken, data, err := t.session.GetAndUpdate(ctx, refreshToken, ip)
 	if err != nil {
 		return responses.TokenResponse{}, err
 	}
 
-	accessToken := t.jwtUtil.CreateToken(data.UserID, data.UserType)
+	accessToken := t.jwtUtil.CreateToken(data.UserID, data.UserType, data.ID)
 
 	return responses.TokenResponse{
 		AccessToken:  accessToken,
 		RefreshToken: newRefreshToken,
 	}, nil
 }
+
+func (t tokenService) GetSessions(ctx context.Context, userID int, userType, currentSessionID string) ([]dto.Session, error) {
+	sessions, err := t.session.GetAll(ctx, userID, userType)
+	if err != nil {
+		return []dto.Session{}, err
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+
+	return t.converter.SessionsDataToDTO(sessions, currentSessionID), nil
+}
+
+func (t tokenService) Logout(ctx context.Context, userID int, userType, sessionID string) error {
+	return t.session.Delete(ctx, userID, userType, sessionID)
+}
+
+func (t tokenService) LogoutAll(ctx context.Context, userID int, userType string) error {
+	return t.session.DeleteAll(ctx, userID, userType)
+}
@@ -0,0 +1,209 @@
+package services
+
+import (
+	"BACKEND/internal/converters"
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/repository"
+	"BACKEND/pkg/config"
+	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
+	"context"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type trainerApplicationService struct {
+	applicationRepo repository.TrainerApplications
+	session         utils.Session
+	mailer          utils.Mailer
+	converter       converters.TrainerApplicationConverter
+	frontendURL     string
+	setPasswordTime time.Duration
+	dbResponseTime  time.Duration
+	logger          zerolog.Logger
+}
+
+func InitTrainerApplicationService(
+	applicationRepo repository.TrainerApplications,
+	session utils.Session,
+	mailer utils.Mailer,
+	dbResponseTime time.Duration,
+	logger zerolog.Logger,
+) TrainerApplications {
+	return &trainerApplicationService{
+		applicationRepo: applicationRepo,
+		session:         session,
+		mailer:          mailer,
+		converter:       converters.InitTrainerApplicationConverter(),
+		frontendURL:     viper.GetString(config.FrontendURL),
+		setPasswordTime: time.Duration(viper.GetInt(config.VerificationTokenTime)) * time.Hour,
+		dbResponseTime:  dbResponseTime,
+		logger:          logger,
+	}
+}
+
+// Submit `c *gin.Context` передается для сохранения сертификатов, как и в UpdatePhotoUrl
+func (t trainerApplicationService) Submit(c *gin.Context, application dto.TrainerApplicationCreate, certificates []*multipart.FileHeader) (int, error) {
+	saved := make([]domain.Certificate, 0, len(certificates))
+	removeSaved := func() {
+		for _, certificate := range saved {
+			os.Remove(".." + certificate.Url)
+		}
+	}
+
+	for _, certificate := range certificates {
+		filePath := fmt.Sprintf("/static/docs/certificates/%s%s", uuid.New().String(), filepath.Ext(certificate.Filename))
+		if err := c.SaveUploadedFile(certificate, ".."+filePath); err != nil {
+			removeSaved()
+			t.logger.Error().Msg("Failed to save uploaded file")
+			return 0, err
+		}
+
+		saved = append(saved, domain.Certificate{
+			Name: filepath.Base(certificate.Filename),
+			Url:  filePath,
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), t.dbResponseTime)
+	defer cancel()
+
+	createdID, err := t.applicationRepo.Create(ctx, t.converter.TrainerApplicationCreateDTOToDomain(application, saved))
+	if err != nil {
+		removeSaved()
+		t.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.CreateObject, log.Application, createdID))
+
+	return createdID, nil
+}
+
+func (t trainerApplicationService) GetByID(ctx context.Context, applicationID int) (dto.TrainerApplication, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	application, err := t.applicationRepo.GetByID(ctx, applicationID)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return dto.TrainerApplication{}, err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.GetObject, log.Application, applicationID))
+
+	return t.converter.TrainerApplicationDomainToDTO(application), nil
+}
+
+func (t trainerApplicationService) GetCovers(ctx context.Context, filters domain.FiltersTrainerApplications) (dto.TrainerApplicationCoverPagination, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	applications, err := t.applicationRepo.GetCovers(ctx, filters)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return dto.TrainerApplicationCoverPagination{}, err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Application))
+
+	return t.converter.TrainerApplicationCoverPaginationDomainToDTO(applications), nil
+}
+
+// Approve создает тренера по заявке. Пароль тренер задает сам по ссылке из письма
+func (t trainerApplicationService) Approve(ctx context.Context, adminID, applicationID int) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	application, err := t.applicationRepo.GetByID(ctx, applicationID)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+
+	trainerID, err := t.applicationRepo.Approve(ctx, applicationID, adminID, domain.TrainerCreate{
+		TrainerBase: application.TrainerBase,
+		Email:       application.Email,
+		// Случайный пароль никто не знает, вход возможен только после установки своего
+		Password: uuid.New().String(),
+	}, baseIDs(application.Roles), baseIDs(application.Specializations))
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.ApproveApplication, applicationID, adminID, trainerID))
+
+	// Тренер уже создан, поэтому ошибка письма не отменяет одобрение: пароль можно задать через восстановление
+	token, err := t.session.SetToken(ctx, utils.ResetToken, utils.SessionData{
+		UserID:   trainerID,
+		UserType: utils.Trainer,
+	}, t.setPasswordTime)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return trainerID, nil
+	}
+
+	t.notify(ctx, utils.ResetToken, utils.Trainer, trainerID, utils.Mail{
+		To:      application.Email,
+		Subject: "Заявка тренера одобрена",
+		Body: fmt.Sprintf("Ваша заявка тренера одобрена. Чтобы войти, задайте пароль по ссылке: %s/reset-password?token=%s\r\n\r\n"+
+			"Ссылка действительна %d ч. Позже пароль можно будет задать через восстановление пароля.",
+			t.frontendURL, token, int(t.setPasswordTime.Hours())),
+	})
+
+	return trainerID, nil
+}
+
+func (t trainerApplicationService) Reject(ctx context.Context, adminID, applicationID int, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	application, err := t.applicationRepo.GetByID(ctx, applicationID)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	if err = t.applicationRepo.Reject(ctx, applicationID, adminID, reason); err != nil {
+		t.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.RejectApplication, applicationID, adminID))
+
+	t.notify(ctx, domain.ApplicationRejected, log.Application, applicationID, utils.Mail{
+		To:      application.Email,
+		Subject: "Заявка тренера отклонена",
+		Body:    fmt.Sprintf("Ваша заявка тренера отклонена. Причина: %s\r\n\r\nВы можете подать новую заявку.", reason),
+	})
+
+	return nil
+}
+
+// notify отправляет письмо о решении по заявке. Решение уже сохранено, поэтому ошибка только логируется
+func (t trainerApplicationService) notify(ctx context.Context, mailType, recipientType string, recipientID int, mail utils.Mail) {
+	if err := t.mailer.Send(ctx, mail); err != nil {
+		t.logger.Error().Msg(err.Error())
+		return
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.SendMail, mailType, recipientType, recipientID))
+}
+
+func baseIDs(bases []domain.Base) []int {
+	ids := make([]int, len(bases))
+	for i, base := range bases {
+		ids[i] = base.ID
+	}
+
+	return ids
+}
@@ -23,18 +23,21 @@ import (
 type trainerService struct {
 	trainerRepo    repository.Trainers
 	converter      converters.TrainerConverter
+	guard          loginGuard
 	dbResponseTime time.Duration
 	logger         zerolog.Logger
 }
 
 func InitTrainerService(
 	trainerRepo repository.Trainers,
+	limiter utils.LoginLimiter,
 	dbResponseTime time.Duration,
 	logger zerolog.Logger,
 ) Trainers {
 	return &trainerService{
 		trainerRepo:    trainerRepo,
 		converter:      converters.InitTrainerConverter(),
+		guard:          loginGuard{limiter: limiter, logger: logger},
 		dbResponseTime: dbResponseTime,
 		logger:         logger,
 	}
@@ -55,25 +58,35 @@ func (t trainerService) Register(ctx context.Context, trainer domain.TrainerCrea
 	return createdID, nil
 }
 
-func (t trainerService) Login(ctx context.Context, auth dto.Auth) (int, error) {
+func (t trainerService) Login(ctx context.Context, auth dto.Auth, ip string) (int, bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
 	defer cancel()
 
-	id, hashedPassword, err := t.trainerRepo.GetSecure(ctx, auth.Email)
+	if err := t.guard.check(ctx, utils.Trainer, auth.Email, ip); err != nil {
+		return 0, false, err
+	}
+
+	secure, err := t.trainerRepo.GetSecure(ctx, auth.Email)
 	if err != nil {
-		t.logger.Error().Msg(err.Error())
-		return 0, err
+		return 0, false, t.guard.fail(ctx, utils.Trainer, auth.Email, ip, err)
 	}
 
-	isCompare := utils.ComparePassword(hashedPassword, auth.Password)
+	isCompare := utils.ComparePassword(secure.Password, auth.Password)
 	if !isCompare {
-		t.logger.Error().Msg(errs.InvalidPassword.Error())
-		return 0, errs.InvalidPassword
+		return 0, false, t.guard.fail(ctx, utils.Trainer, auth.Email, ip, errs.InvalidPassword)
+	}
+
+	t.guard.success(ctx, utils.Trainer, auth.Email)
+
+	// Заблокированному аккаунту о блокировке сообщается только после проверки пароля
+	if secure.IsSuspended {
+		t.logger.Error().Msg(log.Normalizer(log.SuspendedLogin, utils.Trainer, secure.ID))
+		return 0, false, errs.ErrSuspended
 	}
 
 	t.logger.Info().Msg(log.Normalizer(log.AuthorizeTrainer, auth.Email))
 
-	return id, nil
+	return secure.ID, secure.IsVerified, nil
 }
 
 func (t trainerService) GetByID(ctx context.Context, trainerID int) (dto.Trainer, error) {
@@ -233,11 +246,11 @@ func (t trainerService) CreateService(ctx context.Context, service domain.Servic
 	return createdID, nil
 }
 
-func (t trainerService) UpdateService(ctx context.Context, service domain.ServiceUpdate) error {
+func (t trainerService) UpdateService(ctx context.Context, trainerID int, service domain.ServiceUpdate) error {
 	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
 	defer cancel()
 
-	err := t.trainerRepo.UpdateService(ctx, service)
+	err := t.trainerRepo.UpdateService(ctx, trainerID, service)
 	if err != nil {
 		t.logger.Error().Msg(err.Error())
 		return err
@@ -278,17 +291,17 @@ func (t trainerService) CreateAchievement(ctx context.Context, trainerID int, ac
 	return createdID, nil
 }
 
-func (t trainerService) UpdateAchievementStatus(ctx context.Context, achievementID int, status bool) error {
+func (t trainerService) UpdateAchievementStatus(ctx context.Context, adminID, achievementID int, status bool) error {
 	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
 	defer cancel()
 
-	err := t.trainerRepo.UpdateAchievementStatus(ctx, achievementID, status)
+	err := t.trainerRepo.UpdateAchievementStatus(ctx, adminID, achievementID, status)
 	if err != nil {
 		t.logger.Error().Msg(err.Error())
 		return err
 	}
 
-	t.logger.Info().Msg(log.Normalizer(log.UpdateObject, log.Trainer, 1505))
+	t.logger.Info().Msg(log.Normalizer(log.ConfirmByAdmin, log.Achievement, achievementID, status, adminID))
 
 	return nil
 }
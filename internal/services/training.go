@@ -2,12 +2,15 @@ package services
 
 import (
 	"BACKEND/internal/converters"
+	"BACKEND/internal/errs"
 	"BACKEND/internal/models/domain"
 	"BACKEND/internal/models/dto"
 	"BACKEND/internal/repository"
 	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
 	"context"
 	"github.com/rs/zerolog"
+	"gopkg.in/guregu/null.v3"
 	"strings"
 	"time"
 )
@@ -204,25 +207,40 @@ func (t trainingService) GetTrainingTrainer(ctx context.Context, trainingID int)
 	return t.converter.TrainingTrainerDomainToDTO(training), nil
 }
 
-func (t trainingService) GetScheduleTrainings(ctx context.Context, userTrainingIDs []int) ([]dto.UserTraining, error) {
+// GetScheduleTrainings возвращает тренировки из расписания со временем в часовом поясе viewer
+func (t trainingService) GetScheduleTrainings(ctx context.Context, viewer domain.Caller, userTrainingIDs []int) ([]dto.UserTraining, error) {
 	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
 	defer cancel()
 
+	loc, err := accountLocation(ctx, t.trainingRepo, viewer.Type, viewer.ID)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return []dto.UserTraining{}, err
+	}
+
 	training, err := t.trainingRepo.GetScheduleTrainings(ctx, userTrainingIDs)
 	if err != nil {
 		t.logger.Error().Msg(err.Error())
 		return []dto.UserTraining{}, err
 	}
 
-	t.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Training, userTrainingIDs))
+	t.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Training))
 
-	return t.converter.TrainingsDateDomainToDTO(training), nil
+	return t.converter.TrainingsDateDomainToDTO(training, loc), nil
 }
 
+// ScheduleTraining добавляет тренировку в расписание. Время приходит местным в часовом поясе пользователя
 func (t trainingService) ScheduleTraining(ctx context.Context, training domain.ScheduleTraining) (int, []int, error) {
 	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
 	defer cancel()
 
+	loc, err := accountLocation(ctx, t.trainingRepo, utils.User, training.UserID)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return 0, []int{}, err
+	}
+	training.TimeStart, training.TimeEnd = localTime(training.TimeStart, loc), localTime(training.TimeEnd, loc)
+
 	createdID, createdIDs, err := t.trainingRepo.ScheduleTraining(ctx, training)
 	if err != nil {
 		t.logger.Error().Msg(err.Error())
@@ -234,19 +252,161 @@ func (t trainingService) ScheduleTraining(ctx context.Context, training domain.S
 	return createdID, createdIDs, nil
 }
 
-func (t trainingService) GetSchedule(ctx context.Context, month, userID int) ([]dto.TrainingSchedule, error) {
+// ScheduleSeries создает серию повторяющихся тренировок. Вхождения не сохраняются,
+// а разворачиваются из правила при запросе календаря. Серия повторяется в часовом поясе пользователя
+func (t trainingService) ScheduleSeries(ctx context.Context, training domain.ScheduleTraining) (int, error) {
 	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
 	defer cancel()
 
-	schedules, err := t.trainingRepo.GetSchedule(ctx, month, userID)
+	rrule, dateEnd, err := seriesRule(training.RRule.String, dayStart(training.TimeStart), training.TimeStart, training.TimeEnd)
+	if err != nil {
+		return 0, err
+	}
+	training.RRule = null.StringFrom(rrule)
+
+	seriesID, err := t.trainingRepo.CreateSeries(ctx, training, dateEnd)
 	if err != nil {
 		t.logger.Error().Msg(err.Error())
-		return []dto.TrainingSchedule{}, err
+		return 0, err
 	}
 
-	t.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Schedule))
+	t.logger.Info().Msg(log.Normalizer(log.CreateObject, log.Series, seriesID))
+
+	return seriesID, nil
+}
+
+func (t trainingService) UpdateSeries(ctx context.Context, series domain.TrainingSeries) error {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	rrule, dateEnd, err := seriesRule(series.RRule, series.DateStart, series.TimeStart, series.TimeEnd)
+	if err != nil {
+		return err
+	}
+	series.RRule, series.DateEnd = rrule, dateEnd
+
+	if err = t.trainingRepo.UpdateSeries(ctx, series); err != nil {
+		t.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.UpdateObject, log.Series, series.ID))
+
+	return nil
+}
+
+func (t trainingService) DeleteSeries(ctx context.Context, userID, seriesID int) error {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	if err := t.trainingRepo.DeleteSeries(ctx, userID, seriesID); err != nil {
+		t.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.DeleteObject, log.Series, seriesID))
+
+	return nil
+}
+
+// SaveOccurrence переносит одно вхождение серии, не меняя остальные. Новое время приходит местным
+// в часовом поясе пользователя. Возвращает id записи users_trainings
+func (t trainingService) SaveOccurrence(ctx context.Context, occurrence domain.TrainingOccurrence) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	if dayStart(occurrence.TimeStart).IsZero() || !occurrence.TimeEnd.After(occurrence.TimeStart) {
+		return 0, errs.ErrBadBody
+	}
+
+	if err := t.checkOccurrence(ctx, occurrence.UserID, occurrence.SeriesID, occurrence.OccurrenceDate); err != nil {
+		return 0, err
+	}
+
+	loc, err := accountLocation(ctx, t.trainingRepo, utils.User, occurrence.UserID)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+	occurrence.TimeStart, occurrence.TimeEnd = localTime(occurrence.TimeStart, loc), localTime(occurrence.TimeEnd, loc)
+
+	userTrainingID, err := t.trainingRepo.SaveOccurrence(ctx, occurrence)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.UpdateObject, log.Schedule, userTrainingID))
+
+	return userTrainingID, nil
+}
+
+func (t trainingService) CancelOccurrence(ctx context.Context, userID, seriesID int, occurrenceDate time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	if err := t.checkOccurrence(ctx, userID, seriesID, occurrenceDate); err != nil {
+		return err
+	}
+
+	if err := t.trainingRepo.CancelOccurrence(ctx, seriesID, occurrenceDate); err != nil {
+		t.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.CancelOccurrence, occurrenceDate.Format(time.DateOnly), seriesID, userID))
+
+	return nil
+}
+
+// checkOccurrence проверяет, что серия принадлежит пользователю и в день occurrenceDate у нее есть неотмененное вхождение
+func (t trainingService) checkOccurrence(ctx context.Context, userID, seriesID int, occurrenceDate time.Time) error {
+	series, err := t.trainingRepo.GetSeries(ctx, userID, seriesID)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	rule, err := utils.ParseRRule(series.RRule)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	if !rule.IsOccurrence(series.DateStart, occurrenceDate) {
+		return errs.ErrNoOccurrence
+	}
+	for _, cancelled := range series.Cancelled {
+		if cancelled.Equal(dayStart(occurrenceDate)) {
+			return errs.ErrNoOccurrence
+		}
+	}
+
+	return nil
+}
+
+// seriesRule проверяет правило и время серии. Возвращает правило в каноническом виде
+// и день последнего вхождения, если серия конечна
+func seriesRule(rrule string, dateStart, timeStart, timeEnd time.Time) (string, null.Time, error) {
+	if dateStart.IsZero() || clock(timeStart) >= clock(timeEnd) {
+		return "", null.Time{}, errs.ErrBadBody
+	}
+
+	rule, err := utils.ParseRRule(rrule)
+	if err != nil {
+		return "", null.Time{}, errs.ErrBadRRule
+	}
+
+	var dateEnd null.Time
+	if rule.Count > 0 || !rule.Until.IsZero() {
+		last, ok := rule.Last(dateStart)
+		if !ok {
+			return "", null.Time{}, errs.ErrBadRRule
+		}
+		dateEnd = null.TimeFrom(last)
+	}
 
-	return t.converter.TrainingSchedulesDomainToDTO(schedules), nil
+	return rule.String(), dateEnd, nil
 }
 
 func (t trainingService) DeleteUserTraining(ctx context.Context, trainingID int) error {
@@ -259,7 +419,7 @@ func (t trainingService) DeleteUserTraining(ctx context.Context, trainingID int)
 		return err
 	}
 
-	t.logger.Info().Msg(log.Normalizer(log.DeleteObject, log.Training))
+	t.logger.Info().Msg(log.Normalizer(log.DeleteObject, log.Training, trainingID))
 
 	return nil
 }
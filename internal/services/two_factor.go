@@ -0,0 +1,281 @@
+package services
+
+import (
+	"BACKEND/internal/errs"
+	"BACKEND/internal/models/domain"
+	"BACKEND/internal/models/dto"
+	"BACKEND/internal/repository"
+	"BACKEND/pkg/config"
+	"BACKEND/pkg/log"
+	"BACKEND/pkg/utils"
+	"context"
+	"errors"
+	"github.com/rs/zerolog"
+	"github.com/spf13/viper"
+	"strings"
+	"time"
+)
+
+type twoFactorService struct {
+	twoFactorRepo  repository.TwoFactor
+	session        utils.Session
+	issuer         string
+	challengeTime  time.Duration
+	dbResponseTime time.Duration
+	logger         zerolog.Logger
+}
+
+func InitTwoFactorService(
+	twoFactorRepo repository.TwoFactor,
+	session utils.Session,
+	dbResponseTime time.Duration,
+	logger zerolog.Logger,
+) TwoFactor {
+	return &twoFactorService{
+		twoFactorRepo:  twoFactorRepo,
+		session:        session,
+		issuer:         viper.GetString(config.TOTPIssuer),
+		challengeTime:  time.Duration(viper.GetInt(config.TwoFactorChallengeTime)) * time.Minute,
+		dbResponseTime: dbResponseTime,
+		logger:         logger,
+	}
+}
+
+// Setup выдает новый секрет. До подтверждения кодом в Enable вход без второго фактора не меняется
+func (t twoFactorService) Setup(ctx context.Context, accountID int, accountType string) (dto.TwoFactorSetup, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	email, err := t.twoFactorRepo.GetEmail(ctx, accountType, accountID)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return dto.TwoFactorSetup{}, err
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return dto.TwoFactorSetup{}, err
+	}
+
+	err = t.twoFactorRepo.SetSecret(ctx, accountType, accountID, secret)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return dto.TwoFactorSetup{}, err
+	}
+
+	return dto.TwoFactorSetup{
+		Secret: secret,
+		URI:    utils.TOTPURI(t.issuer, email, secret),
+	}, nil
+}
+
+func (t twoFactorService) Enable(ctx context.Context, accountID int, accountType, code string) (dto.RecoveryCodes, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	twoFactor, err := t.twoFactorRepo.Get(ctx, accountType, accountID)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return dto.RecoveryCodes{}, err
+	}
+
+	if twoFactor.IsEnabled {
+		return dto.RecoveryCodes{}, errs.ErrTwoFactorEnabled
+	}
+
+	step, ok := utils.ValidateTOTP(twoFactor.Secret, normalizeCode(code), time.Now())
+	if !ok {
+		t.logger.Warn().Msg(log.Normalizer(log.TwoFactorFailed, accountType, accountID))
+		return dto.RecoveryCodes{}, errs.ErrInvalidCode
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return dto.RecoveryCodes{}, err
+	}
+
+	err = t.twoFactorRepo.Enable(ctx, twoFactor.ID, step, hashes)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return dto.RecoveryCodes{}, err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.TwoFactorEnable, accountType, accountID))
+
+	return dto.RecoveryCodes{Codes: codes}, nil
+}
+
+func (t twoFactorService) Disable(ctx context.Context, accountID int, accountType, code string) error {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	twoFactor, err := t.getEnabled(ctx, accountID, accountType)
+	if err != nil {
+		return err
+	}
+
+	if err = t.checkCode(ctx, twoFactor, accountID, accountType, code); err != nil {
+		return err
+	}
+
+	err = t.twoFactorRepo.Delete(ctx, twoFactor.ID)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.TwoFactorDisable, accountType, accountID))
+
+	return nil
+}
+
+func (t twoFactorService) RegenerateRecoveryCodes(ctx context.Context, accountID int, accountType, code string) (dto.RecoveryCodes, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	twoFactor, err := t.getEnabled(ctx, accountID, accountType)
+	if err != nil {
+		return dto.RecoveryCodes{}, err
+	}
+
+	if err = t.checkCode(ctx, twoFactor, accountID, accountType, code); err != nil {
+		return dto.RecoveryCodes{}, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return dto.RecoveryCodes{}, err
+	}
+
+	err = t.twoFactorRepo.ReplaceRecoveryCodes(ctx, twoFactor.ID, hashes)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return dto.RecoveryCodes{}, err
+	}
+
+	t.logger.Info().Msg(log.Normalizer(log.UpdateObject, log.TwoFactor, twoFactor.ID))
+
+	return dto.RecoveryCodes{Codes: codes}, nil
+}
+
+// CreateChallenge выдает токен второго шага входа, если у аккаунта включена двухфакторная аутентификация.
+// Второе значение сообщает, нужен ли второй шаг
+func (t twoFactorService) CreateChallenge(ctx context.Context, data utils.SessionData) (dto.TwoFactorChallenge, bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	twoFactor, err := t.twoFactorRepo.Get(ctx, data.UserType, data.UserID)
+	if err != nil {
+		if errors.Is(err, errs.ErrNoTwoFactor) {
+			return dto.TwoFactorChallenge{}, false, nil
+		}
+		t.logger.Error().Msg(err.Error())
+		return dto.TwoFactorChallenge{}, false, err
+	}
+
+	if !twoFactor.IsEnabled {
+		return dto.TwoFactorChallenge{}, false, nil
+	}
+
+	token, err := t.session.SetToken(ctx, utils.TwoFactorToken, data, t.challengeTime)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return dto.TwoFactorChallenge{}, false, err
+	}
+
+	return dto.TwoFactorChallenge{
+		ChallengeToken: token,
+		ExpiresIn:      int(t.challengeTime.Seconds()),
+	}, true, nil
+}
+
+// VerifyChallenge проверяет код второго шага. Токен одноразовый: после неверного кода вход начинается заново
+func (t twoFactorService) VerifyChallenge(ctx context.Context, challengeToken, code string) (utils.SessionData, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.dbResponseTime)
+	defer cancel()
+
+	data, err := t.session.PopToken(ctx, utils.TwoFactorToken, challengeToken)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return utils.SessionData{}, err
+	}
+
+	twoFactor, err := t.getEnabled(ctx, data.UserID, data.UserType)
+	if err != nil {
+		return utils.SessionData{}, err
+	}
+
+	if err = t.checkCode(ctx, twoFactor, data.UserID, data.UserType, code); err != nil {
+		return utils.SessionData{}, err
+	}
+
+	return data, nil
+}
+
+func (t twoFactorService) getEnabled(ctx context.Context, accountID int, accountType string) (domain.TwoFactor, error) {
+	twoFactor, err := t.twoFactorRepo.Get(ctx, accountType, accountID)
+	if err != nil {
+		t.logger.Error().Msg(err.Error())
+		return domain.TwoFactor{}, err
+	}
+
+	if !twoFactor.IsEnabled {
+		t.logger.Error().Msg(errs.ErrNoTwoFactor.Error())
+		return domain.TwoFactor{}, errs.ErrNoTwoFactor
+	}
+
+	return twoFactor, nil
+}
+
+// checkCode принимает код из приложения или один из кодов восстановления
+func (t twoFactorService) checkCode(ctx context.Context, twoFactor domain.TwoFactor, accountID int, accountType, code string) error {
+	code = normalizeCode(code)
+
+	var err error
+	if utils.IsTOTPCode(code) {
+		step, ok := utils.ValidateTOTP(twoFactor.Secret, code, time.Now())
+		if !ok {
+			t.logger.Warn().Msg(log.Normalizer(log.TwoFactorFailed, accountType, accountID))
+			return errs.ErrInvalidCode
+		}
+		err = t.twoFactorRepo.UseStep(ctx, twoFactor.ID, step)
+	} else {
+		err = t.twoFactorRepo.UseRecoveryCode(ctx, twoFactor.ID, utils.HashRecoveryCode(code))
+	}
+
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidCode) {
+			t.logger.Warn().Msg(log.Normalizer(log.TwoFactorFailed, accountType, accountID))
+		} else {
+			t.logger.Error().Msg(err.Error())
+		}
+		return err
+	}
+
+	if !utils.IsTOTPCode(code) {
+		t.logger.Info().Msg(log.Normalizer(log.RecoveryCodeUsed, accountType, accountID))
+	}
+
+	return nil
+}
+
+func normalizeCode(code string) string {
+	return strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes, err := utils.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, utils.HashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
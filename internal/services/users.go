@@ -23,18 +23,21 @@ import (
 type userService struct {
 	userRepo       repository.Users
 	converter      converters.UserConverter
+	guard          loginGuard
 	dbResponseTime time.Duration
 	logger         zerolog.Logger
 }
 
 func InitUserService(
 	userRepo repository.Users,
+	limiter utils.LoginLimiter,
 	dbResponseTime time.Duration,
 	logger zerolog.Logger,
 ) Users {
 	return &userService{
 		userRepo:       userRepo,
 		converter:      converters.InitUserConverter(),
+		guard:          loginGuard{limiter: limiter, logger: logger},
 		dbResponseTime: dbResponseTime,
 		logger:         logger,
 	}
@@ -55,25 +58,35 @@ func (u userService) Register(ctx context.Context, user domain.UserCreate) (int,
 	return createdID, nil
 }
 
-func (u userService) Login(ctx context.Context, auth dto.Auth) (int, error) {
+func (u userService) Login(ctx context.Context, auth dto.Auth, ip string) (int, bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, u.dbResponseTime)
 	defer cancel()
 
-	id, hashedPassword, err := u.userRepo.GetSecure(ctx, auth.Email)
+	if err := u.guard.check(ctx, utils.User, auth.Email, ip); err != nil {
+		return 0, false, err
+	}
+
+	secure, err := u.userRepo.GetSecure(ctx, auth.Email)
 	if err != nil {
-		u.logger.Error().Msg(err.Error())
-		return 0, err
+		return 0, false, u.guard.fail(ctx, utils.User, auth.Email, ip, err)
 	}
 
-	isCompare := utils.ComparePassword(hashedPassword, auth.Password)
+	isCompare := utils.ComparePassword(secure.Password, auth.Password)
 	if !isCompare {
-		u.logger.Error().Msg(errs.InvalidPassword.Error())
-		return 0, errs.InvalidPassword
+		return 0, false, u.guard.fail(ctx, utils.User, auth.Email, ip, errs.InvalidPassword)
+	}
+
+	u.guard.success(ctx, utils.User, auth.Email)
+
+	// Заблокированному аккаунту о блокировке сообщается только после проверки пароля
+	if secure.IsSuspended {
+		u.logger.Error().Msg(log.Normalizer(log.SuspendedLogin, utils.User, secure.ID))
+		return 0, false, errs.ErrSuspended
 	}
 
 	u.logger.Info().Msg(log.Normalizer(log.AuthorizeUser, auth.Email))
 
-	return id, nil
+	return secure.ID, secure.IsVerified, nil
 }
 
 func (u userService) GetByID(ctx context.Context, userID int) (dto.User, error) {
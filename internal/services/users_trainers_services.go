@@ -2,6 +2,7 @@ package services
 
 import (
 	"BACKEND/internal/converters"
+	"BACKEND/internal/errs"
 	"BACKEND/internal/models/domain"
 	"BACKEND/internal/models/dto"
 	"BACKEND/internal/repository"
@@ -46,10 +47,22 @@ func (s usersTrainersServicesService) Create(ctx context.Context, service domain
 	return createdID, nil
 }
 
-func (s usersTrainersServicesService) Schedule(ctx context.Context, schedule domain.ScheduleService) (int, error) {
+// Schedule записывает занятие по договору. Время приходит местным в часовом поясе того, кто записывает
+func (s usersTrainersServicesService) Schedule(ctx context.Context, caller domain.Caller, schedule domain.ScheduleService) (int, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.dbResponseTime)
 	defer cancel()
 
+	if clock(schedule.TimeStart) >= clock(schedule.TimeEnd) {
+		return 0, errs.ErrBadBody
+	}
+
+	loc, err := accountLocation(ctx, s.serviceRepo, caller.Type, caller.ID)
+	if err != nil {
+		s.logger.Error().Msg(err.Error())
+		return 0, err
+	}
+	schedule.TimeStart, schedule.TimeEnd = localTime(schedule.TimeStart, loc), localTime(schedule.TimeEnd, loc)
+
 	createdID, err := s.serviceRepo.Schedule(ctx, schedule)
 	if err != nil {
 		s.logger.Error().Msg(err.Error())
@@ -61,25 +74,17 @@ func (s usersTrainersServicesService) Schedule(ctx context.Context, schedule dom
 	return createdID, nil
 }
 
-func (s usersTrainersServicesService) GetSchedule(ctx context.Context, month, trainerID int) ([]dto.TrainingSchedule, error) {
+// GetSchedulesByIDs возвращает занятия со временем в часовом поясе viewer
+func (s usersTrainersServicesService) GetSchedulesByIDs(ctx context.Context, viewer domain.Caller, scheduleIDs []int) ([]dto.ScheduleServiceUser, error) {
 	ctx, cancel := context.WithTimeout(ctx, s.dbResponseTime)
 	defer cancel()
 
-	schedules, err := s.serviceRepo.GetSchedule(ctx, month, trainerID)
+	loc, err := accountLocation(ctx, s.serviceRepo, viewer.Type, viewer.ID)
 	if err != nil {
 		s.logger.Error().Msg(err.Error())
-		return []dto.TrainingSchedule{}, err
+		return []dto.ScheduleServiceUser{}, err
 	}
 
-	s.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Service))
-
-	return s.converter.SchedulesDomainToDTO(schedules), nil
-}
-
-func (s usersTrainersServicesService) GetSchedulesByIDs(ctx context.Context, scheduleIDs []int) ([]dto.ScheduleServiceUser, error) {
-	ctx, cancel := context.WithTimeout(ctx, s.dbResponseTime)
-	defer cancel()
-
 	schedules, err := s.serviceRepo.GetSchedulesByIDs(ctx, scheduleIDs)
 	if err != nil {
 		s.logger.Error().Msg(err.Error())
@@ -88,7 +93,7 @@ func (s usersTrainersServicesService) GetSchedulesByIDs(ctx context.Context, sch
 
 	s.logger.Info().Msg(log.Normalizer(log.GetObjects, log.Service))
 
-	return s.converter.SchedulesServiceUserDomainToDTO(schedules), nil
+	return s.converter.SchedulesServiceUserDomainToDTO(schedules, loc), nil
 }
 
 func (s usersTrainersServicesService) DeleteScheduled(ctx context.Context, scheduleID int) error {
@@ -1,11 +1,10 @@
 package validators
 
 import (
+	"BACKEND/internal/errs"
 	"errors"
-	"fmt"
 	"github.com/go-playground/validator/v10"
 	"reflect"
-	"strings"
 )
 
 func getJSONTag(obj interface{}, fieldName string) string {
@@ -25,51 +24,33 @@ func getJSONTag(obj interface{}, fieldName string) string {
 	return ""
 }
 
-func CustomErrorMessage(err error, obj interface{}) string {
-	var sb strings.Builder
-
+// ValidationError превращает ошибки валидатора в errs.ValidationError с перечнем полей под именами из json тегов
+func ValidationError(err error, obj interface{}) error {
 	// Проверяем, является ли ошибка ошибкой валидации
 	var validationErrors validator.ValidationErrors
-	if errors.As(err, &validationErrors) {
-		for _, e := range validationErrors {
-			field, _ := reflect.TypeOf(obj).Elem().FieldByName(e.StructField())
-			jsonTag := getJSONTag(obj, e.StructField())
-			if jsonTag == "" {
-				jsonTag = e.Field()
-			}
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
 
-			var message string
-			switch e.Tag() {
-			case "required":
-				message = fmt.Sprintf("Поле `%s` является обязательным.", jsonTag)
-			case "min":
-				if field.Type.Kind() == reflect.String || (field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.String) {
-					message = fmt.Sprintf("Поле `%s` должно быть не менее %s символов.", jsonTag, e.Param())
-				} else {
-					message = fmt.Sprintf("Поле `%s` должно быть не менее %s.", jsonTag, e.Param())
-				}
-			case "max":
-				if field.Type.Kind() == reflect.String || (field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.String) {
-					message = fmt.Sprintf("Поле `%s` должно быть не более %s символов.", jsonTag, e.Param())
-				} else {
-					message = fmt.Sprintf("Поле `%s` должно быть не более %s.", jsonTag, e.Param())
-				}
-			case "email":
-				message = fmt.Sprintf("Поле `%s` должно быть корректным адресом электронной почты.", jsonTag)
-			case "url":
-				message = fmt.Sprintf("Поле `%s` должно быть корректным URL.", jsonTag)
-			case "password":
-				message = fmt.Sprintf("Поле `%s` должно содержать от 8 до 64 символов и включать заглавные и строчные буквы, цифры и специальные символы.", jsonTag)
-			default:
-				message = fmt.Sprintf("Поле `%s` является некорректным.", jsonTag)
-			}
+	fields := make([]errs.FieldError, 0, len(validationErrors))
+	for _, e := range validationErrors {
+		jsonTag := getJSONTag(obj, e.StructField())
+		if jsonTag == "" {
+			jsonTag = e.Field()
+		}
 
-			sb.WriteString(message)
-			break // Возвращаем только первую ошибку
+		kind := e.Kind()
+		if kind == reflect.Ptr {
+			kind = e.Type().Elem().Kind()
 		}
-	} else {
-		sb.WriteString(err.Error())
+
+		fields = append(fields, errs.FieldError{
+			Field:    jsonTag,
+			Tag:      e.Tag(),
+			Param:    e.Param(),
+			IsString: kind == reflect.String,
+		})
 	}
 
-	return sb.String()
+	return &errs.ValidationError{Fields: fields}
 }
@@ -1,6 +1,7 @@
 package validators
 
 import (
+	"BACKEND/internal/models/domain"
 	"github.com/go-playground/validator/v10"
 	"mime/multipart"
 	"path/filepath"
@@ -57,3 +58,62 @@ func ValidateFileTypeExtension(file *multipart.FileHeader) bool {
 
 	return true
 }
+
+func ValidateDocumentTypeExtension(file *multipart.FileHeader) bool {
+	// Проверка на допустимый тип `Content-Type`
+	allowedTypes := map[string]bool{
+		"application/pdf": true,
+		"image/jpeg":      true,
+		"image/png":       true,
+	}
+	if !allowedTypes[file.Header.Get("Content-Type")] {
+		return false
+	}
+
+	extension := strings.ToLower(filepath.Ext(file.Filename))
+	// Проверка на допустимое расширение файла
+	allowedExtensions := map[string]bool{
+		".pdf":  true,
+		".jpeg": true,
+		".jpg":  true,
+		".png":  true,
+	}
+	if !allowedExtensions[extension] {
+		return false
+	}
+
+	return true
+}
+
+// ValidateAttachmentTypeExtension проверяет вложение чата и возвращает его вид по `Content-Type`
+func ValidateAttachmentTypeExtension(file *multipart.FileHeader) (string, bool) {
+	// Допустимые типы `Content-Type` и расширения для каждого вида вложений
+	allowed := map[string]struct {
+		kind       string
+		extensions []string
+	}{
+		"image/jpeg":      {domain.AttachmentImage, []string{".jpeg", ".jpg"}},
+		"image/png":       {domain.AttachmentImage, []string{".png"}},
+		"image/gif":       {domain.AttachmentImage, []string{".gif"}},
+		"audio/ogg":       {domain.AttachmentVoice, []string{".ogg", ".oga", ".opus"}},
+		"audio/mpeg":      {domain.AttachmentVoice, []string{".mp3"}},
+		"audio/mp4":       {domain.AttachmentVoice, []string{".m4a"}},
+		"audio/webm":      {domain.AttachmentVoice, []string{".webm"}},
+		"application/pdf": {domain.AttachmentFile, []string{".pdf"}},
+		"text/plain":      {domain.AttachmentFile, []string{".txt"}},
+	}
+
+	fileType, ok := allowed[file.Header.Get("Content-Type")]
+	if !ok {
+		return "", false
+	}
+
+	extension := strings.ToLower(filepath.Ext(file.Filename))
+	for _, allowedExtension := range fileType.extensions {
+		if extension == allowedExtension {
+			return fileType.kind, true
+		}
+	}
+
+	return "", false
+}
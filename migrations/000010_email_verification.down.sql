@@ -0,0 +1,5 @@
+ALTER TABLE trainers
+    DROP COLUMN is_verified;
+
+ALTER TABLE users
+    DROP COLUMN is_verified;
@@ -0,0 +1,12 @@
+ALTER TABLE users
+    ADD COLUMN is_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE trainers
+    ADD COLUMN is_verified BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Уже существующие аккаунты считаем подтвержденными
+UPDATE users
+SET is_verified = TRUE;
+
+UPDATE trainers
+SET is_verified = TRUE;
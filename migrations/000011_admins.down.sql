@@ -0,0 +1,4 @@
+ALTER TABLE achievements
+    DROP COLUMN confirmed_by;
+
+DROP TABLE IF EXISTS admins;
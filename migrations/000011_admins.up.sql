@@ -0,0 +1,13 @@
+CREATE TABLE admins
+(
+    id         SERIAL PRIMARY KEY,
+    email      VARCHAR UNIQUE NOT NULL,
+    password   VARCHAR        NOT NULL,
+    roles      VARCHAR[]      NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP      NOT NULL DEFAULT NOW(),
+    CONSTRAINT admins_roles_check CHECK (roles <@ ARRAY ['content_moderator', 'trainer_onboarding', 'support']::VARCHAR[])
+);
+
+ALTER TABLE achievements
+    ADD COLUMN confirmed_by INTEGER NULL,
+    ADD CONSTRAINT fk_confirmed_by FOREIGN KEY (confirmed_by) REFERENCES admins (id) ON DELETE SET NULL;
@@ -0,0 +1,24 @@
+CREATE TABLE two_factor
+(
+    id             SERIAL PRIMARY KEY,
+    trainer_id     INTEGER UNIQUE NULL,
+    admin_id       INTEGER UNIQUE NULL,
+    secret         VARCHAR NOT NULL,
+    is_enabled     BOOLEAN NOT NULL DEFAULT FALSE,
+    -- Последний использованный шаг TOTP, чтобы один код нельзя было использовать дважды
+    last_used_step BIGINT  NOT NULL DEFAULT 0,
+    CONSTRAINT fk_trainer FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE CASCADE,
+    CONSTRAINT fk_admin FOREIGN KEY (admin_id) REFERENCES admins (id) ON DELETE CASCADE,
+    CONSTRAINT two_factor_owner_check CHECK ((trainer_id IS NULL) <> (admin_id IS NULL))
+);
+
+CREATE TABLE two_factor_recovery_codes
+(
+    id            SERIAL PRIMARY KEY,
+    two_factor_id INTEGER   NOT NULL,
+    code_hash     VARCHAR   NOT NULL,
+    used_at       TIMESTAMP NULL,
+    CONSTRAINT fk_two_factor FOREIGN KEY (two_factor_id) REFERENCES two_factor (id) ON DELETE CASCADE
+);
+
+CREATE INDEX two_factor_recovery_codes_hash_idx ON two_factor_recovery_codes (two_factor_id, code_hash);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS trainer_applications_certificates;
+DROP TABLE IF EXISTS trainer_applications_specializations;
+DROP TABLE IF EXISTS trainer_applications_roles;
+DROP TABLE IF EXISTS trainer_applications;
@@ -0,0 +1,50 @@
+CREATE TABLE trainer_applications
+(
+    id          SERIAL PRIMARY KEY,
+    email       VARCHAR   NOT NULL,
+    first_name  VARCHAR   NOT NULL,
+    last_name   VARCHAR   NOT NULL,
+    age         INTEGER   NOT NULL,
+    sex         INTEGER   NOT NULL,
+    experience  INTEGER   NOT NULL,
+    quote       VARCHAR   NULL,
+    status      VARCHAR   NOT NULL DEFAULT 'pending',
+    reason      VARCHAR   NULL,
+    reviewed_by INTEGER   NULL,
+    trainer_id  INTEGER   NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMP NULL,
+    CONSTRAINT trainer_applications_status_check CHECK (status IN ('pending', 'approved', 'rejected')),
+    CONSTRAINT fk_admin FOREIGN KEY (reviewed_by) REFERENCES admins (id) ON DELETE SET NULL,
+    CONSTRAINT fk_trainer FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE SET NULL
+);
+
+-- На одну почту может быть только одна заявка на рассмотрении
+CREATE UNIQUE INDEX trainer_applications_pending_email_idx ON trainer_applications (email) WHERE status = 'pending';
+
+CREATE TABLE trainer_applications_roles
+(
+    application_id INTEGER NOT NULL,
+    role_id        INTEGER NOT NULL,
+    PRIMARY KEY (application_id, role_id),
+    CONSTRAINT fk_application FOREIGN KEY (application_id) REFERENCES trainer_applications (id) ON DELETE CASCADE,
+    CONSTRAINT fk_role FOREIGN KEY (role_id) REFERENCES roles (id) ON DELETE CASCADE
+);
+
+CREATE TABLE trainer_applications_specializations
+(
+    application_id    INTEGER NOT NULL,
+    specialization_id INTEGER NOT NULL,
+    PRIMARY KEY (application_id, specialization_id),
+    CONSTRAINT fk_application FOREIGN KEY (application_id) REFERENCES trainer_applications (id) ON DELETE CASCADE,
+    CONSTRAINT fk_specialization FOREIGN KEY (specialization_id) REFERENCES specializations (id) ON DELETE CASCADE
+);
+
+CREATE TABLE trainer_applications_certificates
+(
+    id             SERIAL PRIMARY KEY,
+    application_id INTEGER NOT NULL,
+    name           VARCHAR NOT NULL,
+    url            VARCHAR NOT NULL,
+    CONSTRAINT fk_application FOREIGN KEY (application_id) REFERENCES trainer_applications (id) ON DELETE CASCADE
+);
@@ -0,0 +1,21 @@
+DROP TABLE IF EXISTS data_exports;
+
+ALTER TABLE trainers
+    DROP COLUMN IF EXISTS delete_at;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS delete_at;
+
+DELETE FROM messages
+WHERE user_id IS NULL
+   OR trainer_id IS NULL;
+
+ALTER TABLE messages
+    DROP CONSTRAINT messages_user_id_fkey,
+    DROP CONSTRAINT messages_trainer_id_fkey,
+    DROP CONSTRAINT messages_service_id_fkey,
+    ALTER COLUMN user_id SET NOT NULL,
+    ALTER COLUMN trainer_id SET NOT NULL,
+    ADD CONSTRAINT messages_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id),
+    ADD CONSTRAINT messages_trainer_id_fkey FOREIGN KEY (trainer_id) REFERENCES trainers (id),
+    ADD CONSTRAINT messages_service_id_fkey FOREIGN KEY (service_id) REFERENCES services (id);
@@ -0,0 +1,39 @@
+-- Переписка остается у собеседника удаленного аккаунта, поэтому ссылки на аккаунты и услуги обнуляются
+ALTER TABLE messages
+    ALTER COLUMN user_id DROP NOT NULL,
+    ALTER COLUMN trainer_id DROP NOT NULL,
+    DROP CONSTRAINT messages_user_id_fkey,
+    DROP CONSTRAINT messages_trainer_id_fkey,
+    DROP CONSTRAINT messages_service_id_fkey,
+    ADD CONSTRAINT messages_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL,
+    ADD CONSTRAINT messages_trainer_id_fkey FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE SET NULL,
+    ADD CONSTRAINT messages_service_id_fkey FOREIGN KEY (service_id) REFERENCES services (id) ON DELETE SET NULL;
+
+-- Время, после которого аккаунт будет удален. NULL - удаление не запрошено
+ALTER TABLE users
+    ADD COLUMN delete_at TIMESTAMP NULL;
+
+ALTER TABLE trainers
+    ADD COLUMN delete_at TIMESTAMP NULL;
+
+CREATE INDEX users_delete_at_idx ON users (delete_at) WHERE delete_at IS NOT NULL;
+CREATE INDEX trainers_delete_at_idx ON trainers (delete_at) WHERE delete_at IS NOT NULL;
+
+CREATE TABLE data_exports
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER NULL,
+    trainer_id INTEGER NULL,
+    status     VARCHAR   NOT NULL DEFAULT 'pending',
+    file_path  VARCHAR   NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    ready_at   TIMESTAMP NULL,
+    CONSTRAINT fk_user FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT fk_trainer FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE CASCADE,
+    CONSTRAINT data_exports_owner_check CHECK ((user_id IS NULL) <> (trainer_id IS NULL)),
+    CONSTRAINT data_exports_status_check CHECK (status IN ('pending', 'ready', 'failed'))
+);
+
+-- Одновременно готовится только одна выгрузка аккаунта
+CREATE UNIQUE INDEX data_exports_pending_user_idx ON data_exports (user_id) WHERE status = 'pending';
+CREATE UNIQUE INDEX data_exports_pending_trainer_idx ON data_exports (trainer_id) WHERE status = 'pending';
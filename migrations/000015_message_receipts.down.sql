@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS messages_unread_idx;
+DROP INDEX IF EXISTS messages_client_id_idx;
+
+ALTER TABLE messages
+    DROP COLUMN read_at,
+    DROP COLUMN delivered_at,
+    DROP COLUMN client_id;
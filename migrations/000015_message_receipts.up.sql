@@ -0,0 +1,11 @@
+-- id сообщения на клиенте, чтобы повторная отправка после обрыва связи не создавала дубликат
+ALTER TABLE messages
+    ADD COLUMN client_id    VARCHAR   NULL,
+    ADD COLUMN delivered_at TIMESTAMP NULL,
+    ADD COLUMN read_at      TIMESTAMP NULL;
+
+-- До появления отметок все сообщения считаются прочитанными
+UPDATE messages SET delivered_at = time, read_at = time;
+
+CREATE UNIQUE INDEX messages_client_id_idx ON messages (user_id, trainer_id, is_to_user, client_id) WHERE client_id IS NOT NULL;
+CREATE INDEX messages_unread_idx ON messages (user_id, trainer_id, is_to_user) WHERE read_at IS NULL;
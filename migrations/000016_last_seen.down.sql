@@ -0,0 +1,5 @@
+ALTER TABLE trainers
+    DROP COLUMN last_seen_at;
+
+ALTER TABLE users
+    DROP COLUMN last_seen_at;
@@ -0,0 +1,6 @@
+-- Время последней активности в чате, обновляется при подключении, событиях и отключении
+ALTER TABLE users
+    ADD COLUMN last_seen_at TIMESTAMP NULL;
+
+ALTER TABLE trainers
+    ADD COLUMN last_seen_at TIMESTAMP NULL;
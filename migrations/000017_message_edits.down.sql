@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS message_edits;
+
+ALTER TABLE messages
+    DROP CONSTRAINT messages_reply_to_id_fkey,
+    DROP COLUMN deleted_at,
+    DROP COLUMN edited_at,
+    DROP COLUMN reply_to_id;
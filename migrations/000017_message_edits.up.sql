@@ -0,0 +1,18 @@
+ALTER TABLE messages
+    ADD COLUMN reply_to_id INTEGER   NULL,
+    ADD COLUMN edited_at   TIMESTAMP NULL,
+    -- Удаленное у всех сообщение остается в БД для жалоб, но его текст больше не отдается
+    ADD COLUMN deleted_at  TIMESTAMP NULL,
+    ADD CONSTRAINT messages_reply_to_id_fkey FOREIGN KEY (reply_to_id) REFERENCES messages (id) ON DELETE SET NULL;
+
+-- Предыдущие версии текста сообщения
+CREATE TABLE message_edits
+(
+    id         SERIAL PRIMARY KEY,
+    message_id INTEGER   NOT NULL,
+    message    VARCHAR   NULL,
+    edited_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE
+);
+
+CREATE INDEX message_edits_message_id_idx ON message_edits (message_id);
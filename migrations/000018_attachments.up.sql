@@ -0,0 +1,23 @@
+-- Вложения загружаются до отправки сообщения, до этого message_id пустой
+CREATE TABLE attachments
+(
+    id            SERIAL PRIMARY KEY,
+    message_id    INTEGER   NULL,
+    user_id       INTEGER   NULL,
+    trainer_id    INTEGER   NULL,
+    kind          VARCHAR   NOT NULL,
+    file_url      VARCHAR   NOT NULL,
+    thumbnail_url VARCHAR   NULL,
+    name          VARCHAR   NOT NULL,
+    content_type  VARCHAR   NOT NULL,
+    size          BIGINT    NOT NULL,
+    created_at    TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL,
+    FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE SET NULL,
+    -- Владелец вложения - тот, кто его загрузил
+    CHECK (user_id IS NULL OR trainer_id IS NULL)
+);
+
+CREATE INDEX attachments_message_id_idx ON attachments (message_id);
+CREATE INDEX attachments_unattached_idx ON attachments (created_at) WHERE message_id IS NULL;
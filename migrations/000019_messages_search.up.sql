@@ -0,0 +1,8 @@
+-- Поиск по истории чата: русская и английская морфология, вес русской конфигурации выше
+ALTER TABLE messages
+    ADD COLUMN search TSVECTOR GENERATED ALWAYS AS (
+        setweight(to_tsvector('russian', COALESCE(message, '')), 'A') ||
+        setweight(to_tsvector('english', COALESCE(message, '')), 'B')
+        ) STORED;
+
+CREATE INDEX messages_search_idx ON messages USING GIN (search);
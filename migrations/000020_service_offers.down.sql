@@ -0,0 +1,7 @@
+ALTER TABLE messages
+    DROP CONSTRAINT messages_offer_status_check,
+    DROP CONSTRAINT messages_contract_id_fkey,
+    DROP COLUMN is_system,
+    DROP COLUMN contract_id,
+    DROP COLUMN offer_expires_at,
+    DROP COLUMN offer_status;
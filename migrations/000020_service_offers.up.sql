@@ -0,0 +1,9 @@
+-- Сообщение тренера с услугой - предложение, которое пользователь принимает или отклоняет до offer_expires_at
+ALTER TABLE messages
+    ADD COLUMN offer_status     VARCHAR   NULL,
+    ADD COLUMN offer_expires_at TIMESTAMP NULL,
+    -- Договор, заключенный по предложению, и системное сообщение о нем
+    ADD COLUMN contract_id      INTEGER   NULL,
+    ADD COLUMN is_system        BOOLEAN   NOT NULL DEFAULT FALSE,
+    ADD CONSTRAINT messages_contract_id_fkey FOREIGN KEY (contract_id) REFERENCES users_trainers_services (id) ON DELETE SET NULL,
+    ADD CONSTRAINT messages_offer_status_check CHECK (offer_status IN ('pending', 'accepted', 'declined'));
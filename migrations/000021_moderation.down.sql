@@ -0,0 +1,13 @@
+ALTER TABLE trainers
+    DROP COLUMN suspension_reason,
+    DROP COLUMN suspended_until,
+    DROP COLUMN suspended_at;
+
+ALTER TABLE users
+    DROP COLUMN suspension_reason,
+    DROP COLUMN suspended_until,
+    DROP COLUMN suspended_at;
+
+DROP TABLE IF EXISTS message_reports;
+
+DROP TABLE IF EXISTS blocks;
@@ -0,0 +1,45 @@
+-- Блокировка собеседника. by_user - пользователь заблокировал тренера, иначе тренер пользователя
+CREATE TABLE blocks
+(
+    user_id    INTEGER   NOT NULL,
+    trainer_id INTEGER   NOT NULL,
+    by_user    BOOLEAN   NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, trainer_id, by_user),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE CASCADE
+);
+
+-- Жалобы на сообщения чата, очередь модерации. Автор жалобы - user_id или trainer_id
+CREATE TABLE message_reports
+(
+    id          SERIAL PRIMARY KEY,
+    message_id  INTEGER   NOT NULL,
+    user_id     INTEGER   NULL,
+    trainer_id  INTEGER   NULL,
+    reason      VARCHAR   NOT NULL,
+    status      VARCHAR   NOT NULL DEFAULT 'pending',
+    reviewed_by INTEGER   NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    reviewed_at TIMESTAMP NULL,
+    CONSTRAINT message_reports_status_check CHECK (status IN ('pending', 'resolved', 'dismissed')),
+    CONSTRAINT message_reports_reporter_check CHECK (user_id IS NULL OR trainer_id IS NULL),
+    FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE SET NULL,
+    FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE SET NULL,
+    FOREIGN KEY (reviewed_by) REFERENCES admins (id) ON DELETE SET NULL
+);
+
+-- Пока жалоба на рассмотрении, повторная на то же сообщение от того же автора не создается
+CREATE UNIQUE INDEX message_reports_pending_idx ON message_reports (message_id, user_id, trainer_id) NULLS NOT DISTINCT WHERE status = 'pending';
+
+-- Блокировка аккаунта администратором: до suspended_until или бессрочно, если он не задан
+ALTER TABLE users
+    ADD COLUMN suspended_at      TIMESTAMP NULL,
+    ADD COLUMN suspended_until   TIMESTAMP NULL,
+    ADD COLUMN suspension_reason VARCHAR   NULL;
+
+ALTER TABLE trainers
+    ADD COLUMN suspended_at      TIMESTAMP NULL,
+    ADD COLUMN suspended_until   TIMESTAMP NULL,
+    ADD COLUMN suspension_reason VARCHAR   NULL;
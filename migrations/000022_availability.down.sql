@@ -0,0 +1,19 @@
+ALTER TABLE users_trainers_services_schedule
+    DROP CONSTRAINT IF EXISTS users_trainers_services_schedule_overlap,
+    DROP CONSTRAINT IF EXISTS users_trainers_services_schedule_time_check,
+    DROP COLUMN IF EXISTS period;
+
+-- Перенесенные при миграции занятия возвращаются в расписание
+INSERT INTO users_trainers_services_schedule (id, users_trainers_services_id, trainer_id, date, time_start, time_end)
+SELECT id, users_trainers_services_id, trainer_id, date, time_start, time_end
+FROM users_trainers_services_schedule_conflicts;
+
+DROP TABLE IF EXISTS users_trainers_services_schedule_conflicts;
+
+ALTER TABLE users_trainers_services_schedule
+    DROP COLUMN IF EXISTS trainer_id;
+
+DROP TABLE IF EXISTS trainer_availability_exceptions;
+DROP TABLE IF EXISTS trainer_availability;
+
+DROP EXTENSION IF EXISTS btree_gist;
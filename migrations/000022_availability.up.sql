@@ -0,0 +1,93 @@
+CREATE EXTENSION IF NOT EXISTS btree_gist;
+
+-- Недельный шаблон рабочего времени тренера. weekday - день недели ISO: 1 - понедельник, 7 - воскресенье
+CREATE TABLE trainer_availability
+(
+    id         SERIAL PRIMARY KEY,
+    trainer_id INTEGER  NOT NULL,
+    weekday    SMALLINT NOT NULL,
+    time_start TIME     NOT NULL,
+    time_end   TIME     NOT NULL,
+    CONSTRAINT trainer_availability_weekday_check CHECK (weekday BETWEEN 1 AND 7),
+    CONSTRAINT trainer_availability_time_check CHECK (time_start < time_end),
+    FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE CASCADE
+);
+
+CREATE INDEX trainer_availability_trainer_idx ON trainer_availability (trainer_id, weekday);
+
+-- Исключения из шаблона: отпуск, выходные. Дни с date_start по date_end включительно недоступны для записи
+CREATE TABLE trainer_availability_exceptions
+(
+    id         SERIAL PRIMARY KEY,
+    trainer_id INTEGER   NOT NULL,
+    date_start DATE      NOT NULL,
+    date_end   DATE      NOT NULL,
+    reason     VARCHAR   NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT trainer_availability_exceptions_date_check CHECK (date_start <= date_end),
+    FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE CASCADE
+);
+
+CREATE INDEX trainer_availability_exceptions_trainer_idx ON trainer_availability_exceptions (trainer_id, date_end);
+
+-- Тренер копируется в расписание, чтобы ограничение исключения не давало пересечься его занятиям
+ALTER TABLE users_trainers_services_schedule
+    ADD COLUMN trainer_id INTEGER NULL;
+
+UPDATE users_trainers_services_schedule tuts
+SET trainer_id = uts.trainer_id
+FROM users_trainers_services uts
+WHERE tuts.users_trainers_services_id = uts.id;
+
+-- Раньше занятия записывались без проверок, поэтому в расписании могут быть занятия с началом не раньше конца
+-- и пересекающиеся занятия одного тренера. С ними ограничения ниже не создадутся, поэтому такие занятия
+-- переносятся в users_trainers_services_schedule_conflicts, откуда их можно разобрать и записать заново.
+-- Из пересекающихся остается занятие с меньшим id, переносятся все, что пересекаются с более ранним по id
+CREATE TABLE users_trainers_services_schedule_conflicts
+(
+    id                         INTEGER PRIMARY KEY,
+    users_trainers_services_id INTEGER   NOT NULL,
+    trainer_id                 INTEGER   NOT NULL,
+    date                       DATE      NOT NULL,
+    time_start                 TIME      NOT NULL,
+    time_end                   TIME      NOT NULL,
+    -- invalid_time - начало не раньше конца, overlap - пересекается с другим занятием тренера
+    reason                     VARCHAR   NOT NULL,
+    moved_at                   TIMESTAMP NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (users_trainers_services_id) REFERENCES users_trainers_services (id) ON DELETE CASCADE
+);
+
+WITH invalid AS (
+    DELETE FROM users_trainers_services_schedule
+    WHERE time_start >= time_end
+    RETURNING id, users_trainers_services_id, trainer_id, date, time_start, time_end
+)
+INSERT INTO users_trainers_services_schedule_conflicts (id, users_trainers_services_id, trainer_id, date, time_start, time_end, reason)
+SELECT id, users_trainers_services_id, trainer_id, date, time_start, time_end, 'invalid_time'
+FROM invalid;
+
+WITH overlapping AS (
+    DELETE FROM users_trainers_services_schedule s
+    WHERE EXISTS (SELECT 1
+                  FROM users_trainers_services_schedule o
+                  WHERE o.trainer_id = s.trainer_id
+                    AND o.id < s.id
+                    AND o.date = s.date
+                    AND o.time_start < s.time_end
+                    AND s.time_start < o.time_end)
+    RETURNING s.id, s.users_trainers_services_id, s.trainer_id, s.date, s.time_start, s.time_end
+)
+INSERT INTO users_trainers_services_schedule_conflicts (id, users_trainers_services_id, trainer_id, date, time_start, time_end, reason)
+SELECT id, users_trainers_services_id, trainer_id, date, time_start, time_end, 'overlap'
+FROM overlapping;
+
+ALTER TABLE users_trainers_services_schedule
+    ALTER COLUMN trainer_id SET NOT NULL,
+    ADD FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE CASCADE,
+    ADD CONSTRAINT users_trainers_services_schedule_time_check CHECK (time_start < time_end);
+
+ALTER TABLE users_trainers_services_schedule
+    ADD COLUMN period TSRANGE GENERATED ALWAYS AS (TSRANGE(date + time_start, date + time_end)) STORED;
+
+ALTER TABLE users_trainers_services_schedule
+    ADD CONSTRAINT users_trainers_services_schedule_overlap EXCLUDE USING gist (trainer_id WITH =, period WITH &&);
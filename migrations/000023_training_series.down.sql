@@ -0,0 +1,8 @@
+ALTER TABLE users_trainings
+    DROP CONSTRAINT IF EXISTS users_trainings_series_occurrence_key,
+    DROP COLUMN IF EXISTS occurrence_date,
+    DROP COLUMN IF EXISTS series_id;
+
+DROP TABLE IF EXISTS users_trainings_series_exdates;
+DROP TABLE IF EXISTS users_trainings_series_exercises;
+DROP TABLE IF EXISTS users_trainings_series;
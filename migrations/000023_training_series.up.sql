@@ -0,0 +1,49 @@
+-- Повторяющиеся тренировки пользователя. rrule - правило повторения RFC 5545 в каноническом виде,
+-- date_end - день последнего вхождения для правил с COUNT или UNTIL, NULL - серия бесконечна
+CREATE TABLE users_trainings_series
+(
+    id          SERIAL PRIMARY KEY,
+    user_id     INTEGER   NOT NULL,
+    training_id INTEGER   NOT NULL,
+    date_start  DATE      NOT NULL,
+    date_end    DATE      NULL,
+    time_start  TIME      NOT NULL,
+    time_end    TIME      NOT NULL,
+    rrule       VARCHAR   NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT users_trainings_series_time_check CHECK (time_start < time_end),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (training_id) REFERENCES trainings (id) ON DELETE CASCADE
+);
+
+CREATE INDEX users_trainings_series_user_idx ON users_trainings_series (user_id, date_start);
+
+-- Упражнения, которые копируются в каждое вхождение серии при его сохранении
+CREATE TABLE users_trainings_series_exercises
+(
+    id          SERIAL PRIMARY KEY,
+    series_id   INTEGER NOT NULL,
+    exercise_id INTEGER NOT NULL,
+    sets        INTEGER NOT NULL,
+    reps        INTEGER NOT NULL,
+    weight      INTEGER NOT NULL,
+    FOREIGN KEY (series_id) REFERENCES users_trainings_series (id) ON DELETE CASCADE,
+    FOREIGN KEY (exercise_id) REFERENCES exercises (id) ON DELETE CASCADE
+);
+
+-- Отмененные вхождения серии
+CREATE TABLE users_trainings_series_exdates
+(
+    series_id INTEGER NOT NULL,
+    date      DATE    NOT NULL,
+    PRIMARY KEY (series_id, date),
+    FOREIGN KEY (series_id) REFERENCES users_trainings_series (id) ON DELETE CASCADE
+);
+
+-- Измененное вхождение хранится обычной записью users_trainings со ссылкой на серию и исходный день.
+-- При удалении серии такие записи остаются отдельными тренировками вместе с отмеченным прогрессом
+ALTER TABLE users_trainings
+    ADD COLUMN series_id       INTEGER NULL,
+    ADD COLUMN occurrence_date DATE    NULL,
+    ADD FOREIGN KEY (series_id) REFERENCES users_trainings_series (id) ON DELETE SET NULL,
+    ADD CONSTRAINT users_trainings_series_occurrence_key UNIQUE (series_id, occurrence_date);
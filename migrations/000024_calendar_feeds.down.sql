@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS trainer_busy;
+DROP TABLE IF EXISTS calendar_feeds;
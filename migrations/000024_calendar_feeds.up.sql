@@ -0,0 +1,26 @@
+-- Секретные ссылки на подписку на календарь, одна на аккаунт. Хранится sha256 токена, сам токен показывается только при создании
+CREATE TABLE calendar_feeds
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INTEGER   NULL UNIQUE,
+    trainer_id INTEGER   NULL UNIQUE,
+    token_hash VARCHAR   NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CONSTRAINT calendar_feeds_owner_check CHECK ((user_id IS NULL) <> (trainer_id IS NULL)),
+    FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE CASCADE
+);
+
+-- Занятое время тренера из импортированного календаря. Время местное, как и у занятий в расписании.
+-- Новый импорт заменяет предыдущий
+CREATE TABLE trainer_busy
+(
+    id         SERIAL PRIMARY KEY,
+    trainer_id INTEGER   NOT NULL,
+    time_start TIMESTAMP NOT NULL,
+    time_end   TIMESTAMP NOT NULL,
+    CONSTRAINT trainer_busy_time_check CHECK (time_start < time_end),
+    FOREIGN KEY (trainer_id) REFERENCES trainers (id) ON DELETE CASCADE
+);
+
+CREATE INDEX trainer_busy_trainer_idx ON trainer_busy (trainer_id, time_end);
@@ -0,0 +1,98 @@
+ALTER TABLE users_trainings_series
+    DROP COLUMN IF EXISTS timezone;
+
+-- Моменты времени возвращаются к местному времени без зоны в часовом поясе владельца
+ALTER TABLE trainer_busy
+    RENAME COLUMN time_start TO zoned_time_start;
+ALTER TABLE trainer_busy
+    RENAME COLUMN time_end TO zoned_time_end;
+
+ALTER TABLE trainer_busy
+    DROP CONSTRAINT trainer_busy_time_check,
+    ADD COLUMN time_start TIMESTAMP NULL,
+    ADD COLUMN time_end   TIMESTAMP NULL;
+
+UPDATE trainer_busy b
+SET time_start = b.zoned_time_start AT TIME ZONE t.timezone,
+    time_end   = b.zoned_time_end AT TIME ZONE t.timezone
+FROM trainers t
+WHERE t.id = b.trainer_id;
+
+DROP INDEX trainer_busy_trainer_idx;
+
+ALTER TABLE trainer_busy
+    ALTER COLUMN time_start SET NOT NULL,
+    ALTER COLUMN time_end SET NOT NULL,
+    DROP COLUMN zoned_time_start,
+    DROP COLUMN zoned_time_end,
+    ADD CONSTRAINT trainer_busy_time_check CHECK (time_start < time_end);
+
+CREATE INDEX trainer_busy_trainer_idx ON trainer_busy (trainer_id, time_end);
+
+ALTER TABLE users_trainers_services_schedule
+    DROP CONSTRAINT users_trainers_services_schedule_overlap,
+    DROP CONSTRAINT users_trainers_services_schedule_time_check,
+    DROP COLUMN period;
+
+ALTER TABLE users_trainers_services_schedule
+    RENAME COLUMN time_start TO zoned_time_start;
+ALTER TABLE users_trainers_services_schedule
+    RENAME COLUMN time_end TO zoned_time_end;
+
+ALTER TABLE users_trainers_services_schedule
+    ADD COLUMN date       DATE NULL,
+    ADD COLUMN time_start TIME NULL,
+    ADD COLUMN time_end   TIME NULL;
+
+UPDATE users_trainers_services_schedule tuts
+SET date       = (tuts.zoned_time_start AT TIME ZONE t.timezone)::date,
+    time_start = (tuts.zoned_time_start AT TIME ZONE t.timezone)::time,
+    time_end   = (tuts.zoned_time_end AT TIME ZONE t.timezone)::time
+FROM trainers t
+WHERE t.id = tuts.trainer_id;
+
+ALTER TABLE users_trainers_services_schedule
+    ALTER COLUMN date SET NOT NULL,
+    ALTER COLUMN time_start SET NOT NULL,
+    ALTER COLUMN time_end SET NOT NULL,
+    DROP COLUMN zoned_time_start,
+    DROP COLUMN zoned_time_end,
+    ADD CONSTRAINT users_trainers_services_schedule_time_check CHECK (time_start < time_end);
+
+ALTER TABLE users_trainers_services_schedule
+    ADD COLUMN period TSRANGE GENERATED ALWAYS AS (TSRANGE(date + time_start, date + time_end)) STORED;
+
+ALTER TABLE users_trainers_services_schedule
+    ADD CONSTRAINT users_trainers_services_schedule_overlap EXCLUDE USING gist (trainer_id WITH =, period WITH &&);
+
+DROP INDEX IF EXISTS users_trainings_user_time_idx;
+
+ALTER TABLE users_trainings
+    RENAME COLUMN time_start TO zoned_time_start;
+ALTER TABLE users_trainings
+    RENAME COLUMN time_end TO zoned_time_end;
+
+ALTER TABLE users_trainings
+    ADD COLUMN date       DATE NULL,
+    ADD COLUMN time_start TIME NULL,
+    ADD COLUMN time_end   TIME NULL;
+
+UPDATE users_trainings ut
+SET date       = (ut.zoned_time_start AT TIME ZONE u.timezone)::date,
+    time_start = (ut.zoned_time_start AT TIME ZONE u.timezone)::time,
+    time_end   = (ut.zoned_time_end AT TIME ZONE u.timezone)::time
+FROM users u
+WHERE u.id = ut.user_id;
+
+ALTER TABLE users_trainings
+    ALTER COLUMN date SET NOT NULL,
+    ALTER COLUMN time_start SET NOT NULL,
+    ALTER COLUMN time_end SET NOT NULL,
+    DROP COLUMN zoned_time_start,
+    DROP COLUMN zoned_time_end;
+
+ALTER TABLE trainers
+    DROP COLUMN IF EXISTS timezone;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,108 @@
+-- Часовой пояс IANA из профиля. В нем вводится и показывается время расписания
+ALTER TABLE users
+    ADD COLUMN timezone VARCHAR NOT NULL DEFAULT 'Europe/Moscow';
+
+ALTER TABLE trainers
+    ADD COLUMN timezone VARCHAR NOT NULL DEFAULT 'Europe/Moscow';
+
+-- Тренировки пользователя хранятся моментами времени. Прежние дата и время без зоны считаются местным временем пользователя
+ALTER TABLE users_trainings
+    RENAME COLUMN time_start TO local_time_start;
+ALTER TABLE users_trainings
+    RENAME COLUMN time_end TO local_time_end;
+
+ALTER TABLE users_trainings
+    ADD COLUMN time_start TIMESTAMPTZ NULL,
+    ADD COLUMN time_end   TIMESTAMPTZ NULL;
+
+UPDATE users_trainings ut
+SET time_start = (ut.date + ut.local_time_start) AT TIME ZONE u.timezone,
+    time_end   = (ut.date + ut.local_time_end) AT TIME ZONE u.timezone
+FROM users u
+WHERE u.id = ut.user_id;
+
+ALTER TABLE users_trainings
+    ALTER COLUMN time_start SET NOT NULL,
+    ALTER COLUMN time_end SET NOT NULL,
+    DROP COLUMN date,
+    DROP COLUMN local_time_start,
+    DROP COLUMN local_time_end;
+
+CREATE INDEX users_trainings_user_time_idx ON users_trainings (user_id, time_start);
+
+-- Занятия по договорам так же переводятся в моменты времени по часовому поясу тренера.
+-- Промежуток для ограничения исключения пересобирается из новых столбцов
+ALTER TABLE users_trainers_services_schedule
+    DROP CONSTRAINT users_trainers_services_schedule_overlap,
+    DROP CONSTRAINT users_trainers_services_schedule_time_check,
+    DROP COLUMN period;
+
+ALTER TABLE users_trainers_services_schedule
+    RENAME COLUMN time_start TO local_time_start;
+ALTER TABLE users_trainers_services_schedule
+    RENAME COLUMN time_end TO local_time_end;
+
+ALTER TABLE users_trainers_services_schedule
+    ADD COLUMN time_start TIMESTAMPTZ NULL,
+    ADD COLUMN time_end   TIMESTAMPTZ NULL;
+
+UPDATE users_trainers_services_schedule tuts
+SET time_start = (tuts.date + tuts.local_time_start) AT TIME ZONE t.timezone,
+    time_end   = (tuts.date + tuts.local_time_end) AT TIME ZONE t.timezone
+FROM trainers t
+WHERE t.id = tuts.trainer_id;
+
+ALTER TABLE users_trainers_services_schedule
+    ALTER COLUMN time_start SET NOT NULL,
+    ALTER COLUMN time_end SET NOT NULL,
+    DROP COLUMN date,
+    DROP COLUMN local_time_start,
+    DROP COLUMN local_time_end,
+    ADD CONSTRAINT users_trainers_services_schedule_time_check CHECK (time_start < time_end);
+
+ALTER TABLE users_trainers_services_schedule
+    ADD COLUMN period TSTZRANGE GENERATED ALWAYS AS (TSTZRANGE(time_start, time_end)) STORED;
+
+ALTER TABLE users_trainers_services_schedule
+    ADD CONSTRAINT users_trainers_services_schedule_overlap EXCLUDE USING gist (trainer_id WITH =, period WITH &&);
+
+-- Импортированное занятое время тренера
+ALTER TABLE trainer_busy
+    RENAME COLUMN time_start TO local_time_start;
+ALTER TABLE trainer_busy
+    RENAME COLUMN time_end TO local_time_end;
+
+ALTER TABLE trainer_busy
+    DROP CONSTRAINT trainer_busy_time_check,
+    ADD COLUMN time_start TIMESTAMPTZ NULL,
+    ADD COLUMN time_end   TIMESTAMPTZ NULL;
+
+UPDATE trainer_busy b
+SET time_start = b.local_time_start AT TIME ZONE t.timezone,
+    time_end   = b.local_time_end AT TIME ZONE t.timezone
+FROM trainers t
+WHERE t.id = b.trainer_id;
+
+DROP INDEX trainer_busy_trainer_idx;
+
+ALTER TABLE trainer_busy
+    ALTER COLUMN time_start SET NOT NULL,
+    ALTER COLUMN time_end SET NOT NULL,
+    DROP COLUMN local_time_start,
+    DROP COLUMN local_time_end,
+    ADD CONSTRAINT trainer_busy_time_check CHECK (time_start < time_end);
+
+CREATE INDEX trainer_busy_trainer_idx ON trainer_busy (trainer_id, time_end);
+
+-- Серия повторяется по местному времени: в 7:00 и зимой, и летом. Поэтому она хранит дни и время без зоны
+-- вместе с часовым поясом, в котором они заданы
+ALTER TABLE users_trainings_series
+    ADD COLUMN timezone VARCHAR NULL;
+
+UPDATE users_trainings_series s
+SET timezone = u.timezone
+FROM users u
+WHERE u.id = s.user_id;
+
+ALTER TABLE users_trainings_series
+    ALTER COLUMN timezone SET NOT NULL;
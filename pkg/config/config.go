@@ -21,9 +21,54 @@ const (
 	SessionSaveTime = "SESSION_SAVE_TIME"
 
 	JWTExpirationTime = "JWT_EXPIRATION_TIME"
-	JWTSecret         = "JWT_SECRET"
+	JWTKeysDir        = "JWT_KEYS_DIR"
+	JWTSigningKeyID   = "JWT_SIGNING_KEY_ID"
+	JWTKeysReloadTime = "JWT_KEYS_RELOAD_TIME"
+	JWTIssuer         = "JWT_ISSUER"
+	JWTAudience       = "JWT_AUDIENCE"
 
-	APIKEY = "API_KEY"
+	MailDriver   = "MAIL_DRIVER"
+	MailFrom     = "MAIL_FROM"
+	MailLogPath  = "MAIL_LOG_PATH"
+	SMTPHost     = "SMTP_HOST"
+	SMTPPort     = "SMTP_PORT"
+	SMTPUser     = "SMTP_USER"
+	SMTPPassword = "SMTP_PASSWORD"
+
+	FrontendURL           = "FRONTEND_URL"
+	VerificationTokenTime = "VERIFICATION_TOKEN_TIME"
+	ResetTokenTime        = "RESET_TOKEN_TIME"
+
+	TOTPIssuer             = "TOTP_ISSUER"
+	TwoFactorChallengeTime = "TWO_FACTOR_CHALLENGE_TIME"
+
+	LoginMaxAttempts    = "LOGIN_MAX_ATTEMPTS"
+	LoginMaxIPAttempts  = "LOGIN_MAX_IP_ATTEMPTS"
+	LoginAttemptsWindow = "LOGIN_ATTEMPTS_WINDOW"
+	LoginLockoutTime    = "LOGIN_LOCKOUT_TIME"
+	LoginMaxLockoutTime = "LOGIN_MAX_LOCKOUT_TIME"
+
+	AccountDeletionTime = "ACCOUNT_DELETION_TIME"
+	AccountPurgeTime    = "ACCOUNT_PURGE_TIME"
+	DataExportTime      = "DATA_EXPORT_TIME"
+
+	ChatBroker   = "CHAT_BROKER"
+	PresenceTime = "PRESENCE_TIME"
+
+	ChatPingTime       = "CHAT_PING_TIME"
+	ChatIdleTime       = "CHAT_IDLE_TIME"
+	ChatWriteTime      = "CHAT_WRITE_TIME"
+	ChatMaxMessageSize = "CHAT_MAX_MESSAGE_SIZE"
+	ChatRateLimit      = "CHAT_RATE_LIMIT"
+	ChatRateBurst      = "CHAT_RATE_BURST"
+	ChatSendQueue      = "CHAT_SEND_QUEUE"
+	ChatAllowedOrigins = "CHAT_ALLOWED_ORIGINS"
+	ShutdownTime       = "SHUTDOWN_TIME"
+
+	MessageDeleteTime = "MESSAGE_DELETE_TIME"
+	OfferTime         = "OFFER_TIME"
+
+	APIURL = "API_URL"
 
 	EntitiesPerRequest = "ENTITIES_PER_REQUEST"
 )
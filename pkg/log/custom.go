@@ -3,28 +3,84 @@ package log
 import "fmt"
 
 const (
-	CreateObject     = "Object `%s` was successfully created with id %d"
-	CreateObjects    = "Objects `%s` were successfully created with ids %v"
-	GetObjects       = "Objects `%s` were successfully got"
-	GetObject        = "Object `%s` with id %d was successfully got"
-	UpdateObject     = "Object `%s` with id %d was successfully updated"
-	UpdateObjects    = "Objects `%s` with ids %v were successfully updated"
-	DeleteObject     = "Object `%s` with id %d was successfully deleted"
-	DeleteObjects    = "Object `%s` with ids %v were successfully deleted"
-	AuthorizeUser    = "User with email %s authorized"
-	AuthorizeTrainer = "Trainer with email %s authorized"
+	CreateObject       = "Object `%s` was successfully created with id %d"
+	CreateObjects      = "Objects `%s` were successfully created with ids %v"
+	GetObjects         = "Objects `%s` were successfully got"
+	GetObject          = "Object `%s` with id %d was successfully got"
+	UpdateObject       = "Object `%s` with id %d was successfully updated"
+	UpdateObjects      = "Objects `%s` with ids %v were successfully updated"
+	DeleteObject       = "Object `%s` with id %d was successfully deleted"
+	DeleteObjects      = "Object `%s` with ids %v were successfully deleted"
+	AuthorizeUser      = "User with email %s authorized"
+	AuthorizeTrainer   = "Trainer with email %s authorized"
+	AuthorizeAdmin     = "Admin with email %s authorized"
+	ConfirmByAdmin     = "Object `%s` with id %d was set to %t by admin %d"
+	AccessDenied       = "Access to `%s` with id %v is denied for %s %d"
+	SendMail           = "Mail `%s` was sent to %s %d"
+	VerifyEmail        = "Email of %s %d was verified"
+	ResetPassword      = "Password of %s %d was reset"
+	TwoFactorEnable    = "Two-factor authentication of %s %d was enabled"
+	TwoFactorDisable   = "Two-factor authentication of %s %d was disabled"
+	TwoFactorFailed    = "Two-factor code of %s %d was rejected"
+	RecoveryCodeUsed   = "Recovery code of %s %d was used"
+	LoginFailed        = "Failed login of %s with email %s from %s, attempt %d"
+	LoginLocked        = "Login of %s with email %s is locked for %s after %d failed attempts"
+	LoginIPLocked      = "Login from %s is locked for %s after %d failed attempts"
+	LoginRejected      = "Login of %s with email %s from %s is rejected, locked for %s"
+	ApproveApplication = "Trainer application %d was approved by admin %d, trainer %d was created"
+	RejectApplication  = "Trainer application %d was rejected by admin %d"
+	ScheduleDeletion   = "Deletion of %s %d was scheduled at %s"
+	CancelDeletion     = "Deletion of %s %d was canceled"
+	DeleteAccount      = "Account of %s %d was deleted, chat history was anonymised"
+	ExportReady        = "Data export %d of %s %d is ready"
+	ExportFailed       = "Data export %d of %s %d failed"
+	DuplicateMessage   = "Message %d with client id %s was already created"
+	DeliverMessages    = "Messages up to %d were delivered to %s %d"
+	ReadMessages       = "Messages up to %d were read by %s %d"
+	EditMessage        = "Message %d was edited by %s %d"
+	DeleteMessage      = "Message %d was deleted for everyone by %s %d"
+	AnswerOffer        = "Offer %d was %s by user %d"
+	DeleteUnattached   = "%d files of unsent attachments were deleted"
+	SuspendedLogin     = "Login of suspended %s %d is rejected"
+	BlockChat          = "%s %d blocked chat with %d"
+	UnblockChat        = "%s %d unblocked chat with %d"
+	ReviewReport       = "Message report %d was %s by admin %d"
+	SuspendAccount     = "%s %d was suspended until %s by admin %d"
+	UnsuspendAccount   = "%s %d was unsuspended by admin %d"
+	UpdateAvailability = "Availability of trainer %d was replaced with %d intervals"
+	CancelOccurrence   = "Occurrence %s of training series %d was cancelled by user %d"
+	ImportBusy         = "Busy time of trainer %d was replaced with %d imported intervals"
+	CreateFeed         = "Calendar feed of %s %d was created"
+	DeleteFeed         = "Calendar feed of %s %d was deleted"
+	GetFeed            = "Calendar feed of %s %d was got"
 )
 
 const (
-	User     = "user"
-	Trainer  = "trainer"
-	Service  = "service"
-	Exercise = "exercise"
-	Training = "training"
-	Plan     = "plan"
-	Schedule = "schedule"
-	Message  = "message"
-	Chat     = "chat"
+	User        = "user"
+	Trainer     = "trainer"
+	Service     = "service"
+	Exercise    = "exercise"
+	Training    = "training"
+	Plan        = "plan"
+	Schedule    = "schedule"
+	Message     = "message"
+	Chat        = "chat"
+	Contract    = "contract"
+	Admin       = "admin"
+	Achievement = "achievement"
+	TwoFactor   = "two_factor"
+	Application = "trainer_application"
+	DataExport  = "data_export"
+	Presence    = "presence"
+	MessageEdit = "message_edit"
+	Attachment  = "attachment"
+	Block       = "block"
+	Report      = "message_report"
+	Available   = "availability"
+	Exception   = "availability_exception"
+	Slot        = "slot"
+	Calendar    = "calendar_event"
+	Series      = "training_series"
 )
 
 func Normalizer(mainEvent string, args ...any) string {
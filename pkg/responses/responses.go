@@ -1,17 +1,20 @@
 package responses
 
-const (
-	ResponseBadQuery = "Bad query provided"
-	ResponseBadBody  = "Bad body provided"
-	ResponseBadPath  = "Bad path parameter provided"
-)
-
 type TokenResponse struct {
 	AccessToken  string `json:"access_token"`
 	RefreshToken string `json:"refresh_token"`
 }
 
-type MessageResponse struct {
+// ErrorResponse - единый формат ошибки: стабильный код, сообщение на языке из Accept-Language и невалидные поля
+type ErrorResponse struct {
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
 	Message string `json:"message"`
 }
 
@@ -27,3 +30,7 @@ type CreatedIDIDsResponse struct {
 	CreatedIDResponse
 	CreatedIDsResponse
 }
+
+type CreatedSeriesResponse struct {
+	SeriesID int `json:"series_id"`
+}
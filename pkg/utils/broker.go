@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"BACKEND/pkg/config"
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+	"sync"
+)
+
+const (
+	MemoryBroker = "memory"
+	RedisBroker  = "redis"
+
+	brokerBufferSize = 100
+)
+
+// Broker рассылает сообщения всем подписчикам канала, в том числе на других экземплярах сервера
+type Broker interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+	// Subscribe возвращает канал с сообщениями, который закрывается после отмены ctx
+	Subscribe(ctx context.Context, channel string) (<-chan []byte, error)
+}
+
+// InitBroker выбирает реализацию по CHAT_BROKER, по умолчанию сообщения рассылаются внутри процесса
+func InitBroker() Broker {
+	switch viper.GetString(config.ChatBroker) {
+	case RedisBroker:
+		return &RedisPubSub{
+			rdb: newRedisClient(),
+		}
+	default:
+		return &MemoryPubSub{
+			subscribers: map[string]map[chan []byte]struct{}{},
+		}
+	}
+}
+
+// MemoryPubSub подходит только для одного экземпляра сервера
+type MemoryPubSub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+func (m *MemoryPubSub) Publish(ctx context.Context, channel string, payload []byte) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for subscriber := range m.subscribers[channel] {
+		select {
+		case subscriber <- payload:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (m *MemoryPubSub) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	subscriber := make(chan []byte, brokerBufferSize)
+
+	m.mu.Lock()
+	if m.subscribers[channel] == nil {
+		m.subscribers[channel] = map[chan []byte]struct{}{}
+	}
+	m.subscribers[channel][subscriber] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		m.mu.Lock()
+		delete(m.subscribers[channel], subscriber)
+		if len(m.subscribers[channel]) == 0 {
+			delete(m.subscribers, channel)
+		}
+		m.mu.Unlock()
+
+		close(subscriber)
+	}()
+
+	return subscriber, nil
+}
+
+type RedisPubSub struct {
+	rdb *redis.Client
+}
+
+func (r RedisPubSub) Publish(ctx context.Context, channel string, payload []byte) error {
+	return r.rdb.Publish(ctx, channel, payload).Err()
+}
+
+func (r RedisPubSub) Subscribe(ctx context.Context, channel string) (<-chan []byte, error) {
+	pubsub := r.rdb.Subscribe(ctx, channel)
+
+	// Ожидание подтверждения подписки, чтобы не потерять сообщения, опубликованные сразу после
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("subscribe to %s: %w", channel, err)
+	}
+
+	subscriber := make(chan []byte, brokerBufferSize)
+
+	go func() {
+		defer close(subscriber)
+		defer pubsub.Close()
+
+		// Канал go-redis сам переподключается при обрыве соединения
+		messages := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+				subscriber <- []byte(message.Payload)
+			}
+		}
+	}()
+
+	return subscriber, nil
+}
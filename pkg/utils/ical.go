@@ -0,0 +1,411 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalendar по RFC 5545: выгрузка событий в UTC и разбор VEVENT при импорте
+const (
+	ICalContentType = "text/calendar; charset=utf-8"
+
+	icalProdID      = "-//LADYA//Calendar//RU"
+	icalLineLength  = 75
+	icalDateTimeUTC = "20060102T150405Z"
+	icalDateTime    = "20060102T150405"
+	icalDate        = "20060102"
+)
+
+type ICalEvent struct {
+	UID         string
+	Summary     string
+	Description string
+	Start       time.Time
+	End         time.Time
+}
+
+// WriteICal собирает календарь с именем name. Время событий записывается в UTC, поэтому VTIMEZONE не нужен
+func WriteICal(name string, events []ICalEvent, stamp time.Time) []byte {
+	var builder strings.Builder
+
+	writeICalLine(&builder, "BEGIN:VCALENDAR")
+	writeICalLine(&builder, "VERSION:2.0")
+	writeICalLine(&builder, "PRODID:"+icalProdID)
+	writeICalLine(&builder, "CALSCALE:GREGORIAN")
+	writeICalLine(&builder, "METHOD:PUBLISH")
+	writeICalLine(&builder, "X-WR-CALNAME:"+escapeICalText(name))
+
+	for _, event := range events {
+		writeICalLine(&builder, "BEGIN:VEVENT")
+		writeICalLine(&builder, "UID:"+event.UID)
+		writeICalLine(&builder, "DTSTAMP:"+stamp.UTC().Format(icalDateTimeUTC))
+		writeICalLine(&builder, "DTSTART:"+event.Start.UTC().Format(icalDateTimeUTC))
+		writeICalLine(&builder, "DTEND:"+event.End.UTC().Format(icalDateTimeUTC))
+		writeICalLine(&builder, "SUMMARY:"+escapeICalText(event.Summary))
+		if event.Description != "" {
+			writeICalLine(&builder, "DESCRIPTION:"+escapeICalText(event.Description))
+		}
+		writeICalLine(&builder, "END:VEVENT")
+	}
+
+	writeICalLine(&builder, "END:VCALENDAR")
+
+	return []byte(builder.String())
+}
+
+// writeICalLine пишет строку, перенося ее по 75 байт без разрыва символов UTF-8
+func writeICalLine(builder *strings.Builder, line string) {
+	limit := icalLineLength
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		builder.WriteString(line[:cut])
+		builder.WriteString("\r\n ")
+		line = line[cut:]
+		// Пробел в начале продолжения тоже занимает байт
+		limit = icalLineLength - 1
+	}
+	builder.WriteString(line)
+	builder.WriteString("\r\n")
+}
+
+func escapeICalText(text string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`).Replace(text)
+}
+
+func unescapeICalText(text string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(text)
+}
+
+// icalProperty строка календаря вида NAME;PARAM=VALUE:value
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// icalComponent свойства одного VEVENT
+type icalComponent map[string][]icalProperty
+
+func (c icalComponent) first(name string) (icalProperty, bool) {
+	if properties := c[name]; len(properties) > 0 {
+		return properties[0], true
+	}
+	return icalProperty{}, false
+}
+
+// ParseICal возвращает занятые промежутки событий календаря, пересекающие [from, to).
+// Время без зоны и с неизвестной TZID считается в loc. Повторяющиеся события разворачиваются,
+// если их правило входит в поддерживаемое ParseRRule подмножество, иначе берется только первое вхождение.
+// Отмененные и прозрачные (не занимающие время) события пропускаются
+func ParseICal(r io.Reader, loc *time.Location, from, to time.Time) ([]ICalEvent, error) {
+	lines, err := unfoldICal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		components []icalComponent
+		current    icalComponent
+		// Вложенные в VEVENT компоненты, например VALARM, пропускаются
+		nested     int
+		isCalendar bool
+	)
+
+	for _, line := range lines {
+		property, ok := parseICalProperty(line)
+		if !ok {
+			continue
+		}
+
+		switch {
+		case property.name == "BEGIN" && property.value == "VCALENDAR":
+			isCalendar = true
+		case property.name == "BEGIN" && property.value == "VEVENT":
+			current = make(icalComponent)
+		case property.name == "END" && property.value == "VEVENT":
+			if current != nil {
+				components = append(components, current)
+			}
+			current, nested = nil, 0
+		case current == nil:
+		case property.name == "BEGIN":
+			nested++
+		case property.name == "END":
+			nested--
+		case nested == 0:
+			current[property.name] = append(current[property.name], property)
+		}
+	}
+
+	if !isCalendar {
+		return nil, fmt.Errorf("ical: VCALENDAR not found")
+	}
+
+	// Измененные вхождения описаны отдельными VEVENT с RECURRENCE-ID и заменяют исходные
+	overridden := make(map[string]map[time.Time]bool)
+	for _, component := range components {
+		uid, _ := component.first("UID")
+		if recurrenceID, ok := component.first("RECURRENCE-ID"); ok {
+			if start, _, err := parseICalTime(recurrenceID, loc); err == nil {
+				if overridden[uid.value] == nil {
+					overridden[uid.value] = make(map[time.Time]bool)
+				}
+				overridden[uid.value][start.UTC()] = true
+			}
+		}
+	}
+
+	var events []ICalEvent
+	for _, component := range components {
+		if status, ok := component.first("STATUS"); ok && strings.EqualFold(status.value, "CANCELLED") {
+			continue
+		}
+		if transp, ok := component.first("TRANSP"); ok && strings.EqualFold(transp.value, "TRANSPARENT") {
+			continue
+		}
+
+		event, err := parseICalEvent(component, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		instances := []ICalEvent{event}
+		if _, ok := component.first("RECURRENCE-ID"); !ok {
+			instances = expandICalEvent(component, event, loc, from, to, overridden[event.UID])
+		}
+
+		for _, instance := range instances {
+			if instance.End.After(from) && instance.Start.Before(to) {
+				events = append(events, instance)
+			}
+		}
+	}
+
+	return events, nil
+}
+
+func unfoldICal(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+func parseICalProperty(line string) (icalProperty, bool) {
+	// Двоеточие внутри кавычек относится к значению параметра, а не отделяет значение свойства
+	quoted := false
+	colon := -1
+	for i, char := range line {
+		if char == '"' {
+			quoted = !quoted
+		}
+		if char == ':' && !quoted {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return icalProperty{}, false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	property := icalProperty{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string, len(parts)-1),
+		value:  line[colon+1:],
+	}
+	for _, param := range parts[1:] {
+		if key, value, ok := strings.Cut(param, "="); ok {
+			property.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+		}
+	}
+
+	return property, true
+}
+
+func parseICalEvent(component icalComponent, loc *time.Location) (ICalEvent, error) {
+	var event ICalEvent
+
+	if uid, ok := component.first("UID"); ok {
+		event.UID = uid.value
+	}
+	if summary, ok := component.first("SUMMARY"); ok {
+		event.Summary = unescapeICalText(summary.value)
+	}
+
+	dtstart, ok := component.first("DTSTART")
+	if !ok {
+		return ICalEvent{}, fmt.Errorf("ical: event %q has no DTSTART", event.UID)
+	}
+	start, allDay, err := parseICalTime(dtstart, loc)
+	if err != nil {
+		return ICalEvent{}, err
+	}
+	event.Start = start
+
+	switch {
+	case len(component["DTEND"]) > 0:
+		if event.End, _, err = parseICalTime(component["DTEND"][0], loc); err != nil {
+			return ICalEvent{}, err
+		}
+	case len(component["DURATION"]) > 0:
+		duration, err := parseICalDuration(component["DURATION"][0].value)
+		if err != nil {
+			return ICalEvent{}, err
+		}
+		event.End = start.Add(duration)
+	case allDay:
+		event.End = start.AddDate(0, 0, 1)
+	default:
+		event.End = start
+	}
+
+	if event.End.Before(event.Start) {
+		return ICalEvent{}, fmt.Errorf("ical: event %q ends before it starts", event.UID)
+	}
+
+	return event, nil
+}
+
+// expandICalEvent разворачивает событие по RRULE с from до to, пропуская EXDATE и измененные вхождения
+func expandICalEvent(component icalComponent, event ICalEvent, loc *time.Location, from, to time.Time, overridden map[time.Time]bool) []ICalEvent {
+	property, ok := component.first("RRULE")
+	if !ok {
+		return []ICalEvent{event}
+	}
+	rule, err := ParseRRule(property.value)
+	if err != nil {
+		return []ICalEvent{event}
+	}
+
+	excluded := make(map[time.Time]bool)
+	for _, exdate := range component["EXDATE"] {
+		for _, value := range strings.Split(exdate.value, ",") {
+			exdate.value = value
+			if start, _, err := parseICalTime(exdate, loc); err == nil {
+				excluded[start.UTC()] = true
+			}
+		}
+	}
+
+	// Вхождение начинается в то же местное время, что и первое, в том числе после перехода на летнее время
+	start := event.Start
+	duration := event.End.Sub(event.Start)
+
+	// Разворачивание начинается с вхождений, которые еще могут пересечь from, а не с DTSTART:
+	// у события многолетней давности иначе не хватило бы предела перебора периодов
+	var instances []ICalEvent
+	for _, day := range rule.Between(start, from.In(start.Location()).Add(-duration), to.In(start.Location())) {
+		instanceStart := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+		if excluded[instanceStart.UTC()] || overridden[instanceStart.UTC()] {
+			continue
+		}
+
+		instance := event
+		instance.Start, instance.End = instanceStart, instanceStart.Add(duration)
+		instances = append(instances, instance)
+	}
+
+	return instances
+}
+
+// parseICalTime разбирает DATE или DATE-TIME. Второе значение - true для дня без времени
+func parseICalTime(property icalProperty, loc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(property.value)
+
+	if property.params["VALUE"] == "DATE" || len(value) == len(icalDate) {
+		date, err := time.ParseInLocation(icalDate, value, loc)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("ical: bad date %q", value)
+		}
+		return date, true, nil
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		dateTime, err := time.Parse(icalDateTimeUTC, value)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("ical: bad date-time %q", value)
+		}
+		return dateTime, false, nil
+	}
+
+	zone := loc
+	if tzid := property.params["TZID"]; tzid != "" {
+		if tz, err := time.LoadLocation(tzid); err == nil {
+			zone = tz
+		}
+	}
+
+	dateTime, err := time.ParseInLocation(icalDateTime, value, zone)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("ical: bad date-time %q", value)
+	}
+
+	return dateTime, false, nil
+}
+
+// parseICalDuration разбирает длительность вида P1D, PT1H30M или P2W
+func parseICalDuration(value string) (time.Duration, error) {
+	rest, ok := strings.CutPrefix(strings.TrimPrefix(value, "+"), "P")
+	if !ok || rest == "" {
+		return 0, fmt.Errorf("ical: bad duration %q", value)
+	}
+
+	units := map[byte]time.Duration{
+		'W': 7 * 24 * time.Hour,
+		'D': 24 * time.Hour,
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+	}
+
+	var duration time.Duration
+	number := ""
+	for i := 0; i < len(rest); i++ {
+		char := rest[i]
+		switch {
+		case char == 'T':
+		case char >= '0' && char <= '9':
+			number += string(char)
+		default:
+			unit, ok := units[char]
+			n, err := strconv.Atoi(number)
+			if !ok || err != nil {
+				return 0, fmt.Errorf("ical: bad duration %q", value)
+			}
+			duration += time.Duration(n) * unit
+			number = ""
+		}
+	}
+
+	if number != "" {
+		return 0, fmt.Errorf("ical: bad duration %q", value)
+	}
+
+	return duration, nil
+}
@@ -0,0 +1,247 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+func icalCalendar(events ...string) string {
+	return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" + strings.Join(events, "") + "END:VCALENDAR\r\n"
+}
+
+func icalVEvent(lines ...string) string {
+	return "BEGIN:VEVENT\r\n" + strings.Join(lines, "\r\n") + "\r\nEND:VEVENT\r\n"
+}
+
+func formatICalEvents(events []ICalEvent) []string {
+	var result []string
+	for _, event := range events {
+		result = append(result, event.Summary+" "+event.Start.UTC().Format(icalDateTimeUTC)+"/"+event.End.UTC().Format(icalDateTimeUTC))
+	}
+
+	return result
+}
+
+func TestParseICal(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	from := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2030, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		src     string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "utc",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20300107T100000Z", "DTEND:20300107T110000Z")),
+			want: []string{"A 20300107T100000Z/20300107T110000Z"},
+		},
+		{
+			name: "time without zone is in loc",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20300107T100000", "DTEND:20300107T110000")),
+			want: []string{"A 20300107T070000Z/20300107T080000Z"},
+		},
+		{
+			name: "tzid",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART;TZID=Asia/Tokyo:20300107T100000", `DTEND;TZID="Asia/Tokyo":20300107T110000`)),
+			want: []string{"A 20300107T010000Z/20300107T020000Z"},
+		},
+		{
+			name: "unknown tzid falls back to loc",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART;TZID=Custom Zone:20300107T100000", "DTEND;TZID=Custom Zone:20300107T110000")),
+			want: []string{"A 20300107T070000Z/20300107T080000Z"},
+		},
+		{
+			name: "duration",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20300107T100000Z", "DURATION:PT1H30M")),
+			want: []string{"A 20300107T100000Z/20300107T113000Z"},
+		},
+		{
+			name: "all day lasts until the next day",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART;VALUE=DATE:20300107")),
+			want: []string{"A 20300106T210000Z/20300107T210000Z"},
+		},
+		{
+			name: "no end",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20300107T100000Z")),
+			want: []string{"A 20300107T100000Z/20300107T100000Z"},
+		},
+		{
+			name: "folded and escaped summary",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:Тренировка\\, зал", "  №1;\r\n\tвечер", "DTSTART:20300107T100000Z")),
+			want: []string{"Тренировка, зал №1;вечер 20300107T100000Z/20300107T100000Z"},
+		},
+		{
+			name: "nested alarm is ignored",
+			src: icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20300107T100000Z",
+				"BEGIN:VALARM", "TRIGGER:-PT15M", "DURATION:PT15M", "END:VALARM")),
+			want: []string{"A 20300107T100000Z/20300107T100000Z"},
+		},
+		{
+			name: "cancelled and transparent are skipped",
+			src: icalCalendar(
+				icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20300107T100000Z", "STATUS:CANCELLED"),
+				icalVEvent("UID:b", "SUMMARY:B", "DTSTART:20300107T100000Z", "TRANSP:TRANSPARENT"),
+			),
+		},
+		{
+			name: "events outside the range",
+			src: icalCalendar(
+				icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20291231T230000Z", "DTEND:20300101T000000Z"),
+				icalVEvent("UID:b", "SUMMARY:B", "DTSTART:20300401T000000Z", "DTEND:20300401T010000Z"),
+				icalVEvent("UID:c", "SUMMARY:C", "DTSTART:20291231T230000Z", "DTEND:20300101T010000Z"),
+			),
+			want: []string{"C 20291231T230000Z/20300101T010000Z"},
+		},
+		{
+			name: "rrule with exdate",
+			src: icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20300107T100000Z", "DTEND:20300107T110000Z",
+				"RRULE:FREQ=WEEKLY;COUNT=3", "EXDATE:20300114T100000Z")),
+			want: []string{"A 20300107T100000Z/20300107T110000Z", "A 20300121T100000Z/20300121T110000Z"},
+		},
+		{
+			name: "exdate list with tzid",
+			src: icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART;TZID=Europe/Moscow:20300107T100000", "DURATION:PT1H",
+				"RRULE:FREQ=WEEKLY;COUNT=4", "EXDATE;TZID=Europe/Moscow:20300114T100000,20300121T100000")),
+			want: []string{"A 20300107T070000Z/20300107T080000Z", "A 20300128T070000Z/20300128T080000Z"},
+		},
+		{
+			name: "recurrence-id replaces the occurrence",
+			src: icalCalendar(
+				icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20300107T100000Z", "DTEND:20300107T110000Z", "RRULE:FREQ=WEEKLY;COUNT=3"),
+				icalVEvent("UID:a", "SUMMARY:Moved", "RECURRENCE-ID:20300114T100000Z", "DTSTART:20300115T120000Z", "DTEND:20300115T130000Z"),
+			),
+			want: []string{"A 20300107T100000Z/20300107T110000Z", "A 20300121T100000Z/20300121T110000Z", "Moved 20300115T120000Z/20300115T130000Z"},
+		},
+		{
+			name: "cancelled recurrence-id removes the occurrence",
+			src: icalCalendar(
+				icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20300107T100000Z", "DTEND:20300107T110000Z", "RRULE:FREQ=WEEKLY;COUNT=3"),
+				icalVEvent("UID:a", "SUMMARY:A", "RECURRENCE-ID:20300114T100000Z", "DTSTART:20300114T100000Z", "STATUS:CANCELLED"),
+			),
+			want: []string{"A 20300107T100000Z/20300107T110000Z", "A 20300121T100000Z/20300121T110000Z"},
+		},
+		{
+			name: "rrule keeps local time across daylight saving",
+			src: icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART;TZID=America/New_York:20300304T090000", "DURATION:PT1H",
+				"RRULE:FREQ=WEEKLY;COUNT=2")),
+			want: []string{"A 20300304T140000Z/20300304T150000Z", "A 20300311T130000Z/20300311T140000Z"},
+		},
+		{
+			name: "infinite rrule stops at the range end",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20300330T100000Z", "DURATION:PT1H", "RRULE:FREQ=DAILY")),
+			want: []string{"A 20300330T100000Z/20300330T110000Z", "A 20300331T100000Z/20300331T110000Z"},
+		},
+		{
+			name: "rrule from decades ago still covers the range",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART:19800101T100000Z", "DURATION:PT1H", "RRULE:FREQ=DAILY;INTERVAL=45")),
+			want: []string{"A 20300108T100000Z/20300108T110000Z", "A 20300222T100000Z/20300222T110000Z"},
+		},
+		{
+			name: "occurrence started before the range overlaps it",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART:19991231T230000Z", "DURATION:PT2H", "RRULE:FREQ=MONTHLY")),
+			want: []string{"A 20291231T230000Z/20300101T010000Z", "A 20300131T230000Z/20300201T010000Z", "A 20300331T230000Z/20300401T010000Z"},
+		},
+		{
+			name: "unsupported rrule keeps the first occurrence",
+			src:  icalCalendar(icalVEvent("UID:a", "SUMMARY:A", "DTSTART:20300107T100000Z", "DURATION:PT1H", "RRULE:FREQ=YEARLY")),
+			want: []string{"A 20300107T100000Z/20300107T110000Z"},
+		},
+		{name: "no calendar", src: icalVEvent("UID:a", "DTSTART:20300107T100000Z"), wantErr: true},
+		{name: "no dtstart", src: icalCalendar(icalVEvent("UID:a", "SUMMARY:A")), wantErr: true},
+		{name: "bad date-time", src: icalCalendar(icalVEvent("UID:a", "DTSTART:2030-01-07T10:00:00Z")), wantErr: true},
+		{name: "bad duration", src: icalCalendar(icalVEvent("UID:a", "DTSTART:20300107T100000Z", "DURATION:1H")), wantErr: true},
+		{name: "end before start", src: icalCalendar(icalVEvent("UID:a", "DTSTART:20300107T100000Z", "DTEND:20300107T090000Z")), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := ParseICal(strings.NewReader(tt.src), moscow, from, to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseICal() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got := formatICalEvents(events); strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("ParseICal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseICalDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "PT1H30M", want: 90 * time.Minute},
+		{value: "+PT45S", want: 45 * time.Second},
+		{value: "P1D", want: 24 * time.Hour},
+		{value: "P1DT2H", want: 26 * time.Hour},
+		{value: "P2W", want: 14 * 24 * time.Hour},
+		{value: "", wantErr: true},
+		{value: "P", wantErr: true},
+		{value: "PT1H30", wantErr: true},
+		{value: "PT1X", wantErr: true},
+		{value: "1H", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseICalDuration(tt.value)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseICalDuration(%q) = (%v, %v), want (%v, wantErr %v)", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestWriteICal(t *testing.T) {
+	stamp := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	tokyo := time.FixedZone("JST", 9*60*60)
+	events := []ICalEvent{
+		{
+			UID:         "session-1@ladya",
+			Summary:     "Тренировка; зал, №1",
+			Description: strings.Repeat("Длинное описание тренировки. ", 5) + "\nВторая строка",
+			Start:       time.Date(2030, 1, 7, 19, 0, 0, 0, tokyo),
+			End:         time.Date(2030, 1, 7, 20, 30, 0, 0, tokyo),
+		},
+	}
+
+	src := string(WriteICal("Тренер, зал", events, stamp))
+
+	if !strings.HasSuffix(src, "END:VCALENDAR\r\n") {
+		t.Errorf("calendar must end with END:VCALENDAR and CRLF")
+	}
+	for _, want := range []string{"X-WR-CALNAME:Тренер\\, зал\r\n", "DTSTART:20300107T100000Z\r\n", "DTEND:20300107T113000Z\r\n", "DTSTAMP:20300101T000000Z\r\n"} {
+		if !strings.Contains(src, want) {
+			t.Errorf("calendar has no line %q", want)
+		}
+	}
+
+	folded := false
+	for _, line := range strings.Split(strings.TrimSuffix(src, "\r\n"), "\r\n") {
+		if len(line) > icalLineLength || !utf8.ValidString(line) {
+			t.Errorf("bad line %q of %d bytes", line, len(line))
+		}
+		folded = folded || strings.HasPrefix(line, " ")
+	}
+	if !folded {
+		t.Error("long description must be folded")
+	}
+
+	parsed, err := ParseICal(strings.NewReader(src), time.UTC, stamp, stamp.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 1 || parsed[0].UID != events[0].UID || parsed[0].Summary != events[0].Summary ||
+		!parsed[0].Start.Equal(events[0].Start) || !parsed[0].End.Equal(events[0].End) {
+		t.Errorf("round trip = %+v, want %+v", parsed, events)
+	}
+}
@@ -0,0 +1,179 @@
+package utils
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	keyFileExt    = ".pem"
+	minRSAKeySize = 2048
+)
+
+var (
+	ErrNoSigningKey = errors.New("no private key for signing jwt")
+	ErrUnknownKey   = errors.New("jwt is signed with unknown key")
+)
+
+// JWKS - набор публичных ключей в формате RFC 7517
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+type jwtKey struct {
+	id      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+type keySet struct {
+	keys    map[string]jwtKey
+	signing jwtKey
+	jwks    JWKS
+}
+
+// loadKeySet читает ключи `<kid>.pem` из директории. Файл с публичным ключом только проверяет подписи,
+// подписывает ключ signingKeyID, а если он не задан - приватный ключ с наибольшим kid
+func loadKeySet(dir, signingKeyID string) (keySet, error) {
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return keySet{}, err
+	}
+
+	set := keySet{keys: make(map[string]jwtKey), jwks: JWKS{Keys: []JWK{}}}
+
+	var kids []string
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != keyFileExt {
+			continue
+		}
+
+		key, err := readKey(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return keySet{}, fmt.Errorf("key %s: %w", file.Name(), err)
+		}
+		key.id = strings.TrimSuffix(file.Name(), keyFileExt)
+
+		jwk, err := publicJWK(key)
+		if err != nil {
+			return keySet{}, fmt.Errorf("key %s: %w", file.Name(), err)
+		}
+
+		set.keys[key.id] = key
+		set.jwks.Keys = append(set.jwks.Keys, jwk)
+		kids = append(kids, key.id)
+	}
+
+	if signingKeyID == "" {
+		sort.Sort(sort.Reverse(sort.StringSlice(kids)))
+		for _, kid := range kids {
+			if set.keys[kid].private != nil {
+				signingKeyID = kid
+				break
+			}
+		}
+	}
+
+	signing, ok := set.keys[signingKeyID]
+	if !ok || signing.private == nil {
+		return keySet{}, ErrNoSigningKey
+	}
+	set.signing = signing
+
+	return set, nil
+}
+
+func readKey(path string) (jwtKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return jwtKey{}, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return jwtKey{}, errors.New("no PEM block found")
+	}
+
+	var parsed any
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	default:
+		return jwtKey{}, fmt.Errorf("unsupported PEM block %s", block.Type)
+	}
+	if err != nil {
+		return jwtKey{}, err
+	}
+
+	switch key := parsed.(type) {
+	case *rsa.PrivateKey:
+		if key.N.BitLen() < minRSAKeySize {
+			return jwtKey{}, fmt.Errorf("RSA key must be at least %d bits", minRSAKeySize)
+		}
+		return jwtKey{method: jwt.SigningMethodRS256, private: key, public: &key.PublicKey}, nil
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeySize {
+			return jwtKey{}, fmt.Errorf("RSA key must be at least %d bits", minRSAKeySize)
+		}
+		return jwtKey{method: jwt.SigningMethodRS256, public: key}, nil
+	case ed25519.PrivateKey:
+		return jwtKey{method: jwt.SigningMethodEdDSA, private: key, public: key.Public()}, nil
+	case ed25519.PublicKey:
+		return jwtKey{method: jwt.SigningMethodEdDSA, public: key}, nil
+	}
+
+	return jwtKey{}, errors.New("only RSA and Ed25519 keys are supported")
+}
+
+func publicJWK(key jwtKey) (JWK, error) {
+	jwk := JWK{
+		KeyID:     key.id,
+		Use:       "sig",
+		Algorithm: key.method.Alg(),
+	}
+
+	switch public := key.public.(type) {
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	default:
+		return JWK{}, errors.New("only RSA and Ed25519 keys are supported")
+	}
+
+	return jwk, nil
+}
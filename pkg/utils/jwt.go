@@ -2,8 +2,10 @@ package utils
 
 import (
 	"BACKEND/pkg/config"
+	"fmt"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/spf13/viper"
+	"sync"
 	"time"
 )
 
@@ -13,53 +15,124 @@ const (
 	Admin   = "admin"
 )
 
+// Роли администраторов
+const (
+	ContentModerator  = "content_moderator"
+	TrainerOnboarding = "trainer_onboarding"
+	Support           = "support"
+)
+
+var AdminRoles = []string{ContentModerator, TrainerOnboarding, Support}
+
+func IsAdminRole(role string) bool {
+	for _, adminRole := range AdminRoles {
+		if role == adminRole {
+			return true
+		}
+	}
+
+	return false
+}
+
 type JWT interface {
-	CreateToken(id int, userType string) string
+	CreateToken(id int, userType, sessionID string) string
 	Authorize(tokenString string, access ...string) (UserClaim, bool, error)
+	JWKS() JWKS
+	ReloadKeys() error
 }
 
 type JWTUtil struct {
 	expireTimeOut time.Duration
-	secret        string
+	issuer        string
+	audience      string
+	keysDir       string
+	signingKeyID  string
+
+	mu   sync.RWMutex
+	keys keySet
 }
 
 func InitJWTUtil() JWT {
-	return &JWTUtil{
+	jwtUtil := &JWTUtil{
 		expireTimeOut: time.Duration(viper.GetInt(config.JWTExpirationTime)) * time.Minute,
-		secret:        viper.GetString(config.JWTSecret),
+		issuer:        viper.GetString(config.JWTIssuer),
+		audience:      viper.GetString(config.JWTAudience),
+		keysDir:       viper.GetString(config.JWTKeysDir),
+		signingKeyID:  viper.GetString(config.JWTSigningKeyID),
+	}
+
+	if err := jwtUtil.ReloadKeys(); err != nil {
+		panic(fmt.Sprintf("Failed to load jwt keys: %s", err.Error()))
 	}
+
+	return jwtUtil
 }
 
 type UserClaim struct {
 	jwt.RegisteredClaims
-	ID       int
-	UserType string
+	ID        int
+	UserType  string
+	SessionID string
+}
+
+// ReloadKeys перечитывает директорию ключей. При ошибке остаются ранее загруженные ключи
+func (j *JWTUtil) ReloadKeys() error {
+	keys, err := loadKeySet(j.keysDir, j.signingKeyID)
+	if err != nil {
+		return err
+	}
+
+	j.mu.Lock()
+	j.keys = keys
+	j.mu.Unlock()
+
+	return nil
 }
 
-func (j JWTUtil) CreateToken(id int, userType string) string {
-	expiredAt := time.Now().Add(j.expireTimeOut)
+// JWKS возвращает публичные ключи, включая те, что уже не подписывают, но еще проверяют токены
+func (j *JWTUtil) JWKS() JWKS {
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	return j.keys.jwks
+}
+
+func (j *JWTUtil) CreateToken(id int, userType, sessionID string) string {
+	j.mu.RLock()
+	signing := j.keys.signing
+	j.mu.RUnlock()
+
+	now := time.Now()
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, UserClaim{
+	token := jwt.NewWithClaims(signing.method, UserClaim{
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: &jwt.NumericDate{
-				Time: expiredAt,
-			},
+			Issuer:    j.issuer,
+			Subject:   fmt.Sprintf("%s:%d", userType, id),
+			Audience:  jwt.ClaimStrings{j.audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(j.expireTimeOut)),
+			IssuedAt:  jwt.NewNumericDate(now),
 		},
-		ID:       id,
-		UserType: userType,
+		ID:        id,
+		UserType:  userType,
+		SessionID: sessionID,
 	})
+	token.Header["kid"] = signing.id
 
-	signedString, _ := token.SignedString([]byte(j.secret))
+	signedString, _ := token.SignedString(signing.private)
 
 	return signedString
 }
 
-func (j JWTUtil) Authorize(tokenString string, accesses ...string) (UserClaim, bool, error) {
+func (j *JWTUtil) Authorize(tokenString string, accesses ...string) (UserClaim, bool, error) {
 	var claim UserClaim
 
-	token, err := jwt.ParseWithClaims(tokenString, &claim, func(token *jwt.Token) (interface{}, error) {
-		return []byte(j.secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &claim, j.keyFunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithIssuer(j.issuer),
+		jwt.WithAudience(j.audience),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
 	if err != nil {
 		return UserClaim{}, false, err
 	}
@@ -78,3 +151,17 @@ func (j JWTUtil) Authorize(tokenString string, accesses ...string) (UserClaim, b
 
 	return claim, match, nil
 }
+
+func (j *JWTUtil) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	j.mu.RLock()
+	key, ok := j.keys.keys[kid]
+	j.mu.RUnlock()
+
+	if !ok || key.method.Alg() != token.Method.Alg() {
+		return nil, ErrUnknownKey
+	}
+
+	return key.public, nil
+}
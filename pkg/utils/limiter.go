@@ -0,0 +1,146 @@
+package utils
+
+import (
+	"BACKEND/pkg/config"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+	"strings"
+	"time"
+)
+
+const (
+	loginFailKeyPrefix = "login:fail:"
+	loginLockKeyPrefix = "login:lock:"
+)
+
+type LoginLimiter interface {
+	Check(ctx context.Context, accountType, email, ip string) (time.Duration, error)
+	Fail(ctx context.Context, accountType, email, ip string) (LoginFailure, error)
+	Reset(ctx context.Context, accountType, email string) error
+}
+
+// LoginFailure описывает неудачную попытку входа и назначенные после нее блокировки
+type LoginFailure struct {
+	EmailAttempts int
+	IPAttempts    int
+	EmailLock     time.Duration
+	IPLock        time.Duration
+}
+
+type RedisLoginLimiter struct {
+	rdb              *redis.Client
+	maxEmailAttempts int
+	maxIPAttempts    int
+	window           time.Duration
+	lockoutTime      time.Duration
+	maxLockoutTime   time.Duration
+	dbResponseTime   time.Duration
+}
+
+func InitLoginLimiter() LoginLimiter {
+	return &RedisLoginLimiter{
+		rdb:              newRedisClient(),
+		maxEmailAttempts: viper.GetInt(config.LoginMaxAttempts),
+		maxIPAttempts:    viper.GetInt(config.LoginMaxIPAttempts),
+		window:           time.Duration(viper.GetInt(config.LoginAttemptsWindow)) * time.Minute,
+		lockoutTime:      time.Duration(viper.GetInt(config.LoginLockoutTime)) * time.Minute,
+		maxLockoutTime:   time.Duration(viper.GetInt(config.LoginMaxLockoutTime)) * time.Minute,
+		dbResponseTime:   time.Duration(viper.GetInt(config.DBResponseTime)) * time.Second,
+	}
+}
+
+func emailLimitKey(accountType, email string) string {
+	return fmt.Sprintf("email:%s:%s", accountType, strings.ToLower(strings.TrimSpace(email)))
+}
+
+func ipLimitKey(ip string) string {
+	return "ip:" + ip
+}
+
+// Check возвращает оставшееся время блокировки почты или IP, 0 - вход разрешен
+func (r RedisLoginLimiter) Check(ctx context.Context, accountType, email, ip string) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
+
+	pipe := r.rdb.Pipeline()
+	emailTTL := pipe.PTTL(ctx, loginLockKeyPrefix+emailLimitKey(accountType, email))
+	ipTTL := pipe.PTTL(ctx, loginLockKeyPrefix+ipLimitKey(ip))
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	// Для отсутствующего ключа PTTL возвращает отрицательное значение
+	return max(emailTTL.Val(), ipTTL.Val(), 0), nil
+}
+
+// Fail учитывает неудачную попытку. После превышения лимита каждая следующая попытка
+// удваивает время блокировки, пока оно не достигнет maxLockoutTime
+func (r RedisLoginLimiter) Fail(ctx context.Context, accountType, email, ip string) (LoginFailure, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
+
+	emailAttempts, emailLock, err := r.fail(ctx, emailLimitKey(accountType, email), r.maxEmailAttempts)
+	if err != nil {
+		return LoginFailure{}, err
+	}
+
+	ipAttempts, ipLock, err := r.fail(ctx, ipLimitKey(ip), r.maxIPAttempts)
+	if err != nil {
+		return LoginFailure{}, err
+	}
+
+	return LoginFailure{
+		EmailAttempts: emailAttempts,
+		IPAttempts:    ipAttempts,
+		EmailLock:     emailLock,
+		IPLock:        ipLock,
+	}, nil
+}
+
+// Reset сбрасывает счетчик почты после успешного входа. Счетчик IP истекает сам,
+// чтобы перебор разных почт с одного адреса не обнулялся входом в свой аккаунт
+func (r RedisLoginLimiter) Reset(ctx context.Context, accountType, email string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
+
+	return r.rdb.Del(ctx, loginFailKeyPrefix+emailLimitKey(accountType, email)).Err()
+}
+
+func (r RedisLoginLimiter) fail(ctx context.Context, key string, maxAttempts int) (int, time.Duration, error) {
+	attempts, err := r.rdb.Incr(ctx, loginFailKeyPrefix+key).Result()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var lock time.Duration
+	// 0 отключает ограничение
+	if maxAttempts > 0 && int(attempts) >= maxAttempts {
+		lock = r.backoff(int(attempts) - maxAttempts)
+	}
+
+	if lock <= 0 {
+		return int(attempts), 0, r.rdb.Expire(ctx, loginFailKeyPrefix+key, r.window).Err()
+	}
+
+	pipe := r.rdb.TxPipeline()
+	pipe.Set(ctx, loginLockKeyPrefix+key, attempts, lock)
+	// Счетчик живет дольше блокировки, иначе следующая блокировка не будет длиннее
+	pipe.Expire(ctx, loginFailKeyPrefix+key, r.window+lock)
+	if _, err = pipe.Exec(ctx); err != nil {
+		return 0, 0, err
+	}
+
+	return int(attempts), lock, nil
+}
+
+func (r RedisLoginLimiter) backoff(exceeded int) time.Duration {
+	lock := r.lockoutTime
+	for i := 0; i < exceeded && lock < r.maxLockoutTime; i++ {
+		lock *= 2
+	}
+
+	return min(lock, r.maxLockoutTime)
+}
@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"BACKEND/pkg/config"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/spf13/viper"
+	"mime"
+	"net"
+	"net/smtp"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SMTPDriver = "smtp"
+	LogDriver  = "log"
+)
+
+type Mailer interface {
+	Send(ctx context.Context, mail Mail) error
+}
+
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// InitMailer выбирает реализацию по MAIL_DRIVER, по умолчанию письма пишутся в файл
+func InitMailer() Mailer {
+	switch viper.GetString(config.MailDriver) {
+	case SMTPDriver:
+		return &SMTPMailer{
+			host:     viper.GetString(config.SMTPHost),
+			port:     viper.GetInt(config.SMTPPort),
+			username: viper.GetString(config.SMTPUser),
+			password: viper.GetString(config.SMTPPassword),
+			from:     viper.GetString(config.MailFrom),
+		}
+	default:
+		path := viper.GetString(config.MailLogPath)
+		if path == "" {
+			path = "log/mail.log"
+		}
+
+		return &LogMailer{
+			path: path,
+			from: viper.GetString(config.MailFrom),
+		}
+	}
+}
+
+type SMTPMailer struct {
+	host     string
+	port     int
+	username string
+	password string
+	from     string
+}
+
+func (s SMTPMailer) Send(ctx context.Context, mail Mail) error {
+	var dialer net.Dialer
+
+	conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%d", s.host, s.port))
+	if err != nil {
+		return err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err = conn.SetDeadline(deadline); err != nil {
+			conn.Close()
+			return err
+		}
+	}
+
+	client, err := smtp.NewClient(conn, s.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: s.host}); err != nil {
+			return err
+		}
+	}
+
+	if s.username != "" {
+		if err = client.Auth(smtp.PlainAuth("", s.username, s.password, s.host)); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(s.from); err != nil {
+		return err
+	}
+	if err = client.Rcpt(mail.To); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err = writer.Write(buildMessage(s.from, mail)); err != nil {
+		writer.Close()
+		return err
+	}
+
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// LogMailer не отправляет письма, а дописывает их в файл
+type LogMailer struct {
+	mu   sync.Mutex
+	path string
+	from string
+}
+
+func (l *LogMailer) Send(_ context.Context, mail Mail) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	file, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0660)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "----- %s -----\r\n%s\r\n", time.Now().Format(time.RFC3339), buildMessage(l.from, mail))
+
+	return err
+}
+
+func buildMessage(from string, mail Mail) []byte {
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("From: %s\r\n", from))
+	builder.WriteString(fmt.Sprintf("To: %s\r\n", mail.To))
+	builder.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", mail.Subject)))
+	builder.WriteString("MIME-Version: 1.0\r\n")
+	builder.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	builder.WriteString("\r\n")
+	builder.WriteString(mail.Body)
+
+	return []byte(builder.String())
+}
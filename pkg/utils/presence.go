@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"BACKEND/pkg/config"
+	"context"
+	"fmt"
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+	"strconv"
+	"time"
+)
+
+const presenceKeyPrefix = "presence:"
+
+// Presence хранит подключения аккаунтов к чату со всех экземпляров сервера.
+// Подключение, которое не продлевали дольше PRESENCE_TIME, считается отключенным
+type Presence interface {
+	Set(ctx context.Context, userType string, userID int, connID string) error
+	Delete(ctx context.Context, userType string, userID int, connID string) error
+	Online(ctx context.Context, userType string, userIDs []int) (map[int]bool, error)
+}
+
+type RedisPresence struct {
+	rdb            *redis.Client
+	presenceTime   time.Duration
+	dbResponseTime time.Duration
+}
+
+func InitPresence() Presence {
+	return &RedisPresence{
+		rdb:            newRedisClient(),
+		presenceTime:   time.Duration(viper.GetInt(config.PresenceTime)) * time.Second,
+		dbResponseTime: time.Duration(viper.GetInt(config.DBResponseTime)) * time.Second,
+	}
+}
+
+// presenceKey sorted set подключений аккаунта, score - время, до которого подключение в сети
+func presenceKey(userType string, userID int) string {
+	return fmt.Sprintf("%s%s:%d", presenceKeyPrefix, userType, userID)
+}
+
+func (r RedisPresence) Set(ctx context.Context, userType string, userID int, connID string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
+
+	key := presenceKey(userType, userID)
+	now := time.Now()
+
+	pipe := r.rdb.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.Add(r.presenceTime).Unix()), Member: connID})
+	// Подключения упавших экземпляров сервера
+	pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(now.Unix(), 10))
+	pipe.Expire(ctx, key, r.presenceTime)
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r RedisPresence) Delete(ctx context.Context, userType string, userID int, connID string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
+
+	return r.rdb.ZRem(ctx, presenceKey(userType, userID), connID).Err()
+}
+
+func (r RedisPresence) Online(ctx context.Context, userType string, userIDs []int) (map[int]bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
+
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+
+	pipe := r.rdb.Pipeline()
+	counts := make([]*redis.IntCmd, len(userIDs))
+	for i, userID := range userIDs {
+		counts[i] = pipe.ZCount(ctx, presenceKey(userType, userID), "("+now, "+inf")
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	online := make(map[int]bool, len(userIDs))
+	for i, userID := range userIDs {
+		online[userID] = counts[i].Val() > 0
+	}
+
+	return online, nil
+}
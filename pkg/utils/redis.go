@@ -13,14 +13,58 @@ import (
 	"time"
 )
 
+const (
+	refreshKeyPrefix  = "refresh:"
+	sessionKeyPrefix  = "session:"
+	sessionsKeyPrefix = "sessions:"
+	tokenKeyPrefix    = "token:"
+
+	// Чаще этого времени last_used_at сессии не перезаписывается
+	lastUsedPrecision = time.Minute
+	// Число попыток изменить сессию, которую параллельно изменяет другой запрос
+	sessionModifyAttempts = 5
+)
+
+// Назначения одноразовых токенов
+const (
+	VerificationToken = "verification"
+	ResetToken        = "reset"
+	TwoFactorToken    = "two_factor"
+)
+
 type Session interface {
-	Set(ctx context.Context, data SessionData) (string, error)
-	GetAndUpdate(ctx context.Context, refreshToken string) (string, SessionData, error)
+	Set(ctx context.Context, data SessionData) (string, SessionData, error)
+	GetAndUpdate(ctx context.Context, refreshToken, ip string) (string, SessionData, error)
+	Check(ctx context.Context, sessionID string) (SessionData, bool, error)
+	GetAll(ctx context.Context, userID int, userType string) ([]SessionData, error)
+	Delete(ctx context.Context, userID int, userType, sessionID string) error
+	DeleteAll(ctx context.Context, userID int, userType string) error
+	SetVerified(ctx context.Context, userID int, userType string) error
+	SetSuspended(ctx context.Context, userID int, userType string, isSuspended bool, until *time.Time) error
+
+	SetToken(ctx context.Context, purpose string, data SessionData, expiration time.Duration) (string, error)
+	PopToken(ctx context.Context, purpose, token string) (SessionData, error)
 }
 
 type SessionData struct {
-	UserID   int    `json:"id"`
-	UserType string `json:"type"`
+	ID           string    `json:"session_id"`
+	UserID       int       `json:"id"`
+	UserType     string    `json:"type"`
+	RefreshToken string    `json:"refresh_token"`
+	Device       string    `json:"device"`
+	IP           string    `json:"ip"`
+	IsVerified   bool      `json:"is_verified"`
+	Roles        []string  `json:"roles,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastUsedAt   time.Time `json:"last_used_at"`
+	// Аккаунт заблокирован администратором до SuspendedUntil или бессрочно
+	IsSuspended    bool       `json:"is_suspended,omitempty"`
+	SuspendedUntil *time.Time `json:"suspended_until,omitempty"`
+}
+
+// Suspended проверяет, действует ли блокировка аккаунта сейчас
+func (s SessionData) Suspended() bool {
+	return s.IsSuspended && (s.SuspendedUntil == nil || s.SuspendedUntil.After(time.Now()))
 }
 
 type RedisSession struct {
@@ -30,6 +74,14 @@ type RedisSession struct {
 }
 
 func InitRedisSession() Session {
+	return &RedisSession{
+		rdb:               newRedisClient(),
+		sessionExpiration: time.Duration(viper.GetInt(config.SessionSaveTime)) * time.Hour * 24,
+		dbResponseTime:    time.Duration(viper.GetInt(config.DBResponseTime)) * time.Second,
+	}
+}
+
+func newRedisClient() *redis.Client {
 	rdb := redis.NewClient(&redis.Options{
 		Addr: fmt.Sprintf("%s:%d",
 			viper.GetString(config.SessionHost),
@@ -47,63 +99,405 @@ func InitRedisSession() Session {
 		panic(fmt.Sprintf("Failed to connect to redis: %s", err.Error()))
 	}
 
-	return &RedisSession{
-		rdb:               rdb,
-		sessionExpiration: time.Duration(viper.GetInt(config.SessionSaveTime)) * time.Hour * 24,
-		dbResponseTime:    time.Duration(viper.GetInt(config.DBResponseTime)) * time.Second,
+	return rdb
+}
+
+func refreshKey(refreshToken string) string {
+	return refreshKeyPrefix + refreshToken
+}
+
+func sessionKey(sessionID string) string {
+	return sessionKeyPrefix + sessionID
+}
+
+func sessionsKey(userID int, userType string) string {
+	return fmt.Sprintf("%s%s:%d", sessionsKeyPrefix, userType, userID)
+}
+
+func tokenKey(purpose, token string) string {
+	return fmt.Sprintf("%s%s:%s", tokenKeyPrefix, purpose, token)
+}
+
+// Set создает новую сессию и возвращает refresh token к ней
+func (r RedisSession) Set(ctx context.Context, data SessionData) (string, SessionData, error) {
+	now := time.Now()
+
+	data.ID = uuid.New().String()
+	data.CreatedAt = now
+	data.LastUsedAt = now
+
+	refreshToken, err := r.save(ctx, data)
+	if err != nil {
+		return "", SessionData{}, err
 	}
+	data.RefreshToken = refreshToken
+
+	return refreshToken, data, nil
 }
 
-func (r RedisSession) Set(ctx context.Context, data SessionData) (string, error) {
+// GetAndUpdate заменяет refresh token сессии на новый, сохраняя id сессии
+func (r RedisSession) GetAndUpdate(ctx context.Context, refreshToken, ip string) (string, SessionData, error) {
+	ctxGet, cancelGet := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancelGet()
+
+	// GETDEL делает refresh token одноразовым даже при параллельных запросах
+	sessionID, err := r.rdb.GetDel(ctxGet, refreshKey(refreshToken)).Result()
+	if err != nil {
+		switch {
+		case errors.Is(err, redis.Nil):
+			return "", SessionData{}, errs.NeedToAuth
+		default:
+			return "", SessionData{}, err
+		}
+	}
+
+	userData, err := r.get(ctx, sessionID)
+	if err != nil {
+		return "", SessionData{}, err
+	}
+
+	userData.IP = ip
+	userData.LastUsedAt = time.Now()
+
+	newRefreshToken, err := r.save(ctx, userData)
+	if err != nil {
+		return "", SessionData{}, err
+	}
+	userData.RefreshToken = newRefreshToken
+
+	return newRefreshToken, userData, nil
+}
+
+// Check проверяет, что сессия не отозвана, и обновляет время ее последнего использования
+func (r RedisSession) Check(ctx context.Context, sessionID string) (SessionData, bool, error) {
+	userData, err := r.modify(ctx, sessionID, func(data *SessionData) bool {
+		if time.Since(data.LastUsedAt) < lastUsedPrecision {
+			return false
+		}
+		data.LastUsedAt = time.Now()
+		return true
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, errs.NeedToAuth):
+			return SessionData{}, false, nil
+		default:
+			return SessionData{}, false, err
+		}
+	}
+
+	return userData, true, nil
+}
+
+func (r RedisSession) GetAll(ctx context.Context, userID int, userType string) ([]SessionData, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
 	defer cancel()
 
+	sessionIDs, err := r.rdb.SMembers(ctx, sessionsKey(userID, userType)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sessionIDs) == 0 {
+		return []SessionData{}, nil
+	}
+
+	keys := make([]string, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, sessionKey(sessionID))
+	}
+
+	values, err := r.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]SessionData, 0, len(values))
+	var expired []any
+	for i, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			// Сессия истекла по TTL, а индекс остался
+			expired = append(expired, sessionIDs[i])
+			continue
+		}
+
+		var userData SessionData
+		if err := json.Unmarshal([]byte(raw), &userData); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, userData)
+	}
+
+	if len(expired) > 0 {
+		err = r.rdb.SRem(ctx, sessionsKey(userID, userType), expired...).Err()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sessions, nil
+}
+
+func (r RedisSession) Delete(ctx context.Context, userID int, userType, sessionID string) error {
+	ctxCheck, cancelCheck := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancelCheck()
+
+	isMember, err := r.rdb.SIsMember(ctxCheck, sessionsKey(userID, userType), sessionID).Result()
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return errs.ErrNoSession
+	}
+
+	return r.delete(ctx, userID, userType, []string{sessionID})
+}
+
+func (r RedisSession) DeleteAll(ctx context.Context, userID int, userType string) error {
+	ctxGet, cancelGet := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancelGet()
+
+	sessionIDs, err := r.rdb.SMembers(ctxGet, sessionsKey(userID, userType)).Result()
+	if err != nil {
+		return err
+	}
+
+	if len(sessionIDs) == 0 {
+		return nil
+	}
+
+	return r.delete(ctx, userID, userType, sessionIDs)
+}
+
+// SetVerified отмечает все сессии пользователя как подтвержденные, чтобы ограничения снимались без перелогина
+func (r RedisSession) SetVerified(ctx context.Context, userID int, userType string) error {
+	sessions, err := r.GetAll(ctx, userID, userType)
+	if err != nil {
+		return err
+	}
+
+	for _, userData := range sessions {
+		_, err = r.modify(ctx, userData.ID, func(data *SessionData) bool {
+			data.IsVerified = true
+			return true
+		})
+		// Сессию могли удалить после GetAll
+		if err != nil && !errors.Is(err, errs.NeedToAuth) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetSuspended блокирует или разблокирует все сессии аккаунта, чтобы блокировка действовала без перелогина
+func (r RedisSession) SetSuspended(ctx context.Context, userID int, userType string, isSuspended bool, until *time.Time) error {
+	sessions, err := r.GetAll(ctx, userID, userType)
+	if err != nil {
+		return err
+	}
+
+	for _, userData := range sessions {
+		_, err = r.modify(ctx, userData.ID, func(data *SessionData) bool {
+			data.IsSuspended = isSuspended
+			data.SuspendedUntil = until
+			return true
+		})
+		// Сессию могли удалить после GetAll
+		if err != nil && !errors.Is(err, errs.NeedToAuth) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetToken сохраняет одноразовый токен с заданным назначением
+func (r RedisSession) SetToken(ctx context.Context, purpose string, data SessionData, expiration time.Duration) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
+
+	tokenDataJSON, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+
+	token := uuid.New().String()
+	err = r.rdb.Set(ctx, tokenKey(purpose, token), tokenDataJSON, expiration).Err()
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// PopToken возвращает данные токена и сразу удаляет его
+func (r RedisSession) PopToken(ctx context.Context, purpose, token string) (SessionData, error) {
+	var tokenData SessionData
+
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
+
+	data, err := r.rdb.GetDel(ctx, tokenKey(purpose, token)).Result()
+	if err != nil {
+		switch {
+		case errors.Is(err, redis.Nil):
+			return SessionData{}, errs.ErrInvalidToken
+		default:
+			return SessionData{}, err
+		}
+	}
+
+	if err := json.Unmarshal([]byte(data), &tokenData); err != nil {
+		return SessionData{}, err
+	}
+
+	return tokenData, nil
+}
+
+// save записывает сессию под новым refresh token и продлевает ее и индекс пользователя
+func (r RedisSession) save(ctx context.Context, data SessionData) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
+
+	refreshToken := uuid.New().String()
+	data.RefreshToken = refreshToken
+
 	sessionDataJSON, err := json.Marshal(data)
 	if err != nil {
 		return "", err
 	}
 
-	key := uuid.New().String()
-	err = r.rdb.Set(ctx, key, sessionDataJSON, r.sessionExpiration).Err()
+	indexKey := sessionsKey(data.UserID, data.UserType)
+
+	_, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, refreshKey(refreshToken), data.ID, r.sessionExpiration)
+		pipe.Set(ctx, sessionKey(data.ID), sessionDataJSON, r.sessionExpiration)
+		pipe.SAdd(ctx, indexKey, data.ID)
+		pipe.Expire(ctx, indexKey, r.sessionExpiration)
+		return nil
+	})
 	if err != nil {
 		return "", err
 	}
 
-	return key, nil
+	return refreshToken, nil
 }
 
-func (r RedisSession) GetAndUpdate(ctx context.Context, refreshToken string) (string, SessionData, error) {
+func (r RedisSession) get(ctx context.Context, sessionID string) (SessionData, error) {
 	var userData SessionData
 
-	ctxGet, cancelGet := context.WithTimeout(ctx, r.dbResponseTime)
-	defer cancelGet()
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
 
-	data, err := r.rdb.Get(ctxGet, refreshToken).Result()
+	data, err := r.rdb.Get(ctx, sessionKey(sessionID)).Result()
 	if err != nil {
 		switch {
 		case errors.Is(err, redis.Nil):
-			return "", SessionData{}, errs.NeedToAuth
+			return SessionData{}, errs.NeedToAuth
 		default:
-			return "", SessionData{}, err
+			return SessionData{}, err
 		}
 	}
 
 	if err := json.Unmarshal([]byte(data), &userData); err != nil {
-		return "", SessionData{}, err
+		return SessionData{}, err
 	}
 
-	key, err := r.Set(ctx, userData)
-	if err != nil {
-		return "", SessionData{}, err
+	return userData, nil
+}
+
+// modify изменяет данные существующей сессии, не продлевая ее. Запись идет в MULTI под WATCH ключа сессии:
+// если сессию изменили между чтением и записью, change повторяется на свежих данных и не затирает чужое изменение,
+// а удаленная сессия не воскрешается. change возвращает false, если сессию не нужно перезаписывать
+func (r RedisSession) modify(ctx context.Context, sessionID string, change func(data *SessionData) bool) (SessionData, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
+
+	key := sessionKey(sessionID)
+
+	var userData SessionData
+	txf := func(tx *redis.Tx) error {
+		data, err := tx.Get(ctx, key).Result()
+		if err != nil {
+			switch {
+			case errors.Is(err, redis.Nil):
+				return errs.NeedToAuth
+			default:
+				return err
+			}
+		}
+
+		userData = SessionData{}
+		if err := json.Unmarshal([]byte(data), &userData); err != nil {
+			return err
+		}
+
+		if !change(&userData) {
+			return nil
+		}
+
+		sessionDataJSON, err := json.Marshal(userData)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.SetArgs(ctx, key, sessionDataJSON, redis.SetArgs{KeepTTL: true})
+			return nil
+		})
+		return err
+	}
+
+	for i := 0; i < sessionModifyAttempts; i++ {
+		err := r.rdb.Watch(ctx, txf, key)
+		if !errors.Is(err, redis.TxFailedErr) {
+			if err != nil {
+				return SessionData{}, err
+			}
+			return userData, nil
+		}
 	}
 
-	ctxDel, cancelDel := context.WithTimeout(ctx, r.dbResponseTime)
-	defer cancelDel()
+	return SessionData{}, redis.TxFailedErr
+}
+
+func (r RedisSession) delete(ctx context.Context, userID int, userType string, sessionIDs []string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.dbResponseTime)
+	defer cancel()
+
+	keys := make([]string, 0, len(sessionIDs))
+	members := make([]any, 0, len(sessionIDs))
+	for _, sessionID := range sessionIDs {
+		keys = append(keys, sessionKey(sessionID))
+		members = append(members, sessionID)
+	}
 
-	err = r.rdb.Del(ctxDel, refreshToken).Err()
+	values, err := r.rdb.MGet(ctx, keys...).Result()
 	if err != nil {
-		return "", SessionData{}, err
+		return err
+	}
+
+	// Вместе с сессией удаляется ее текущий refresh token
+	for _, value := range values {
+		raw, ok := value.(string)
+		if !ok {
+			continue
+		}
+
+		var userData SessionData
+		if err := json.Unmarshal([]byte(raw), &userData); err != nil {
+			return err
+		}
+		keys = append(keys, refreshKey(userData.RefreshToken))
 	}
 
-	return key, userData, nil
+	_, err = r.rdb.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, keys...)
+		pipe.SRem(ctx, sessionsKey(userID, userType), members...)
+		return nil
+	})
+
+	return err
 }
@@ -0,0 +1,340 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Поддерживаемое подмножество RRULE из RFC 5545: FREQ=DAILY|WEEKLY|MONTHLY, INTERVAL, BYDAY, COUNT, UNTIL.
+// Вхождения считаются с точностью до дня, время начала и конца задается отдельно
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+
+	RRuleMaxCount    = 1000
+	RRuleMaxInterval = 366
+
+	// Предел перебора периодов, чтобы правило без подходящих дней не зациклило разворачивание
+	rruleMaxPeriods = 10000
+)
+
+var rruleWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RRuleDay день недели из BYDAY. N - номер дня в месяце (1..5 или -1..-5 с конца), 0 - каждый такой день
+type RRuleDay struct {
+	Weekday time.Weekday
+	N       int
+}
+
+type RRule struct {
+	Freq     string
+	Interval int
+	ByDay    []RRuleDay
+	Count    int
+	// Until последний допустимый день включительно, нулевое значение - без ограничения
+	Until time.Time
+}
+
+// ParseRRule разбирает правило вида "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=12". Префикс "RRULE:" допускается
+func ParseRRule(value string) (RRule, error) {
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	rule := RRule{Interval: 1}
+
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" || seen[key] {
+			return RRule{}, fmt.Errorf("rrule: bad part %q", part)
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			if val != FreqDaily && val != FreqWeekly && val != FreqMonthly {
+				return RRule{}, fmt.Errorf("rrule: unsupported FREQ %q", val)
+			}
+			rule.Freq = val
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 || interval > RRuleMaxInterval {
+				return RRule{}, fmt.Errorf("rrule: bad INTERVAL %q", val)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 || count > RRuleMaxCount {
+				return RRule{}, fmt.Errorf("rrule: bad COUNT %q", val)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseRRuleUntil(val)
+			if err != nil {
+				return RRule{}, err
+			}
+			rule.Until = until
+		case "BYDAY":
+			for _, item := range strings.Split(val, ",") {
+				day, err := parseRRuleDay(item)
+				if err != nil {
+					return RRule{}, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "WKST":
+			// Неделя всегда начинается с понедельника
+			if val != "MO" {
+				return RRule{}, fmt.Errorf("rrule: unsupported WKST %q", val)
+			}
+		default:
+			return RRule{}, fmt.Errorf("rrule: unsupported part %q", key)
+		}
+	}
+
+	if rule.Freq == "" {
+		return RRule{}, fmt.Errorf("rrule: FREQ is required")
+	}
+	if rule.Count > 0 && !rule.Until.IsZero() {
+		return RRule{}, fmt.Errorf("rrule: COUNT and UNTIL are mutually exclusive")
+	}
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Freq != FreqMonthly {
+			return RRule{}, fmt.Errorf("rrule: numbered BYDAY is allowed only with FREQ=MONTHLY")
+		}
+	}
+
+	return rule, nil
+}
+
+func parseRRuleUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102", "20060102T150405Z", "20060102T150405"} {
+		until, err := time.Parse(layout, value)
+		if err == nil {
+			return dateOf(until), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("rrule: bad UNTIL %q", value)
+}
+
+func parseRRuleDay(value string) (RRuleDay, error) {
+	if len(value) < 2 {
+		return RRuleDay{}, fmt.Errorf("rrule: bad BYDAY %q", value)
+	}
+
+	weekday, ok := rruleWeekdays[value[len(value)-2:]]
+	if !ok {
+		return RRuleDay{}, fmt.Errorf("rrule: bad BYDAY %q", value)
+	}
+
+	day := RRuleDay{Weekday: weekday}
+	if prefix := value[:len(value)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 || n < -5 || n > 5 {
+			return RRuleDay{}, fmt.Errorf("rrule: bad BYDAY %q", value)
+		}
+		day.N = n
+	}
+
+	return day, nil
+}
+
+// String возвращает правило в каноническом виде, в котором оно хранится в базе
+func (r RRule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		days := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			days[i] = strings.ToUpper(day.Weekday.String()[:2])
+			if day.N != 0 {
+				days[i] = strconv.Itoa(day.N) + days[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if !r.Until.IsZero() {
+		parts = append(parts, "UNTIL="+r.Until.Format("20060102"))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Occurrences возвращает дни вхождений правила с началом в dtstart по день to включительно.
+// COUNT отсчитывается от dtstart, поэтому перебор всегда идет с начала серии
+func (r RRule) Occurrences(dtstart, to time.Time) []time.Time {
+	return r.Between(dtstart, dtstart, to)
+}
+
+// Between возвращает дни вхождений правила с началом в dtstart с дня from по день to включительно.
+// Правило без COUNT перебирается сразу с периода, в котором лежит from, поэтому давний dtstart
+// не упирается в предел перебора периодов. Правило с COUNT перебирается с dtstart, чтобы посчитать вхождения до from
+func (r RRule) Between(dtstart, from, to time.Time) []time.Time {
+	dtstart, from, limit := dateOf(dtstart), dateOf(from), dateOf(to)
+	if from.Before(dtstart) {
+		from = dtstart
+	}
+	if !r.Until.IsZero() && r.Until.Before(limit) {
+		limit = r.Until
+	}
+
+	first := 0
+	if r.Count == 0 {
+		first = r.periodOf(dtstart, from)
+	}
+
+	var result []time.Time
+	counted := 0
+	for period := first; period < first+rruleMaxPeriods; period++ {
+		periodStart, days := r.period(dtstart, period)
+		if periodStart.After(limit) {
+			break
+		}
+
+		for _, day := range days {
+			if day.Before(dtstart) {
+				continue
+			}
+			if day.After(limit) {
+				return result
+			}
+
+			counted++
+			if !day.Before(from) {
+				result = append(result, day)
+			}
+			if r.Count > 0 && counted == r.Count {
+				return result
+			}
+		}
+	}
+
+	return result
+}
+
+// Last возвращает день последнего вхождения конечного правила. ok = false для бесконечного правила
+// или если у правила нет ни одного вхождения
+func (r RRule) Last(dtstart time.Time) (time.Time, bool) {
+	var occurrences []time.Time
+	switch {
+	case r.Count > 0:
+		occurrences = r.Occurrences(dtstart, dateOf(dtstart).AddDate(rruleMaxPeriods/12, 0, 0))
+	case !r.Until.IsZero():
+		occurrences = r.Occurrences(dtstart, r.Until)
+	}
+
+	if len(occurrences) == 0 {
+		return time.Time{}, false
+	}
+
+	return occurrences[len(occurrences)-1], true
+}
+
+// IsOccurrence проверяет, что day - одно из вхождений правила
+func (r RRule) IsOccurrence(dtstart, day time.Time) bool {
+	occurrences := r.Occurrences(dtstart, day)
+
+	return len(occurrences) > 0 && occurrences[len(occurrences)-1].Equal(dateOf(day))
+}
+
+// period возвращает начало периода с номером n и подходящие дни внутри него по возрастанию
+func (r RRule) period(dtstart time.Time, n int) (time.Time, []time.Time) {
+	switch r.Freq {
+	case FreqDaily:
+		day := dtstart.AddDate(0, 0, n*r.Interval)
+		if len(r.ByDay) > 0 && !r.matchesWeekday(day) {
+			return day, nil
+		}
+		return day, []time.Time{day}
+
+	case FreqWeekly:
+		weekStart := dtstart.AddDate(0, 0, -(int(dtstart.Weekday())+6)%7+n*r.Interval*7)
+		var days []time.Time
+		for i := 0; i < 7; i++ {
+			day := weekStart.AddDate(0, 0, i)
+			if (len(r.ByDay) == 0 && day.Weekday() == dtstart.Weekday()) || r.matchesWeekday(day) {
+				days = append(days, day)
+			}
+		}
+		return weekStart, days
+
+	default:
+		monthStart := time.Date(dtstart.Year(), dtstart.Month()+time.Month(n*r.Interval), 1, 0, 0, 0, 0, time.UTC)
+		if len(r.ByDay) == 0 {
+			// Месяцы без такого числа пропускаются, как того требует RFC 5545
+			day := monthStart.AddDate(0, 0, dtstart.Day()-1)
+			if day.Month() != monthStart.Month() {
+				return monthStart, nil
+			}
+			return monthStart, []time.Time{day}
+		}
+
+		monthEnd := monthStart.AddDate(0, 1, -1)
+		var days []time.Time
+		for day := monthStart; !day.After(monthEnd); day = day.AddDate(0, 0, 1) {
+			if r.matchesMonthDay(day, monthEnd.Day()) {
+				days = append(days, day)
+			}
+		}
+		return monthStart, days
+	}
+}
+
+// periodOf возвращает номер периода, в котором лежит день day не раньше dtstart
+func (r RRule) periodOf(dtstart, day time.Time) int {
+	switch r.Freq {
+	case FreqDaily:
+		return int(day.Sub(dtstart).Hours()/24) / r.Interval
+	case FreqWeekly:
+		weekStart := dtstart.AddDate(0, 0, -(int(dtstart.Weekday())+6)%7)
+		return int(day.Sub(weekStart).Hours()/24) / 7 / r.Interval
+	default:
+		months := (day.Year()-dtstart.Year())*12 + int(day.Month()) - int(dtstart.Month())
+		return months / r.Interval
+	}
+}
+
+func (r RRule) matchesWeekday(day time.Time) bool {
+	for _, byDay := range r.ByDay {
+		if byDay.Weekday == day.Weekday() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r RRule) matchesMonthDay(day time.Time, daysInMonth int) bool {
+	for _, byDay := range r.ByDay {
+		if byDay.Weekday != day.Weekday() {
+			continue
+		}
+		switch {
+		case byDay.N == 0,
+			byDay.N > 0 && (day.Day()-1)/7+1 == byDay.N,
+			byDay.N < 0 && (daysInMonth-day.Day())/7+1 == -byDay.N:
+			return true
+		}
+	}
+
+	return false
+}
+
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,310 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func day(value string) time.Time {
+	parsed, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		panic(err)
+	}
+
+	return parsed
+}
+
+func days(values ...string) []time.Time {
+	result := make([]time.Time, len(values))
+	for i, value := range values {
+		result[i] = day(value)
+	}
+
+	return result
+}
+
+func TestParseRRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    RRule
+		wantErr bool
+	}{
+		{name: "daily", value: "FREQ=DAILY", want: RRule{Freq: FreqDaily, Interval: 1}},
+		{name: "prefix and lowercase", value: " rrule:freq=weekly;interval=2 ", want: RRule{Freq: FreqWeekly, Interval: 2}},
+		{
+			name:  "weekly by days with count",
+			value: "FREQ=WEEKLY;BYDAY=MO,WE,FR;COUNT=12",
+			want: RRule{Freq: FreqWeekly, Interval: 1, Count: 12,
+				ByDay: []RRuleDay{{Weekday: time.Monday}, {Weekday: time.Wednesday}, {Weekday: time.Friday}}},
+		},
+		{
+			name:  "monthly numbered days",
+			value: "FREQ=MONTHLY;BYDAY=2TU,-1FR,+1MO",
+			want: RRule{Freq: FreqMonthly, Interval: 1,
+				ByDay: []RRuleDay{{Weekday: time.Tuesday, N: 2}, {Weekday: time.Friday, N: -1}, {Weekday: time.Monday, N: 1}}},
+		},
+		{name: "until date", value: "FREQ=DAILY;UNTIL=20300115", want: RRule{Freq: FreqDaily, Interval: 1, Until: day("2030-01-15")}},
+		{name: "until date-time is cut to the day", value: "FREQ=DAILY;UNTIL=20300115T235959Z", want: RRule{Freq: FreqDaily, Interval: 1, Until: day("2030-01-15")}},
+		{name: "week start monday", value: "FREQ=WEEKLY;WKST=MO", want: RRule{Freq: FreqWeekly, Interval: 1}},
+		{name: "empty", value: "", wantErr: true},
+		{name: "no freq", value: "COUNT=3", wantErr: true},
+		{name: "unsupported freq", value: "FREQ=YEARLY", wantErr: true},
+		{name: "duplicate part", value: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+		{name: "unsupported part", value: "FREQ=DAILY;BYMONTH=1", wantErr: true},
+		{name: "zero interval", value: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{name: "interval too large", value: "FREQ=DAILY;INTERVAL=367", wantErr: true},
+		{name: "count too large", value: "FREQ=DAILY;COUNT=1001", wantErr: true},
+		{name: "count and until together", value: "FREQ=DAILY;COUNT=3;UNTIL=20300115", wantErr: true},
+		{name: "bad until", value: "FREQ=DAILY;UNTIL=2030-01-15", wantErr: true},
+		{name: "bad weekday", value: "FREQ=WEEKLY;BYDAY=XX", wantErr: true},
+		{name: "numbered day out of range", value: "FREQ=MONTHLY;BYDAY=6MO", wantErr: true},
+		{name: "zero numbered day", value: "FREQ=MONTHLY;BYDAY=0MO", wantErr: true},
+		{name: "numbered day with weekly", value: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{name: "other week start", value: "FREQ=WEEKLY;WKST=SU", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRRule(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRRule(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRRule(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRRuleString(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "FREQ=DAILY;INTERVAL=1", want: "FREQ=DAILY"},
+		{value: "rrule:byday=fr,mo;freq=weekly;interval=2", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=FR,MO"},
+		{value: "FREQ=MONTHLY;BYDAY=-1FR,+2TU;COUNT=5", want: "FREQ=MONTHLY;BYDAY=-1FR,2TU;COUNT=5"},
+		{value: "FREQ=DAILY;UNTIL=20300115T120000Z", want: "FREQ=DAILY;UNTIL=20300115"},
+	}
+
+	for _, tt := range tests {
+		rule, err := ParseRRule(tt.value)
+		if err != nil {
+			t.Fatalf("ParseRRule(%q): %v", tt.value, err)
+		}
+		if got := rule.String(); got != tt.want {
+			t.Errorf("String() of %q = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestRRuleOccurrences(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart string
+		to      string
+		want    []time.Time
+	}{
+		{
+			name: "daily", rule: "FREQ=DAILY", dtstart: "2030-01-30", to: "2030-02-02",
+			want: days("2030-01-30", "2030-01-31", "2030-02-01", "2030-02-02"),
+		},
+		{
+			name: "daily with interval", rule: "FREQ=DAILY;INTERVAL=3", dtstart: "2030-01-01", to: "2030-01-10",
+			want: days("2030-01-01", "2030-01-04", "2030-01-07", "2030-01-10"),
+		},
+		{
+			name: "daily filtered by weekdays", rule: "FREQ=DAILY;BYDAY=SA,SU", dtstart: "2030-01-01", to: "2030-01-13",
+			want: days("2030-01-05", "2030-01-06", "2030-01-12", "2030-01-13"),
+		},
+		{
+			name: "weekly on the start weekday", rule: "FREQ=WEEKLY", dtstart: "2030-01-02", to: "2030-01-23",
+			want: days("2030-01-02", "2030-01-09", "2030-01-16", "2030-01-23"),
+		},
+		{
+			name: "weekly by days skips days before start", rule: "FREQ=WEEKLY;BYDAY=MO,WE,FR", dtstart: "2030-01-02", to: "2030-01-09",
+			want: days("2030-01-02", "2030-01-04", "2030-01-07", "2030-01-09"),
+		},
+		{
+			name: "every other week", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU", dtstart: "2030-01-07", to: "2030-02-05",
+			want: days("2030-01-08", "2030-01-22", "2030-02-05"),
+		},
+		{
+			name: "monthly skips months without the 31st", rule: "FREQ=MONTHLY", dtstart: "2030-01-31", to: "2030-08-31",
+			want: days("2030-01-31", "2030-03-31", "2030-05-31", "2030-07-31", "2030-08-31"),
+		},
+		{
+			name: "monthly on the 29th in a non-leap year", rule: "FREQ=MONTHLY", dtstart: "2030-01-29", to: "2030-03-31",
+			want: days("2030-01-29", "2030-03-29"),
+		},
+		{
+			name: "second tuesday", rule: "FREQ=MONTHLY;BYDAY=2TU", dtstart: "2030-01-01", to: "2030-03-31",
+			want: days("2030-01-08", "2030-02-12", "2030-03-12"),
+		},
+		{
+			name: "last friday", rule: "FREQ=MONTHLY;BYDAY=-1FR", dtstart: "2030-01-01", to: "2030-03-31",
+			want: days("2030-01-25", "2030-02-22", "2030-03-29"),
+		},
+		{
+			name: "second to last monday", rule: "FREQ=MONTHLY;BYDAY=-2MO", dtstart: "2030-01-01", to: "2030-02-28",
+			want: days("2030-01-21", "2030-02-18"),
+		},
+		{
+			name: "fifth monday only in months that have one", rule: "FREQ=MONTHLY;BYDAY=5MO", dtstart: "2030-01-01", to: "2030-04-30",
+			want: days("2030-04-29"),
+		},
+		{
+			name: "every monday of the month", rule: "FREQ=MONTHLY;BYDAY=MO", dtstart: "2030-02-01", to: "2030-02-28",
+			want: days("2030-02-04", "2030-02-11", "2030-02-18", "2030-02-25"),
+		},
+		{
+			name: "count counts from the start", rule: "FREQ=DAILY;COUNT=3", dtstart: "2030-01-01", to: "2030-12-31",
+			want: days("2030-01-01", "2030-01-02", "2030-01-03"),
+		},
+		{
+			name: "count with an earlier limit", rule: "FREQ=DAILY;COUNT=10", dtstart: "2030-01-01", to: "2030-01-02",
+			want: days("2030-01-01", "2030-01-02"),
+		},
+		{
+			name: "until is inclusive", rule: "FREQ=WEEKLY;UNTIL=20300115", dtstart: "2030-01-01", to: "2030-12-31",
+			want: days("2030-01-01", "2030-01-08", "2030-01-15"),
+		},
+		{
+			name: "to before until", rule: "FREQ=DAILY;UNTIL=20300131", dtstart: "2030-01-01", to: "2030-01-02",
+			want: days("2030-01-01", "2030-01-02"),
+		},
+		{name: "to before start", rule: "FREQ=DAILY", dtstart: "2030-01-10", to: "2030-01-09"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := rule.Occurrences(day(tt.dtstart), day(tt.to)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Occurrences() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRRuleBetween(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart string
+		from    string
+		to      string
+		want    []time.Time
+	}{
+		{
+			name: "daily from decades ago", rule: "FREQ=DAILY", dtstart: "1990-01-01", from: "2030-01-30", to: "2030-02-01",
+			want: days("2030-01-30", "2030-01-31", "2030-02-01"),
+		},
+		{
+			name: "daily interval keeps alignment", rule: "FREQ=DAILY;INTERVAL=3", dtstart: "2030-01-01", from: "2030-01-05", to: "2030-01-14",
+			want: days("2030-01-07", "2030-01-10", "2030-01-13"),
+		},
+		{
+			name: "every other week from years ago", rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH", dtstart: "1980-01-03", from: "2030-01-01", to: "2030-01-20",
+			want: days("2030-01-07", "2030-01-10"),
+		},
+		{
+			name: "monthly skips short months after from", rule: "FREQ=MONTHLY", dtstart: "1999-01-31", from: "2030-02-01", to: "2030-05-31",
+			want: days("2030-03-31", "2030-05-31"),
+		},
+		{
+			name: "last friday from decades ago", rule: "FREQ=MONTHLY;BYDAY=-1FR", dtstart: "1970-01-01", from: "2030-01-26", to: "2030-03-01",
+			want: days("2030-02-22"),
+		},
+		{
+			name: "count is still counted from the start", rule: "FREQ=DAILY;COUNT=5", dtstart: "2030-01-01", from: "2030-01-04", to: "2030-12-31",
+			want: days("2030-01-04", "2030-01-05"),
+		},
+		{
+			name: "count ended before from", rule: "FREQ=WEEKLY;COUNT=2", dtstart: "2030-01-01", from: "2030-02-01", to: "2030-12-31",
+		},
+		{
+			name: "until before from", rule: "FREQ=DAILY;UNTIL=20300110", dtstart: "2030-01-01", from: "2030-01-11", to: "2030-12-31",
+		},
+		{
+			name: "from before start", rule: "FREQ=DAILY", dtstart: "2030-01-10", from: "2030-01-01", to: "2030-01-11",
+			want: days("2030-01-10", "2030-01-11"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := rule.Between(day(tt.dtstart), day(tt.from), day(tt.to)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Between() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRRuleLast(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart string
+		want    string
+		wantOK  bool
+	}{
+		{name: "count", rule: "FREQ=WEEKLY;BYDAY=MO,TH;COUNT=5", dtstart: "2030-01-01", want: "2030-01-17", wantOK: true},
+		{name: "until", rule: "FREQ=MONTHLY;UNTIL=20300601", dtstart: "2030-01-15", want: "2030-05-15", wantOK: true},
+		{name: "count over short months", rule: "FREQ=MONTHLY;COUNT=3", dtstart: "2030-01-31", want: "2030-05-31", wantOK: true},
+		{name: "infinite", rule: "FREQ=DAILY", dtstart: "2030-01-01"},
+		{name: "until before start", rule: "FREQ=DAILY;UNTIL=20291231", dtstart: "2030-01-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRRule(tt.rule)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, ok := rule.Last(day(tt.dtstart))
+			if ok != tt.wantOK || (ok && !got.Equal(day(tt.want))) {
+				t.Errorf("Last() = (%v, %v), want (%s, %v)", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRRuleIsOccurrence(t *testing.T) {
+	rule, err := ParseRRule("FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dtstart := day("2030-01-01")
+
+	tests := []struct {
+		day  string
+		want bool
+	}{
+		{day: "2030-01-01", want: true},
+		{day: "2030-01-03", want: true},
+		{day: "2030-01-10", want: true},
+		{day: "2030-01-02"},
+		// Пятое вхождение уже за пределами COUNT
+		{day: "2030-01-15"},
+		{day: "2029-12-27"},
+	}
+
+	for _, tt := range tests {
+		if got := rule.IsOccurrence(dtstart, day(tt.day)); got != tt.want {
+			t.Errorf("IsOccurrence(%s) = %v, want %v", tt.day, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package utils
+
+import (
+	"fmt"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"io"
+	"os"
+)
+
+const (
+	thumbnailQuality = 80
+	// Ограничение на размер исходного изображения в пикселях, чтобы небольшой файл не занял всю память при декодировании
+	maxImagePixels = 50_000_000
+)
+
+// MakeThumbnail уменьшает изображение так, чтобы большая сторона была не больше maxSide, и сохраняет его в JPEG.
+// Возвращает ошибку, если srcPath не изображение jpeg/png/gif
+func MakeThumbnail(srcPath, dstPath string, maxSide int) error {
+	src, err := os.Open(srcPath)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	config, _, err := image.DecodeConfig(src)
+	if err != nil {
+		return err
+	}
+
+	if config.Width*config.Height > maxImagePixels {
+		return fmt.Errorf("image %dx%d is too large", config.Width, config.Height)
+	}
+
+	if _, err = src.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	img, _, err := image.Decode(src)
+	if err != nil {
+		return err
+	}
+
+	thumbnail := downscale(img, maxSide)
+
+	dst, err := os.Create(dstPath)
+	if err != nil {
+		return err
+	}
+
+	if err = jpeg.Encode(dst, thumbnail, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		dst.Close()
+		os.Remove(dstPath)
+		return err
+	}
+
+	return dst.Close()
+}
+
+// downscale усредняет цвета исходных пикселей, попавших в каждый пиксель результата, прозрачные области заливаются белым
+func downscale(img image.Image, maxSide int) image.Image {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	newWidth, newHeight := width, height
+	if width >= height && width > maxSide {
+		newWidth, newHeight = maxSide, max(1, height*maxSide/width)
+	} else if height > width && height > maxSide {
+		newWidth, newHeight = max(1, width*maxSide/height), maxSide
+	}
+
+	result := image.NewRGBA(image.Rect(0, 0, newWidth, newHeight))
+
+	for y := 0; y < newHeight; y++ {
+		y0 := bounds.Min.Y + y*height/newHeight
+		y1 := max(y0+1, bounds.Min.Y+(y+1)*height/newHeight)
+
+		for x := 0; x < newWidth; x++ {
+			x0 := bounds.Min.X + x*width/newWidth
+			x1 := max(x0+1, bounds.Min.X+(x+1)*width/newWidth)
+
+			var r, g, b, a, count uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := img.At(sx, sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+					count++
+				}
+			}
+
+			// Цвета premultiplied, поэтому наложение на белый фон - прибавление недостающей непрозрачности
+			background := 0xffff - a/count
+
+			i := result.PixOffset(x, y)
+			result.Pix[i+0] = uint8((r/count + background) >> 8)
+			result.Pix[i+1] = uint8((g/count + background) >> 8)
+			result.Pix[i+2] = uint8((b/count + background) >> 8)
+			result.Pix[i+3] = 0xff
+		}
+	}
+
+	return result
+}
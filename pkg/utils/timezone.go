@@ -0,0 +1,24 @@
+package utils
+
+import (
+	"sync"
+	"time"
+)
+
+var locations sync.Map
+
+// LoadLocation как time.LoadLocation, но запоминает загруженные зоны: часовой пояс профиля
+// нужен почти каждому запросу расписания, а time.LoadLocation каждый раз читает базу зон с диска
+func LoadLocation(name string) (*time.Location, error) {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, err
+	}
+	locations.Store(name, loc)
+
+	return loc, nil
+}
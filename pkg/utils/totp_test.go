@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Секрет "12345678901234567890" из тестовых векторов RFC 6238 (SHA1) в base32
+const rfcTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCodeRFC6238(t *testing.T) {
+	key, err := totpEncoding.DecodeString(rfcTOTPSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Коды из RFC 6238, приложение B, усеченные до TOTPDigits цифр
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1111111111, want: "050471"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+		{unix: 20000000000, want: "353130"},
+	}
+
+	for _, tt := range tests {
+		if got := totpCode(key, tt.unix/TOTPPeriod); got != tt.want {
+			t.Errorf("totpCode(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	issued := time.Unix(1111111111, 0)
+	step := issued.Unix() / TOTPPeriod
+
+	tests := []struct {
+		name     string
+		secret   string
+		code     string
+		at       time.Time
+		wantStep int64
+		wantOK   bool
+	}{
+		{name: "same step", secret: rfcTOTPSecret, code: "050471", at: issued, wantStep: step, wantOK: true},
+		{name: "lowercase secret", secret: strings.ToLower(rfcTOTPSecret), code: "050471", at: issued, wantStep: step, wantOK: true},
+		{name: "previous step within skew", secret: rfcTOTPSecret, code: "050471", at: issued.Add(TOTPPeriod * time.Second), wantStep: step, wantOK: true},
+		{name: "next step within skew", secret: rfcTOTPSecret, code: "050471", at: issued.Add(-TOTPPeriod * time.Second), wantStep: step, wantOK: true},
+		{name: "outside skew", secret: rfcTOTPSecret, code: "050471", at: issued.Add(3 * TOTPPeriod * time.Second)},
+		{name: "wrong code", secret: rfcTOTPSecret, code: "000000", at: issued},
+		{name: "short code", secret: rfcTOTPSecret, code: "50471", at: issued},
+		{name: "bad secret", secret: "not base32!", code: "050471", at: issued},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotStep, gotOK := ValidateTOTP(tt.secret, tt.code, tt.at)
+			if gotOK != tt.wantOK || gotStep != tt.wantStep {
+				t.Errorf("ValidateTOTP() = (%d, %v), want (%d, %v)", gotStep, gotOK, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestIsTOTPCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{code: "123456", want: true},
+		{code: "000000", want: true},
+		{code: "12345"},
+		{code: "1234567"},
+		{code: "12a456"},
+		{code: "abcde-fghjk"},
+		{code: ""},
+	}
+
+	for _, tt := range tests {
+		if got := IsTOTPCode(tt.code); got != tt.want {
+			t.Errorf("IsTOTPCode(%q) = %v, want %v", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri, err := url.Parse(TOTPURI("LADYA", "coach@example.com", rfcTOTPSecret))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/LADYA:coach@example.com" {
+		t.Errorf("unexpected URI %s", uri)
+	}
+
+	query := uri.Query()
+	for key, want := range map[string]string{"secret": rfcTOTPSecret, "issuer": "LADYA", "digits": "6", "period": "30", "algorithm": "SHA1"} {
+		if got := query.Get(key); got != want {
+			t.Errorf("%s = %q, want %q", key, got, want)
+		}
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := totpEncoding.DecodeString(secret)
+	if err != nil || len(key) != totpSecretSize {
+		t.Errorf("secret %q decodes to %d bytes, err %v", secret, len(key), err)
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(codes) != RecoveryCodesCount {
+		t.Fatalf("got %d codes, want %d", len(codes), RecoveryCodesCount)
+	}
+
+	seen := make(map[string]bool, len(codes))
+	for _, code := range codes {
+		left, right, ok := strings.Cut(code, "-")
+		if !ok || len(left)+len(right) != recoveryCodeLength || strings.Trim(left+right, recoveryAlphabet) != "" {
+			t.Errorf("bad recovery code %q", code)
+		}
+		if seen[code] {
+			t.Errorf("duplicate recovery code %q", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	want := HashRecoveryCode("abcde-fghjk")
+
+	tests := []struct {
+		code string
+		same bool
+	}{
+		{code: "abcde-fghjk", same: true},
+		{code: "ABCDE-FGHJK", same: true},
+		{code: "abcdefghjk", same: true},
+		{code: "abcde-fghjm"},
+	}
+
+	for _, tt := range tests {
+		if got := HashRecoveryCode(tt.code) == want; got != tt.same {
+			t.Errorf("HashRecoveryCode(%q) matches = %v, want %v", tt.code, got, tt.same)
+		}
+	}
+}